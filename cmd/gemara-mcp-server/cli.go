@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/complytime/gemara-mcp-server/tools/info"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes of the offline subcommands
+const (
+	// exitFailure reports an invalid artifact, a failed store, or an error returned by a tool
+	exitFailure = 1
+	// exitUsage reports invalid arguments or flags
+	exitUsage = 2
+)
+
+// exitError carries the exit code of a failed subcommand. A nil err means the failure was
+// already reported on stdout or stderr and main only has to exit.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit status %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// usageErrorf reports invalid arguments with exitUsage
+func usageErrorf(format string, args ...any) error {
+	return &exitError{code: exitUsage, err: fmt.Errorf(format, args...)}
+}
+
+// usageArgs wraps a cobra argument validator so argument errors exit with exitUsage
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return &exitError{code: exitUsage, err: err}
+		}
+		return nil
+	}
+}
+
+// parseLayer parses a layer argument, accepting only layers between minLayer and maxLayer
+func parseLayer(arg string, minLayer, maxLayer int) (int, error) {
+	layer, err := strconv.Atoi(arg)
+	if err != nil || layer < minLayer || layer > maxLayer {
+		return 0, usageErrorf("invalid layer %q: must be %d-%d", arg, minLayer, maxLayer)
+	}
+	return layer, nil
+}
+
+// checkOutputFormat rejects output formats a subcommand does not support
+func checkOutputFormat(format string, supported ...string) error {
+	for _, s := range supported {
+		if format == s {
+			return nil
+		}
+	}
+	return usageErrorf("invalid output format %q: must be one of %v", format, supported)
+}
+
+// schemaClient fetches the Gemara CUE schemas for validation. Nil uses http.DefaultClient.
+var schemaClient *http.Client
+
+// newInfoTools creates the info tools for a CLI command
+func newInfoTools() (*info.GemaraInfoTools, error) {
+	return info.NewGemaraInfoToolsWithClient("", schemaClient)
+}
+
+// toolCaller is implemented by GemaraInfoTools and GemaraAuthoringTools
+type toolCaller interface {
+	CallTool(ctx context.Context, name string, arguments map[string]any) (*mcp.CallToolResult, error)
+}
+
+// runTool calls an MCP tool and prints its text result. Tool errors are printed to stderr and
+// exit with exitFailure.
+func runTool(tools toolCaller, name string, arguments map[string]any) error {
+	result, err := tools.CallTool(context.Background(), name, arguments)
+	if err != nil {
+		return &exitError{code: exitFailure, err: err}
+	}
+	text := resultText(result)
+	if result.IsError {
+		fmt.Fprintln(os.Stderr, text)
+		return &exitError{code: exitFailure}
+	}
+	fmt.Println(text)
+	return nil
+}
+
+// resultText joins the text content of a tool result
+func resultText(result *mcp.CallToolResult) string {
+	var text string
+	for _, content := range result.Content {
+		if textContent, ok := content.(mcp.TextContent); ok {
+			text += textContent.Text
+		}
+	}
+	return text
+}
+
+func init() {
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &exitError{code: exitUsage, err: err}
+	})
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/complytime/gemara-mcp-server/internal/schematest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// execute runs the command line with args and returns what it printed to stdout. Schemas are served
+// from schematest, and the flags of validate and store are reset first, since cobra keeps flag values
+// between runs.
+func execute(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	schemaClient = schematest.Client()
+	t.Cleanup(func() { schemaClient = nil })
+	validateLayer, validateOutput, validateAll = 0, "text", false
+	storeLayer, storeOutput, artifactsDir = 0, "text", ""
+
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = writer
+	var output bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(&output, reader)
+		close(done)
+	}()
+
+	rootCmd.SetArgs(args)
+	runErr := rootCmd.Execute()
+	os.Stdout = stdout
+	require.NoError(t, writer.Close())
+	<-done
+	return output.String(), runErr
+}
+
+// exitCode returns the exit code main uses for err
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exit *exitError
+	if errors.As(err, &exit) {
+		return exit.code
+	}
+	return exitFailure
+}
+
+func TestExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "layer2", "valid.yaml")
+	invalid := filepath.Join(dir, "layer2", "invalid.yaml")
+	unknown := filepath.Join(dir, "catalog.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(valid), 0755))
+	require.NoError(t, os.WriteFile(valid, []byte(validCatalog), 0644))
+	require.NoError(t, os.WriteFile(invalid, []byte(validCatalog+"unknown: true\n"), 0644))
+	require.NoError(t, os.WriteFile(unknown, []byte(validCatalog), 0644))
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "valid file", args: []string{"validate", valid}, want: 0},
+		{name: "invalid file", args: []string{"validate", valid, invalid}, want: exitFailure},
+		{name: "missing file", args: []string{"validate", filepath.Join(dir, "layer2", "missing.yaml")}, want: exitFailure},
+		{name: "no files", args: []string{"validate"}, want: exitUsage},
+		{name: "files with --all", args: []string{"validate", "--all", valid}, want: exitUsage},
+		{name: "unknown flag", args: []string{"validate", "--strict", valid}, want: exitUsage},
+		{name: "invalid output format", args: []string{"validate", "-o", "xml", valid}, want: exitUsage},
+		{name: "invalid layer", args: []string{"validate", "--layer", "7", valid}, want: exitUsage},
+		{name: "undetermined layer", args: []string{"validate", unknown}, want: exitUsage},
+		{name: "all with Layer 4", args: []string{"validate", "--all", "--layer", "4"}, want: exitUsage},
+		{name: "store Layer 4", args: []string{"store", "--layer", "4", valid}, want: exitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := execute(t, tt.args...)
+			assert.Equal(t, tt.want, exitCode(err), "error: %v", err)
+		})
+	}
+}
+
+func TestExitError(t *testing.T) {
+	err := usageErrorf("invalid layer %d", 7)
+	assert.Equal(t, exitUsage, exitCode(err))
+	assert.EqualError(t, err, "invalid layer 7")
+
+	reported := &exitError{code: exitFailure}
+	assert.EqualError(t, reported, "exit status 1")
+	assert.Nil(t, errors.Unwrap(reported))
+
+	_, err = parseLayer("5", 1, 3)
+	assert.Equal(t, exitUsage, exitCode(err))
+	assert.Equal(t, exitUsage, exitCode(checkOutputFormat("yaml", "text", "json")))
+	assert.NoError(t, checkOutputFormat("json", "text", "json"))
+}
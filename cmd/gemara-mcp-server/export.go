@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/complytime/gemara-mcp-server/tools/authoring"
+	"github.com/spf13/cobra"
+)
+
+// Export formats
+const (
+	exportOSCALJSON   = "oscal-json"
+	exportOSCALYAML   = "oscal-yaml"
+	exportControlsCSV = "controls-csv"
+	exportMappingsCSV = "mappings-csv"
+)
+
+var (
+	exportFormat     string
+	exportOSCALModel string
+	exportFile       string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export <layer> <id>",
+	Short: "Export a stored artifact as OSCAL or CSV",
+	Long: `Export a stored artifact using the same conversions as the export_oscal, export_controls_csv, and export_mappings_csv tools.
+Formats: oscal-json and oscal-yaml for Layer 2 Catalogs, Layer 3 Policies, and Layer 4 evaluation logs; controls-csv and mappings-csv for Layer 2 Catalogs.
+The export is printed to stdout unless --file is set.
+
+Exits with status 1 when the export fails and 2 on invalid arguments.`,
+	Args:          usageArgs(cobra.ExactArgs(2)),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		layer, err := parseLayer(args[0], consts.Layer2, consts.Layer4)
+		if err != nil {
+			return err
+		}
+		artifactID := args[1]
+		switch exportFormat {
+		case exportOSCALJSON, exportOSCALYAML:
+		case exportControlsCSV, exportMappingsCSV:
+			if layer != consts.Layer2 {
+				return usageErrorf("%s exports Layer 2 Catalogs only", exportFormat)
+			}
+		default:
+			return usageErrorf("invalid format %q: must be %s, %s, %s, or %s", exportFormat, exportOSCALJSON, exportOSCALYAML, exportControlsCSV, exportMappingsCSV)
+		}
+
+		tools, err := newAuthoringTools()
+		if err != nil {
+			return err
+		}
+
+		if exportFormat == exportControlsCSV || exportFormat == exportMappingsCSV {
+			toolName := "export_controls_csv"
+			if exportFormat == exportMappingsCSV {
+				toolName = "export_mappings_csv"
+			}
+			if exportFile == "" {
+				return runTool(tools, toolName, map[string]any{"catalog_id": artifactID})
+			}
+			result, err := tools.CallTool(cmd.Context(), toolName, map[string]any{"catalog_id": artifactID})
+			if err != nil {
+				return &exitError{code: exitFailure, err: err}
+			}
+			if result.IsError {
+				return &exitError{code: exitFailure, err: fmt.Errorf("%s", resultText(result))}
+			}
+			if err := os.WriteFile(exportFile, []byte(resultText(result)), 0644); err != nil {
+				return &exitError{code: exitFailure, err: fmt.Errorf("failed to write %s: %w", exportFile, err)}
+			}
+			fmt.Printf("Wrote %s\n", exportFile)
+			return nil
+		}
+
+		options := authoring.OSCALExportOptions{
+			Layer:      layer,
+			ArtifactID: artifactID,
+			Model:      exportOSCALModel,
+			Format:     "json",
+			OutputPath: exportFile,
+		}
+		if exportFormat == exportOSCALYAML {
+			options.Format = "yaml"
+		}
+		result, err := tools.ExportOSCAL(options)
+		if err != nil {
+			return &exitError{code: exitFailure, err: err}
+		}
+		if exportFile == "" {
+			fmt.Print(result.Document)
+			return nil
+		}
+		fmt.Print(result.ToMarkdown())
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVar(&artifactsDir, "artifacts-dir", "", "artifacts directory (default: ./artifacts or next to the executable)")
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", exportOSCALJSON, "export format (oscal-json/oscal-yaml/controls-csv/mappings-csv)")
+	exportCmd.Flags().StringVar(&exportOSCALModel, "oscal-model", "", "OSCAL model for Layer 3 policies (profile/component-definition)")
+	exportCmd.Flags().StringVar(&exportFile, "file", "", "file to write the export to (default: stdout)")
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/complytime/gemara-mcp-server/storage"
+	"github.com/complytime/gemara-mcp-server/tools/authoring"
+	"github.com/spf13/cobra"
+)
+
+var (
+	artifactsDir string
+	importOSCAL  authoring.OSCALImportOptions
+	importDryRun bool
+	importOutput string
+)
+
+var importOSCALCmd = &cobra.Command{
+	Use:   "import-oscal <file>",
+	Short: "Import an OSCAL catalog or profile as a Gemara artifact",
+	Long:  "Convert an OSCAL catalog (JSON or YAML) into a Layer 1 Guidance document or Layer 2 Catalog, or an OSCAL profile into a Layer 3 Policy, validate it, and store it in the artifacts directory.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkOutputFormat(importOutput, "text", "json"); err != nil {
+			return err
+		}
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read OSCAL file: %w", err)
+		}
+
+		tools, err := newAuthoringTools()
+		if err != nil {
+			return err
+		}
+		options := importOSCAL
+		options.Store = !importDryRun
+		result, err := tools.ImportOSCAL(data, options)
+		if err != nil {
+			return err
+		}
+
+		if importOutput == "json" {
+			output, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(output))
+		} else {
+			fmt.Print(result.ToMarkdown())
+		}
+		if !result.Valid {
+			return fmt.Errorf("imported %s failed CUE validation", result.Model)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importOSCALCmd)
+
+	importOSCALCmd.Flags().StringVar(&artifactsDir, "artifacts-dir", "", "artifacts directory (default: ./artifacts or next to the executable)")
+	importOSCALCmd.Flags().IntVar(&importOSCAL.Layer, "layer", consts.Layer2, "target layer for catalogs: 1 (Guidance) or 2 (Catalog)")
+	importOSCALCmd.Flags().StringVar(&importOSCAL.ID, "id", "", "artifact ID (default: derived from the OSCAL title)")
+	importOSCALCmd.Flags().StringVar(&importOSCAL.DocumentType, "document-type", "", "Layer 1 document type (Standard, Regulation, Best Practice, Framework)")
+	importOSCALCmd.Flags().StringVar(&importOSCAL.CatalogID, "catalog-id", "", "for profiles: ID of the stored catalog or guidance the profile imports")
+	importOSCALCmd.Flags().BoolVar(&importOSCAL.Overwrite, "overwrite", false, "replace a stored artifact with the same ID")
+	importOSCALCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "validate and print the converted artifact without storing it")
+	importOSCALCmd.Flags().StringVarP(&importOutput, "output", "o", "text", "output format (text/json)")
+}
+
+// newAuthoringTools creates the authoring tools for a CLI command, using --artifacts-dir when set.
+// Informational logging is turned down so command output stays readable.
+func newAuthoringTools() (*authoring.GemaraAuthoringTools, error) {
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})))
+
+	infoTools, err := newInfoTools()
+	if err != nil {
+		return nil, err
+	}
+	if artifactsDir == "" {
+		return authoring.NewGemaraAuthoringToolsWithInfo(nil, infoTools)
+	}
+	localStorage, err := storage.NewArtifactStorage(artifactsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize artifact storage at %s: %w", artifactsDir, err)
+	}
+	return authoring.NewGemaraAuthoringToolsWithInfo(localStorage, infoTools)
+}
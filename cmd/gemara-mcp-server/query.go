@@ -0,0 +1,96 @@
+package main
+
+import (
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/spf13/cobra"
+)
+
+var queryOutput string
+
+// queryTools names the MCP tools behind the list, get, and search subcommands for each layer,
+// and the argument get passes the ID as
+var queryTools = map[int]struct {
+	list, get, search, idArgument string
+}{
+	consts.Layer1: {list: "list_layer1_guidance", get: "get_layer1_guidance", search: "search_layer1_guidance", idArgument: "guidance_id"},
+	consts.Layer2: {list: "list_layer2_controls", get: "get_layer2_control", search: "search_layer2_controls", idArgument: "control_id"},
+	consts.Layer3: {list: "list_layer3_policies", get: "get_layer3_policy", search: "search_layer3_policies", idArgument: "policy_id"},
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list <layer>",
+	Short: "List stored artifacts of a layer",
+	Long: `List the stored Layer 1 Guidance documents, Layer 2 controls, or Layer 3 Policies, using the same logic as the list_layer1_guidance, list_layer2_controls, and list_layer3_policies tools.
+
+Exits with status 1 when the tool reports an error and 2 on invalid arguments.`,
+	Args:          usageArgs(cobra.ExactArgs(1)),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runQuery(args[0], func(layer int) (string, map[string]any) {
+			return queryTools[layer].list, map[string]any{}
+		})
+	},
+}
+
+var getCmd = &cobra.Command{
+	Use:   "get <layer> <id>",
+	Short: "Print a stored artifact",
+	Long: `Print a stored Layer 1 Guidance document, Layer 2 control, or Layer 3 Policy, using the same logic as the get_layer1_guidance, get_layer2_control, and get_layer3_policy tools. For Layer 2 the ID is a control ID. With --output original the stored file is printed byte-for-byte, in the YAML or JSON it was stored in; for Layer 2 that is the catalog that defines the control.
+
+Exits with status 1 when the artifact is not found and 2 on invalid arguments.`,
+	Args:          usageArgs(cobra.ExactArgs(2)),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runQuery(args[0], func(layer int) (string, map[string]any) {
+			return queryTools[layer].get, map[string]any{queryTools[layer].idArgument: args[1]}
+		}, "yaml", "json", "original")
+	},
+}
+
+var searchCmd = &cobra.Command{
+	Use:   "search <layer> <term>",
+	Short: "Search stored artifacts of a layer",
+	Long: `Search stored Layer 1 Guidance documents, Layer 2 controls, or Layer 3 Policies, using the same logic as the search_layer1_guidance, search_layer2_controls, and search_layer3_policies tools.
+
+Exits with status 1 when the tool reports an error and 2 on invalid arguments.`,
+	Args:          usageArgs(cobra.ExactArgs(2)),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runQuery(args[0], func(layer int) (string, map[string]any) {
+			return queryTools[layer].search, map[string]any{"search_term": args[1]}
+		})
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{listCmd, getCmd, searchCmd} {
+		rootCmd.AddCommand(cmd)
+		cmd.Flags().StringVar(&artifactsDir, "artifacts-dir", "", "artifacts directory (default: ./artifacts or next to the executable)")
+		cmd.Flags().StringVarP(&queryOutput, "output", "o", "yaml", "output format (yaml/json)")
+	}
+	getCmd.Flags().Lookup("output").Usage = "output format (yaml/json/original)"
+}
+
+// runQuery calls the query tool chosen for the layer argument. Output formats default to yaml and json.
+func runQuery(layerArg string, tool func(layer int) (string, map[string]any), formats ...string) error {
+	layer, err := parseLayer(layerArg, consts.Layer1, consts.Layer3)
+	if err != nil {
+		return err
+	}
+	if len(formats) == 0 {
+		formats = []string{"yaml", "json"}
+	}
+	if err := checkOutputFormat(queryOutput, formats...); err != nil {
+		return err
+	}
+	tools, err := newAuthoringTools()
+	if err != nil {
+		return err
+	}
+	name, arguments := tool(layer)
+	arguments["output_format"] = queryOutput
+	return runTool(tools, name, arguments)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/complytime/gemara-mcp-server/internal/site"
+	"github.com/spf13/cobra"
+)
+
+var (
+	renderOptions site.Options
+	renderOutput  string
+)
+
+var renderSiteCmd = &cobra.Command{
+	Use:   "render-site",
+	Short: "Render stored artifacts as a static documentation site",
+	Long:  "Render every stored Layer 1 Guidance document, Layer 2 Catalog, and Layer 3 Policy as cross-linked HTML or Markdown pages, with an index page, a family index, and a search index (search.json). Templates in --templates replace the embedded templates with the same file name.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tools, err := newAuthoringTools()
+		if err != nil {
+			return err
+		}
+		pages, err := tools.RenderSite(renderOutput, renderOptions)
+		if err != nil {
+			return err
+		}
+		for _, page := range pages {
+			fmt.Println(filepath.Join(renderOutput, filepath.FromSlash(page.Path)))
+		}
+		fmt.Printf("Rendered %d files to %s\n", len(pages), renderOutput)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(renderSiteCmd)
+
+	renderSiteCmd.Flags().StringVar(&artifactsDir, "artifacts-dir", "", "artifacts directory (default: ./artifacts or next to the executable)")
+	renderSiteCmd.Flags().StringVarP(&renderOutput, "output", "o", "site", "directory to write the site to")
+	renderSiteCmd.Flags().StringVar(&renderOptions.Format, "format", site.FormatHTML, "page format (html/markdown)")
+	renderSiteCmd.Flags().StringVar(&renderOptions.TemplateDir, "templates", "", "directory of templates overriding the embedded ones")
+	renderSiteCmd.Flags().StringVar(&renderOptions.Title, "title", "", "site title shown on every page (default: Gemara Artifacts)")
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/complytime/gemara-mcp-server/storage"
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+)
+
+var (
+	validateLayer  int
+	validateOutput string
+	validateAll    bool
+	storeLayer     int
+	storeOutput    string
+)
+
+// fileResult is the outcome of validating or storing one file
+type fileResult struct {
+	File   string   `json:"file"`
+	Layer  int      `json:"layer"`
+	Valid  bool     `json:"valid"`
+	Stored bool     `json:"stored,omitempty"`
+	ID     string   `json:"id,omitempty"`
+	Error  string   `json:"error,omitempty"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate <file>... | validate --all",
+	Short: "Validate artifact files against the Gemara CUE schemas",
+	Long: `Validate YAML or JSON artifact files against the Gemara CUE schema of their layer, using the same validation as the validate_gemara_yaml tool.
+The layer is taken from --layer, or from a parent directory named layer1 to layer4 as in the artifacts directory. Use - to read from stdin.
+
+With --all, every artifact in the artifacts directory is validated instead, using the same checks as the validate_stored_artifacts tool:
+schema, references, and fidelity, plus files in the layer directories that could not be indexed. --layer limits the check to one layer.
+--all covers layers 1 to 3 only, because storage does not index Layer 4 evaluation logs; validate those by path instead,
+for example: gemara-mcp-server validate artifacts/layer4/*.yaml
+
+Exits with status 1 when any file is invalid or could not be indexed and 2 on invalid arguments.`,
+	Args: usageArgs(func(cmd *cobra.Command, args []string) error {
+		if validateAll {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	}),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkOutputFormat(validateOutput, "text", "json"); err != nil {
+			return err
+		}
+		if validateAll {
+			return validateStored()
+		}
+		if validateLayer != 0 && (validateLayer < consts.MinLayer || validateLayer > consts.MaxLayer) {
+			return usageErrorf("invalid layer %d: must be %d-%d", validateLayer, consts.MinLayer, consts.MaxLayer)
+		}
+		infoTools, err := newInfoTools()
+		if err != nil {
+			return err
+		}
+
+		var results []fileResult
+		failed := false
+		for _, file := range args {
+			layer, err := fileLayer(file, validateLayer)
+			if err != nil {
+				return err
+			}
+			result := fileResult{File: file, Layer: layer}
+			content, err := readArtifactFile(file)
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				validation := infoTools.PerformCUEValidation(string(content), layer)
+				result.Valid = validation.Valid
+				result.Error = validation.Error
+				result.Errors = validation.Errors
+			}
+			failed = failed || !result.Valid
+			results = append(results, result)
+		}
+
+		if err := printFileResults(results, validateOutput); err != nil {
+			return err
+		}
+		if failed {
+			return &exitError{code: exitFailure}
+		}
+		return nil
+	},
+}
+
+var storeCmd = &cobra.Command{
+	Use:   "store <file>...",
+	Short: "Validate and store artifact files in the artifacts directory",
+	Long: `Validate YAML or JSON artifact files and store them in the artifacts directory, using the same logic as the store_layerN_yaml tools.
+Files that fail validation are not stored. The layer is taken from --layer, or from a parent directory named layer1 to layer3.
+
+Exits with status 1 when any file could not be stored and 2 on invalid arguments.`,
+	Args:          usageArgs(cobra.MinimumNArgs(1)),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkOutputFormat(storeOutput, "text", "json"); err != nil {
+			return err
+		}
+		if storeLayer != 0 && (storeLayer < consts.Layer1 || storeLayer > consts.Layer3) {
+			return usageErrorf("invalid layer %d: store supports layers 1-3", storeLayer)
+		}
+		layers := make([]int, len(args))
+		for i, file := range args {
+			layer, err := fileLayer(file, storeLayer)
+			if err != nil {
+				return err
+			}
+			if layer > consts.Layer3 {
+				return usageErrorf("cannot store %s: store supports layers 1-3", file)
+			}
+			layers[i] = layer
+		}
+		tools, err := newAuthoringTools()
+		if err != nil {
+			return err
+		}
+
+		var results []fileResult
+		failed := false
+		for i, file := range args {
+			result := fileResult{File: file, Layer: layers[i]}
+			content, err := readArtifactFile(file)
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				contentArgument := "yaml_content"
+				if storage.DetectFormat(content) == storage.FormatJSON {
+					contentArgument = "json_content"
+				}
+				toolResult, err := tools.CallTool(cmd.Context(), fmt.Sprintf("store_layer%d_yaml", layers[i]), map[string]any{contentArgument: string(content)})
+				switch {
+				case err != nil:
+					result.Error = err.Error()
+				case toolResult.IsError:
+					result.Error = resultText(toolResult)
+				default:
+					result.Valid = true
+					result.Stored = true
+					result.ID = artifactID(content)
+				}
+			}
+			failed = failed || !result.Stored
+			results = append(results, result)
+		}
+
+		if err := printFileResults(results, storeOutput); err != nil {
+			return err
+		}
+		if failed {
+			return &exitError{code: exitFailure}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(storeCmd)
+
+	validateCmd.Flags().IntVar(&validateLayer, "layer", 0, "layer of the files (default: from a layerN parent directory)")
+	validateCmd.Flags().StringVarP(&validateOutput, "output", "o", "text", "output format (text/json)")
+	validateCmd.Flags().BoolVar(&validateAll, "all", false, "validate every artifact in the artifacts directory")
+	validateCmd.Flags().StringVar(&artifactsDir, "artifacts-dir", "", "artifacts directory for --all (default: ./artifacts or next to the executable)")
+
+	storeCmd.Flags().StringVar(&artifactsDir, "artifacts-dir", "", "artifacts directory (default: ./artifacts or next to the executable)")
+	storeCmd.Flags().IntVar(&storeLayer, "layer", 0, "layer of the files (default: from a layerN parent directory)")
+	storeCmd.Flags().StringVarP(&storeOutput, "output", "o", "text", "output format (text/json)")
+}
+
+// validateStored validates every stored artifact for --all
+func validateStored() error {
+	if validateLayer != 0 && (validateLayer < consts.Layer1 || validateLayer > consts.Layer3) {
+		return usageErrorf("invalid layer %d: --all supports layers 1-3; validate Layer 4 files by path", validateLayer)
+	}
+	tools, err := newAuthoringTools()
+	if err != nil {
+		return err
+	}
+	report, err := tools.ValidateStoredArtifacts(validateLayer)
+	if err != nil {
+		return &exitError{code: exitFailure, err: err}
+	}
+
+	if validateOutput == "json" {
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(output))
+	} else {
+		fmt.Print(report.ToMarkdown())
+	}
+	if report.Failed() {
+		return &exitError{code: exitFailure}
+	}
+	return nil
+}
+
+// fileLayer returns the layer flag if set, otherwise the layer of a layerN parent directory
+func fileLayer(file string, flagLayer int) (int, error) {
+	if flagLayer != 0 {
+		return flagLayer, nil
+	}
+	if file != "-" {
+		if abs, err := filepath.Abs(file); err == nil {
+			for dir := filepath.Dir(abs); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+				if name := filepath.Base(dir); strings.HasPrefix(name, "layer") {
+					if layer, err := strconv.Atoi(strings.TrimPrefix(name, "layer")); err == nil && layer >= consts.MinLayer && layer <= consts.MaxLayer {
+						return layer, nil
+					}
+				}
+			}
+		}
+	}
+	return 0, usageErrorf("cannot determine the layer of %s: use --layer", file)
+}
+
+// readArtifactFile reads a file, or stdin for -
+func readArtifactFile(file string) ([]byte, error) {
+	if file == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(file)
+}
+
+// artifactID returns the metadata.id of artifact content
+func artifactID(content []byte) string {
+	var artifact struct {
+		Metadata struct {
+			ID string `yaml:"id"`
+		} `yaml:"metadata"`
+	}
+	if err := yaml.Unmarshal(content, &artifact); err != nil {
+		return ""
+	}
+	return artifact.Metadata.ID
+}
+
+// printFileResults prints validation or store results as text or JSON
+func printFileResults(results []fileResult, format string) error {
+	if format == "json" {
+		output, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+	for _, result := range results {
+		switch {
+		case result.Stored:
+			fmt.Printf("✅ %s: stored Layer %d artifact %s\n", result.File, result.Layer, result.ID)
+		case result.Valid:
+			fmt.Printf("✅ %s: valid Layer %d artifact\n", result.File, result.Layer)
+		default:
+			fmt.Printf("❌ %s: Layer %d\n", result.File, result.Layer)
+			if result.Error != "" {
+				fmt.Printf("   %s\n", strings.ReplaceAll(strings.TrimSpace(result.Error), "\n", "\n   "))
+			}
+			for _, err := range result.Errors {
+				fmt.Printf("   - %s\n", err)
+			}
+		}
+	}
+	return nil
+}
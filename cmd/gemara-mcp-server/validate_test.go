@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/complytime/gemara-mcp-server/tools/authoring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validCatalog is a Layer 2 catalog that is valid against the schemas in schematest
+const validCatalog = `metadata:
+  id: cli-catalog
+  description: A catalog stored from the command line
+  author:
+    id: test
+    name: TEST
+    type: Human
+title: CLI Catalog
+families:
+  - id: DATA
+    title: Data
+    description: Data protection
+controls:
+  - id: DATA-1
+    title: Test Control
+    objective: Test control objective
+    family: DATA
+    assessment-requirements:
+      - id: DATA-1.1
+        text: Test requirement
+        applicability:
+          - all
+`
+
+func TestFileLayer(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		flagLayer int
+		want      int
+		wantErr   bool
+	}{
+		{name: "layer directory", file: filepath.Join("artifacts", "layer2", "catalog.yaml"), want: 2},
+		{name: "nested in a layer directory", file: filepath.Join("layer3", "drafts", "policy.json"), want: 3},
+		{name: "Layer 4 directory", file: filepath.Join("layer4", "log.yaml"), want: 4},
+		{name: "flag wins over the directory", file: filepath.Join("layer1", "catalog.yaml"), flagLayer: 2, want: 2},
+		{name: "flag for stdin", file: "-", flagLayer: 1, want: 1},
+		{name: "stdin without flag", file: "-", wantErr: true},
+		{name: "layer directory out of range", file: filepath.Join("layer5", "catalog.yaml"), wantErr: true},
+		{name: "directory that is not a layer", file: filepath.Join("layers", "catalog.yaml"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layer, err := fileLayer(tt.file, tt.flagLayer)
+			if tt.wantErr {
+				assert.Equal(t, exitUsage, exitCode(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, layer)
+		})
+	}
+}
+
+func TestValidateJSONOutput(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "layer2", "valid.yaml")
+	invalid := filepath.Join(dir, "layer2", "invalid.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(valid), 0755))
+	require.NoError(t, os.WriteFile(valid, []byte(validCatalog), 0644))
+	require.NoError(t, os.WriteFile(invalid, []byte(validCatalog+"unknown: true\n"), 0644))
+
+	output, err := execute(t, "validate", "-o", "json", valid, invalid)
+	assert.Equal(t, exitFailure, exitCode(err))
+	var results []fileResult
+	require.NoError(t, json.Unmarshal([]byte(output), &results), output)
+	require.Len(t, results, 2)
+	assert.Equal(t, fileResult{File: valid, Layer: 2, Valid: true}, results[0])
+	assert.Equal(t, invalid, results[1].File)
+	assert.False(t, results[1].Valid)
+	assert.Contains(t, results[1].Error, "unknown")
+}
+
+func TestStoreJSONOutput(t *testing.T) {
+	dir := t.TempDir()
+	artifacts := filepath.Join(dir, "artifacts")
+	file := filepath.Join(dir, "layer2", "catalog.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+	require.NoError(t, os.WriteFile(file, []byte(validCatalog), 0644))
+
+	output, err := execute(t, "store", "--artifacts-dir", artifacts, "-o", "json", file)
+	require.NoError(t, err)
+	var results []fileResult
+	require.NoError(t, json.Unmarshal([]byte(output), &results), output)
+	assert.Equal(t, []fileResult{{File: file, Layer: 2, Valid: true, Stored: true, ID: "cli-catalog"}}, results)
+	assert.FileExists(t, filepath.Join(artifacts, "layer2", "cli-catalog.yaml"))
+
+	// The stored catalog passes the checks of validate --all
+	output, err = execute(t, "validate", "--all", "--artifacts-dir", artifacts, "-o", "json")
+	require.NoError(t, err)
+	var report authoring.StoreValidationReport
+	require.NoError(t, json.Unmarshal([]byte(output), &report), output)
+	require.Len(t, report.Artifacts, 1)
+	assert.Equal(t, "cli-catalog", report.Artifacts[0].ArtifactID)
+	assert.Empty(t, report.Unindexed)
+
+	// A file without metadata.id is not stored
+	require.NoError(t, os.WriteFile(file, []byte("title: No Metadata\n"), 0644))
+	output, err = execute(t, "store", "--artifacts-dir", artifacts, "-o", "json", file)
+	assert.Equal(t, exitFailure, exitCode(err))
+	var failed []fileResult
+	require.NoError(t, json.Unmarshal([]byte(output), &failed), output)
+	require.Len(t, failed, 1)
+	assert.False(t, failed[0].Stored)
+	assert.Contains(t, failed[0].Error, "metadata.id is required")
+}
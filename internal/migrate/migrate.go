@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package migrate upgrades Gemara artifacts written against older schema versions. A migration is
+// an ordered list of steps between two versions; each step rewrites one part of the layout and
+// records what it changed, so every transformation can be reviewed.
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/complytime/gemara-mcp-server/internal/yamldoc"
+	"github.com/goccy/go-yaml"
+)
+
+// Schema versions known to the migration engine
+const (
+	// Legacy is the layout of artifacts written before v0.17: controls and guidelines nested under
+	// control-families and categories, the title in metadata, a plain-text author, and policies
+	// built from guidance-references and control-references
+	Legacy = "legacy"
+	// Current is the schema version of the gemara module this server is built against
+	Current = "v0.17"
+)
+
+// Step is a single transformation of a migration
+type Step struct {
+	Name        string
+	Description string
+	// Layers the step applies to; empty means every layer
+	Layers []int
+	// detect reports whether a document still uses the layout the step upgrades; the step only
+	// runs when it does. Steps without detect always run and do not identify a version.
+	detect func(layer int, root yaml.MapSlice) bool
+	apply  func(layer int, root yaml.MapSlice, r *recorder) yaml.MapSlice
+}
+
+// Migration upgrades artifacts from one schema version to the next
+type Migration struct {
+	From  string
+	To    string
+	Steps []Step
+}
+
+// Change is a single transformation applied to an artifact
+type Change struct {
+	Step   string `json:"step"`
+	Path   string `json:"path"`
+	Detail string `json:"detail"`
+}
+
+// Result is a migrated artifact with the changes made to it
+type Result struct {
+	Layer   int      `json:"layer"`
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	YAML    string   `json:"yaml"`
+	Changes []Change `json:"changes"`
+}
+
+// migrations are ordered from oldest to newest; each starts where the previous one ends
+var migrations = []Migration{
+	{From: Legacy, To: Current, Steps: legacySteps},
+}
+
+// Migrations returns the registered migrations, oldest first
+func Migrations() []Migration {
+	return migrations
+}
+
+// Versions returns the known schema versions, oldest first
+func Versions() []string {
+	versions := []string{migrations[0].From}
+	for _, m := range migrations {
+		versions = append(versions, m.To)
+	}
+	return versions
+}
+
+// Detect returns the schema version an artifact of the given layer was written against
+func Detect(layer int, data []byte) (string, error) {
+	doc, err := yamldoc.Parse(data)
+	if err != nil {
+		return "", err
+	}
+	root, err := documentRoot(doc)
+	if err != nil {
+		return "", err
+	}
+	return detect(layer, root), nil
+}
+
+// Migrate upgrades an artifact of the given layer from one schema version to another. An empty
+// from detects the version and an empty to means Current. Parts of the document no step touches
+// keep their comments and formatting.
+func Migrate(layer int, data []byte, from, to string) (*Result, error) {
+	doc, err := yamldoc.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	original, err := documentRoot(doc)
+	if err != nil {
+		return nil, err
+	}
+	if from == "" {
+		from = detect(layer, original)
+	}
+	if to == "" {
+		to = Current
+	}
+	path, err := plan(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Steps rewrite a separate copy so the original stays intact for the merge
+	root, err := documentRoot(doc)
+	if err != nil {
+		return nil, err
+	}
+	r := &recorder{}
+	for _, m := range path {
+		for _, step := range m.Steps {
+			if !step.appliesTo(layer) || (step.detect != nil && !step.detect(layer, root)) {
+				continue
+			}
+			r.step = step.Name
+			root = step.apply(layer, root, r)
+		}
+	}
+
+	if err := doc.Merge(original, root); err != nil {
+		return nil, fmt.Errorf("failed to apply migration: %w", err)
+	}
+	return &Result{Layer: layer, From: from, To: to, YAML: doc.String(), Changes: r.changes}, nil
+}
+
+// detect returns the oldest version whose layout the document still uses, or Current
+func detect(layer int, root yaml.MapSlice) string {
+	for _, m := range migrations {
+		for _, step := range m.Steps {
+			if step.detect != nil && step.appliesTo(layer) && step.detect(layer, root) {
+				return m.From
+			}
+		}
+	}
+	return Current
+}
+
+// plan returns the migrations leading from one version to another
+func plan(from, to string) ([]Migration, error) {
+	if !knownVersion(from) {
+		return nil, fmt.Errorf("unknown schema version %q (known: %v)", from, Versions())
+	}
+	if !knownVersion(to) {
+		return nil, fmt.Errorf("unknown schema version %q (known: %v)", to, Versions())
+	}
+	var path []Migration
+	version := from
+	for _, m := range migrations {
+		if version == to {
+			break
+		}
+		if m.From == version {
+			path = append(path, m)
+			version = m.To
+		}
+	}
+	if version != to {
+		return nil, fmt.Errorf("no migration from %s to %s; only upgrades are supported", from, to)
+	}
+	return path, nil
+}
+
+func knownVersion(version string) bool {
+	for _, v := range Versions() {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Step) appliesTo(layer int) bool {
+	if len(s.Layers) == 0 {
+		return true
+	}
+	for _, l := range s.Layers {
+		if l == layer {
+			return true
+		}
+	}
+	return false
+}
+
+// documentRoot returns the top-level mapping of a document
+func documentRoot(doc *yamldoc.Document) (yaml.MapSlice, error) {
+	value, err := doc.Value("")
+	if err != nil {
+		return nil, err
+	}
+	root, ok := value.(yaml.MapSlice)
+	if !ok {
+		return nil, fmt.Errorf("artifact must be a YAML mapping")
+	}
+	return root, nil
+}
+
+// recorder collects the changes made by the running step
+type recorder struct {
+	step    string
+	changes []Change
+}
+
+func (r *recorder) record(path, format string, args ...interface{}) {
+	r.changes = append(r.changes, Change{Step: r.step, Path: path, Detail: fmt.Sprintf(format, args...)})
+}
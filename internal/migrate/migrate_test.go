@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate
+
+import (
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const legacyCatalog = `# Legacy catalog
+metadata:
+  id: TEST # keep me
+  title: Test Catalog
+  description: d
+  author: Jane Doe
+control-families:
+  - title: Data Protection
+    description: Protect data
+    controls:
+      - id: C-1
+        title: One
+        objective: o
+        guideline-mappings:
+          - reference-id: 2.1
+            entries: []
+        assessment-requirements:
+          - id: C-1.1
+            text: First
+            applicability: null
+`
+
+func TestMigrateLegacyCatalog(t *testing.T) {
+	version, err := Detect(2, []byte(legacyCatalog))
+	require.NoError(t, err)
+	assert.Equal(t, Legacy, version)
+
+	result, err := Migrate(2, []byte(legacyCatalog), "", "")
+	require.NoError(t, err)
+	assert.Equal(t, Legacy, result.From)
+	assert.Equal(t, Current, result.To)
+	assert.Contains(t, result.YAML, "# keep me")
+
+	steps := make(map[string]bool)
+	for _, change := range result.Changes {
+		steps[change.Step] = true
+	}
+	for _, name := range []string{"identifier-strings", "metadata-title", "metadata-author", "flatten-control-families"} {
+		assert.True(t, steps[name], "expected a change from step %s", name)
+	}
+
+	var migrated map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(result.YAML), &migrated))
+	assert.Equal(t, "Test Catalog", migrated["title"])
+	assert.NotContains(t, migrated, "control-families")
+
+	metadata := migrated["metadata"].(map[string]interface{})
+	assert.NotContains(t, metadata, "title")
+	author := metadata["author"].(map[string]interface{})
+	assert.Equal(t, "Jane Doe", author["name"])
+	assert.Equal(t, "jane-doe", author["id"])
+
+	families := migrated["families"].([]interface{})
+	require.Len(t, families, 1)
+	assert.Equal(t, "data-protection", families[0].(map[string]interface{})["id"])
+
+	controls := migrated["controls"].([]interface{})
+	require.Len(t, controls, 1)
+	control := controls[0].(map[string]interface{})
+	assert.Equal(t, "data-protection", control["family"])
+	mapping := control["guideline-mappings"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "2.1", mapping["reference-id"])
+	requirement := control["assessment-requirements"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{}, requirement["applicability"])
+
+	// A migrated artifact uses the current layout and migrates to itself
+	version, err = Detect(2, []byte(result.YAML))
+	require.NoError(t, err)
+	assert.Equal(t, Current, version)
+
+	again, err := Migrate(2, []byte(result.YAML), "", "")
+	require.NoError(t, err)
+	assert.Empty(t, again.Changes)
+	assert.Equal(t, result.YAML, again.YAML)
+}
+
+func TestMigrateLegacyPolicy(t *testing.T) {
+	policy := `metadata:
+  id: POL
+  title: Policy
+  description: d
+  author: Security Team
+  last-modified: "2024-03-05T10:00:00Z"
+scope:
+  boundaries: [EU]
+  technologies: [Kubernetes]
+guidance-references:
+  - reference-id: AIGF
+control-references:
+  - reference-id: CCC
+`
+	result, err := Migrate(3, []byte(policy), "", "")
+	require.NoError(t, err)
+
+	var migrated map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(result.YAML), &migrated))
+	assert.Equal(t, "Policy", migrated["title"])
+	assert.Equal(t, "2024-03-05", migrated["metadata"].(map[string]interface{})["date"])
+	in := migrated["scope"].(map[string]interface{})["in"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"EU"}, in["geopolitical"])
+	assert.Equal(t, []interface{}{"Kubernetes"}, in["technologies"])
+	assert.Contains(t, migrated, "imports")
+	assert.Contains(t, migrated, "adherence")
+	assert.NotContains(t, migrated, "guidance-references")
+	assert.NotContains(t, migrated, "control-references")
+}
+
+func TestMigrateVersions(t *testing.T) {
+	assert.Equal(t, []string{Legacy, Current}, Versions())
+
+	_, err := Migrate(2, []byte(legacyCatalog), "v0.1", "")
+	assert.ErrorContains(t, err, "unknown schema version")
+
+	_, err = Migrate(2, []byte(legacyCatalog), Current, Legacy)
+	assert.ErrorContains(t, err, "only upgrades are supported")
+
+	_, err = Migrate(2, []byte("- not a mapping\n"), "", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,792 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/goccy/go-yaml"
+)
+
+// legacySteps upgrade the legacy layout to Current. Later steps rely on earlier ones, for example
+// the guideline steps expect guidelines to have been moved out of their categories.
+var legacySteps = []Step{
+	{
+		Name:        "identifier-strings",
+		Description: "Quote identifiers that YAML reads as numbers, such as reference-id: 2.1",
+		apply:       migrateIdentifiers,
+	},
+	{
+		Name:        "null-fields",
+		Description: "Remove fields left empty, which the schema does not accept as null",
+		apply:       migrateNullFields,
+	},
+	{
+		Name:        "metadata-title",
+		Description: "Move metadata.title to the top-level title",
+		detect:      func(_ int, root yaml.MapSlice) bool { return has(mapping(root, "metadata"), "title") },
+		apply:       migrateMetadataTitle,
+	},
+	{
+		Name:        "metadata-last-modified",
+		Description: "Replace metadata.last-modified with metadata.date",
+		detect:      func(_ int, root yaml.MapSlice) bool { return has(mapping(root, "metadata"), "last-modified") },
+		apply:       migrateLastModified,
+	},
+	{
+		Name:        "metadata-author",
+		Description: "Replace a plain-text or missing metadata.author with an actor",
+		detect: func(_ int, root yaml.MapSlice) bool {
+			metadata, ok := get(root, "metadata")
+			return ok && isMapping(metadata) && !isMapping(value(metadata.(yaml.MapSlice), "author"))
+		},
+		apply: migrateAuthor,
+	},
+	{
+		Name:        "document-type",
+		Description: "Move metadata.document-type to the top level",
+		Layers:      []int{consts.Layer1},
+		detect:      func(_ int, root yaml.MapSlice) bool { return has(mapping(root, "metadata"), "document-type") },
+		apply:       migrateDocumentType,
+	},
+	{
+		Name:        "metadata-applicability",
+		Description: "Remove metadata.applicability, which has no equivalent in the current schema",
+		Layers:      []int{consts.Layer1},
+		detect:      func(_ int, root yaml.MapSlice) bool { return has(mapping(root, "metadata"), "applicability") },
+		apply:       migrateMetadataApplicability,
+	},
+	{
+		Name:        "flatten-categories",
+		Description: "Move guidelines out of categories into a flat guidelines list with a family field",
+		Layers:      []int{consts.Layer1},
+		detect:      func(_ int, root yaml.MapSlice) bool { return has(root, "categories") },
+		apply: func(_ int, root yaml.MapSlice, r *recorder) yaml.MapSlice {
+			return flattenGroups(root, "categories", "guidelines", r)
+		},
+	},
+	{
+		Name:        "guideline-parts",
+		Description: "Convert guideline-parts to statements",
+		Layers:      []int{consts.Layer1},
+		detect: func(_ int, root yaml.MapSlice) bool {
+			return anyItem(root, "guidelines", func(g yaml.MapSlice) bool { return has(g, "guideline-parts") })
+		},
+		apply: migrateGuidelineParts,
+	},
+	{
+		Name:        "guideline-rationale",
+		Description: "Convert rationale risks and outcomes to importance and goals",
+		Layers:      []int{consts.Layer1},
+		detect: func(_ int, root yaml.MapSlice) bool {
+			return anyItem(root, "guidelines", func(g yaml.MapSlice) bool {
+				rationale := mapping(g, "rationale")
+				return has(rationale, "risks") || has(rationale, "outcomes")
+			})
+		},
+		apply: migrateRationale,
+	},
+	{
+		Name:        "flatten-control-families",
+		Description: "Move controls out of control-families into a flat controls list with a family field",
+		Layers:      []int{consts.Layer2},
+		detect:      func(_ int, root yaml.MapSlice) bool { return has(root, "control-families") },
+		apply: func(_ int, root yaml.MapSlice, r *recorder) yaml.MapSlice {
+			return flattenGroups(root, "control-families", "controls", r)
+		},
+	},
+	{
+		Name:        "requirement-applicability",
+		Description: "Make assessment requirement applicability a list of category IDs",
+		Layers:      []int{consts.Layer2},
+		apply:       migrateRequirementApplicability,
+	},
+	{
+		Name:        "policy-organization",
+		Description: "Remove organization-id, which has no equivalent in the current schema",
+		Layers:      []int{consts.Layer3},
+		detect:      func(_ int, root yaml.MapSlice) bool { return has(root, "organization-id") },
+		apply:       migrateOrganization,
+	},
+	{
+		Name:        "policy-purpose",
+		Description: "Move purpose to metadata.description",
+		Layers:      []int{consts.Layer3},
+		detect:      func(_ int, root yaml.MapSlice) bool { return has(root, "purpose") },
+		apply:       migratePurpose,
+	},
+	{
+		Name:        "policy-scope",
+		Description: "Move scope boundaries and technologies to scope.in",
+		Layers:      []int{consts.Layer3},
+		detect: func(_ int, root yaml.MapSlice) bool {
+			scope := mapping(root, "scope")
+			return has(scope, "boundaries") || has(scope, "technologies") || has(scope, "providers")
+		},
+		apply: migrateScope,
+	},
+	{
+		Name:        "policy-imports",
+		Description: "Convert guidance-references and control-references to imports",
+		Layers:      []int{consts.Layer3},
+		detect: func(_ int, root yaml.MapSlice) bool {
+			return has(root, "guidance-references") || has(root, "control-references")
+		},
+		apply: migratePolicyReferences,
+	},
+	{
+		Name:        "policy-required-sections",
+		Description: "Add empty imports and adherence sections where they are missing",
+		Layers:      []int{consts.Layer3},
+		apply:       migrateRequiredSections,
+	},
+}
+
+// identifierKeys are the fields that hold identifiers, which the schema requires to be strings
+var identifierKeys = map[string]bool{"id": true, "reference-id": true, "entry-id": true, "target-id": true, "requirement-id": true}
+
+func migrateIdentifiers(_ int, root yaml.MapSlice, r *recorder) yaml.MapSlice {
+	return quoteIdentifiers("", root, r).(yaml.MapSlice)
+}
+
+func quoteIdentifiers(path string, v interface{}, r *recorder) interface{} {
+	switch v := v.(type) {
+	case yaml.MapSlice:
+		result := make(yaml.MapSlice, len(v))
+		for i, item := range v {
+			key := fmt.Sprint(item.Key)
+			itemPath := path + "/" + key
+			result[i] = item
+			switch item.Value.(type) {
+			case int, int64, uint64, float64:
+				if identifierKeys[key] {
+					result[i].Value = text(item.Value)
+					r.record(itemPath, "quoted numeric identifier %s", text(item.Value))
+				}
+			default:
+				result[i].Value = quoteIdentifiers(itemPath, item.Value, r)
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = quoteIdentifiers(fmt.Sprintf("%s/%d", path, i), item, r)
+		}
+		return result
+	}
+	return v
+}
+
+func migrateNullFields(_ int, root yaml.MapSlice, r *recorder) yaml.MapSlice {
+	return dropNulls("", root, r).(yaml.MapSlice)
+}
+
+func dropNulls(path string, v interface{}, r *recorder) interface{} {
+	switch v := v.(type) {
+	case yaml.MapSlice:
+		result := make(yaml.MapSlice, 0, len(v))
+		for _, item := range v {
+			itemPath := fmt.Sprintf("%s/%v", path, item.Key)
+			if item.Value == nil {
+				r.record(itemPath, "removed empty field")
+				continue
+			}
+			result = append(result, yaml.MapItem{Key: item.Key, Value: dropNulls(itemPath, item.Value, r)})
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = dropNulls(fmt.Sprintf("%s/%d", path, i), item, r)
+		}
+		return result
+	}
+	return v
+}
+
+func migrateMetadataTitle(_ int, root yaml.MapSlice, r *recorder) yaml.MapSlice {
+	metadata := mapping(root, "metadata")
+	title, ok := get(metadata, "title")
+	if !ok {
+		return root
+	}
+	if existing := text(value(root, "title")); existing == "" {
+		root = set(root, "title", title)
+		r.record("/title", "moved from /metadata/title")
+	} else if existing != text(title) {
+		r.record("/metadata/title", "removed %q; the document title %q is kept", text(title), existing)
+	} else {
+		r.record("/metadata/title", "removed duplicate of the document title")
+	}
+	return set(root, "metadata", del(metadata, "title"))
+}
+
+func migrateLastModified(_ int, root yaml.MapSlice, r *recorder) yaml.MapSlice {
+	metadata := mapping(root, "metadata")
+	modified := text(value(metadata, "last-modified"))
+	metadata = del(metadata, "last-modified")
+	switch {
+	case has(metadata, "date"):
+		r.record("/metadata/last-modified", "removed %q; metadata.date is already set", modified)
+	default:
+		date, ok := parseDate(modified)
+		if ok {
+			metadata = set(metadata, "date", date)
+			r.record("/metadata/date", "set to %s from last-modified %q", date, modified)
+		} else {
+			r.record("/metadata/last-modified", "removed %q, which is not a date", modified)
+		}
+	}
+	return set(root, "metadata", metadata)
+}
+
+func migrateAuthor(_ int, root yaml.MapSlice, r *recorder) yaml.MapSlice {
+	metadata := mapping(root, "metadata")
+	name := text(value(metadata, "author"))
+	actor := yaml.MapSlice{
+		{Key: "id", Value: slug(name)},
+		{Key: "name", Value: name},
+		{Key: "type", Value: "Human"},
+	}
+	if name == "" {
+		actor[0].Value = "todo-author"
+		actor[1].Value = "TODO: author"
+		r.record("/metadata/author", "added placeholder author; replace the TODO")
+	} else {
+		r.record("/metadata/author", "converted %q to a Human actor; check the id and type", name)
+	}
+	return set(root, "metadata", set(metadata, "author", actor))
+}
+
+func migrateDocumentType(_ int, root yaml.MapSlice, r *recorder) yaml.MapSlice {
+	metadata := mapping(root, "metadata")
+	documentType := value(metadata, "document-type")
+	if has(root, "document-type") {
+		r.record("/metadata/document-type", "removed %q; document-type is already set", text(documentType))
+	} else {
+		root = set(root, "document-type", documentType)
+		r.record("/document-type", "moved from /metadata/document-type")
+	}
+	return set(root, "metadata", del(metadata, "document-type"))
+}
+
+func migrateMetadataApplicability(_ int, root yaml.MapSlice, r *recorder) yaml.MapSlice {
+	metadata := mapping(root, "metadata")
+	var dropped []string
+	for _, item := range mapping(metadata, "applicability") {
+		dropped = append(dropped, fmt.Sprintf("%v: %s", item.Key, strings.Join(texts(item.Value), ", ")))
+	}
+	r.record("/metadata/applicability", "removed (%s); record applicability on guidelines or as applicability-categories instead", strings.Join(dropped, "; "))
+	return set(root, "metadata", del(metadata, "applicability"))
+}
+
+// flattenGroups moves the items nested under each group of groupKey into a flat itemKey list, and
+// the groups themselves into families
+func flattenGroups(root yaml.MapSlice, groupKey, itemKey string, r *recorder) yaml.MapSlice {
+	families := list(root, "families")
+	items := list(root, itemKey)
+	familyIDs := make(map[string]bool)
+	for _, family := range families {
+		familyIDs[text(value(asMapping(family), "id"))] = true
+	}
+
+	for i, group := range list(root, groupKey) {
+		groupMap := asMapping(group)
+		title := text(value(groupMap, "title"))
+		familyID := text(value(groupMap, "id"))
+		if familyID == "" {
+			familyID = slug(title)
+			r.record(fmt.Sprintf("/%s/%d/id", groupKey, i), "family %q had no id; using %q", title, familyID)
+		}
+		if !familyIDs[familyID] {
+			familyIDs[familyID] = true
+			description := text(value(groupMap, "description"))
+			if description == "" {
+				description = fmt.Sprintf("TODO: describe the %s family.", title)
+			}
+			families = append(families, yaml.MapSlice{
+				{Key: "id", Value: familyID},
+				{Key: "title", Value: title},
+				{Key: "description", Value: description},
+			})
+			r.record(fmt.Sprintf("/families/%d", len(families)-1), "created family %q from /%s/%d", familyID, groupKey, i)
+		}
+		for _, extra := range groupMap {
+			switch extra.Key {
+			case "id", "title", "description", itemKey:
+			default:
+				r.record(fmt.Sprintf("/%s/%d/%v", groupKey, i, extra.Key), "removed; families have no %v field", extra.Key)
+			}
+		}
+
+		for j, item := range list(groupMap, itemKey) {
+			itemMap := asMapping(item)
+			if family := text(value(itemMap, "family")); family == "" {
+				itemMap = set(itemMap, "family", familyID)
+			} else if family != familyID {
+				r.record(fmt.Sprintf("/%s/%d/%s/%d/family", groupKey, i, itemKey, j), "kept family %q although it was nested under %q", family, familyID)
+			}
+			items = append(items, itemMap)
+			r.record(fmt.Sprintf("/%s/%d", itemKey, len(items)-1), "moved %s from /%s/%d/%s/%d into family %q", text(value(itemMap, "id")), groupKey, i, itemKey, j, familyID)
+		}
+	}
+
+	root = del(root, groupKey)
+	root = set(root, "families", families)
+	return set(root, itemKey, items)
+}
+
+func migrateGuidelineParts(_ int, root yaml.MapSlice, r *recorder) yaml.MapSlice {
+	guidelines := list(root, "guidelines")
+	for i, guideline := range guidelines {
+		guidelineMap := asMapping(guideline)
+		parts, ok := get(guidelineMap, "guideline-parts")
+		if !ok {
+			continue
+		}
+		statements := list(guidelineMap, "statements")
+		for j, part := range asList(parts) {
+			partMap := asMapping(part)
+			statement := yaml.MapSlice{}
+			for _, field := range partMap {
+				switch field.Key {
+				case "id", "title", "text", "recommendations":
+					statement = append(statement, field)
+				default:
+					r.record(fmt.Sprintf("/guidelines/%d/guideline-parts/%d/%v", i, j, field.Key), "removed; statements have no %v field", field.Key)
+				}
+			}
+			if text(value(statement, "text")) == "" {
+				statement = set(statement, "text", "TODO: state this part of the guideline.")
+				r.record(fmt.Sprintf("/guidelines/%d/statements/%d/text", i, len(statements)), "added placeholder text; replace the TODO")
+			}
+			statements = append(statements, statement)
+		}
+		guidelineMap = del(guidelineMap, "guideline-parts")
+		guidelineMap = set(guidelineMap, "statements", statements)
+		guidelines[i] = guidelineMap
+		r.record(fmt.Sprintf("/guidelines/%d/statements", i), "converted %d guideline part(s) of %s to statements", len(asList(parts)), text(value(guidelineMap, "id")))
+	}
+	return set(root, "guidelines", guidelines)
+}
+
+func migrateRationale(_ int, root yaml.MapSlice, r *recorder) yaml.MapSlice {
+	guidelines := list(root, "guidelines")
+	for i, guideline := range guidelines {
+		guidelineMap := asMapping(guideline)
+		rationale := mapping(guidelineMap, "rationale")
+		if !has(rationale, "risks") && !has(rationale, "outcomes") {
+			continue
+		}
+		importance := text(value(rationale, "importance"))
+		if importance == "" {
+			importance = strings.Join(describeItems(list(rationale, "risks")), " ")
+		}
+		if importance == "" {
+			importance = "TODO: explain why this guideline matters."
+		}
+		goals := texts(value(rationale, "goals"))
+		goals = append(goals, describeItems(list(rationale, "outcomes"))...)
+		guidelineMap = set(guidelineMap, "rationale", yaml.MapSlice{
+			{Key: "importance", Value: importance},
+			{Key: "goals", Value: goals},
+		})
+		guidelines[i] = guidelineMap
+		r.record(fmt.Sprintf("/guidelines/%d/rationale", i), "converted %d risk(s) to importance and %d outcome(s) to goals for %s",
+			len(list(rationale, "risks")), len(list(rationale, "outcomes")), text(value(guidelineMap, "id")))
+	}
+	return set(root, "guidelines", guidelines)
+}
+
+func migrateRequirementApplicability(_ int, root yaml.MapSlice, r *recorder) yaml.MapSlice {
+	categoryIDs := make(map[string]bool)
+	titles := make(map[string]string)
+	for _, category := range list(mapping(root, "metadata"), "applicability-categories") {
+		categoryMap := asMapping(category)
+		id := text(value(categoryMap, "id"))
+		categoryIDs[id] = true
+		titles[text(value(categoryMap, "title"))] = id
+	}
+
+	controls := list(root, "controls")
+	changed := false
+	for i, control := range controls {
+		controlMap := asMapping(control)
+		requirements := list(controlMap, "assessment-requirements")
+		for j, requirement := range requirements {
+			requirementMap := asMapping(requirement)
+			path := fmt.Sprintf("/controls/%d/assessment-requirements/%d/applicability", i, j)
+			current, ok := get(requirementMap, "applicability")
+			var applicability []string
+			var notes []string
+			switch {
+			case !ok || current == nil:
+				notes = append(notes, "was empty")
+			case !isList(current):
+				notes = append(notes, fmt.Sprintf("was the single value %q", text(current)))
+			}
+			for _, v := range texts(current) {
+				if id, ok := titles[v]; ok && !categoryIDs[v] {
+					notes = append(notes, fmt.Sprintf("%q → %q", v, id))
+					v = id
+				}
+				applicability = append(applicability, v)
+			}
+			if len(notes) == 0 {
+				continue
+			}
+			if applicability == nil {
+				applicability = []string{}
+			}
+			requirements[j] = set(requirementMap, "applicability", applicability)
+			r.record(path, "%s", strings.Join(notes, ", "))
+			changed = true
+		}
+		controls[i] = set(controlMap, "assessment-requirements", requirements)
+	}
+	if !changed {
+		return root
+	}
+	return set(root, "controls", controls)
+}
+
+func migrateOrganization(_ int, root yaml.MapSlice, r *recorder) yaml.MapSlice {
+	r.record("/organization-id", "removed %q; the current schema has no organization field", text(value(root, "organization-id")))
+	return del(root, "organization-id")
+}
+
+func migratePurpose(_ int, root yaml.MapSlice, r *recorder) yaml.MapSlice {
+	purpose := text(value(root, "purpose"))
+	root = del(root, "purpose")
+	metadata, ok := get(root, "metadata")
+	if !ok || !isMapping(metadata) {
+		metadata = yaml.MapSlice{}
+	}
+	switch description := text(value(metadata.(yaml.MapSlice), "description")); {
+	case description == "":
+		r.record("/metadata/description", "moved from /purpose")
+		return set(root, "metadata", set(metadata.(yaml.MapSlice), "description", purpose))
+	case strings.TrimSpace(description) == strings.TrimSpace(purpose):
+		r.record("/purpose", "removed duplicate of metadata.description")
+	default:
+		r.record("/purpose", "removed %q; metadata.description is kept", purpose)
+	}
+	return root
+}
+
+func migrateScope(_ int, root yaml.MapSlice, r *recorder) yaml.MapSlice {
+	scope := mapping(root, "scope")
+	in := mapping(scope, "in")
+	for _, field := range []struct{ from, to string }{{"boundaries", "geopolitical"}, {"technologies", "technologies"}} {
+		values, ok := get(scope, field.from)
+		if !ok {
+			continue
+		}
+		in = set(in, field.to, append(texts(value(in, field.to)), texts(values)...))
+		scope = del(scope, field.from)
+		r.record("/scope/in/"+field.to, "moved from /scope/%s", field.from)
+	}
+	if providers, ok := get(scope, "providers"); ok {
+		scope = del(scope, "providers")
+		r.record("/scope/providers", "removed (%s); the current schema has no provider dimension", strings.Join(texts(providers), ", "))
+	}
+	scope = set(scope, "in", in)
+	return set(root, "scope", scope)
+}
+
+func migratePolicyReferences(_ int, root yaml.MapSlice, r *recorder) yaml.MapSlice {
+	imports := mapping(root, "imports")
+	for _, kind := range []struct{ from, to string }{{"guidance-references", "guidance"}, {"control-references", "catalogs"}} {
+		references, ok := get(root, kind.from)
+		if !ok {
+			continue
+		}
+		entries := list(imports, kind.to)
+		for i, reference := range asList(references) {
+			entries = append(entries, migrateReference(fmt.Sprintf("/%s/%d", kind.from, i), asMapping(reference), kind.to == "catalogs", r))
+			r.record(fmt.Sprintf("/imports/%s/%d", kind.to, len(entries)-1), "converted from /%s/%d", kind.from, i)
+		}
+		imports = set(imports, kind.to, entries)
+		root = del(root, kind.from)
+	}
+	return set(root, "imports", imports)
+}
+
+// migrateReference converts a legacy guidance or control reference to an import. Guideline and control
+// modifications become constraints; assessment requirement modifications are kept on catalog imports
+// and become constraints on guidance imports, which cannot modify requirements.
+func migrateReference(path string, reference yaml.MapSlice, catalog bool, r *recorder) yaml.MapSlice {
+	referenceID := text(value(reference, "reference-id"))
+	entry := yaml.MapSlice{{Key: "reference-id", Value: referenceID}}
+	var constraints, modifications []interface{}
+	ids := make(map[string]bool)
+
+	for _, field := range reference {
+		fieldPath := fmt.Sprintf("%s/%v", path, field.Key)
+		switch field.Key {
+		case "reference-id":
+		case "exclusions":
+			entry = append(entry, field)
+		case "guideline-modifications", "control-modifications":
+			for _, item := range asList(field.Value) {
+				constraints = append(constraints, legacyConstraint(referenceID, asMapping(item), ids))
+			}
+			r.record(fieldPath, "converted %d modification(s) to constraints", len(asList(field.Value)))
+		case "assessment-requirement-modifications":
+			for _, item := range asList(field.Value) {
+				if catalog {
+					modifications = append(modifications, legacyModification(fieldPath, asMapping(item), ids, r))
+				} else {
+					constraints = append(constraints, legacyConstraint(referenceID, asMapping(item), ids))
+				}
+			}
+			if !catalog {
+				r.record(fieldPath, "converted %d modification(s) to constraints; guidance imports cannot modify assessment requirements", len(asList(field.Value)))
+			}
+		default:
+			r.record(fieldPath, "removed; imports have no %v field", field.Key)
+		}
+	}
+	if len(constraints) > 0 {
+		entry = append(entry, yaml.MapItem{Key: "constraints", Value: constraints})
+	}
+	if len(modifications) > 0 {
+		entry = append(entry, yaml.MapItem{Key: "assessment-requirement-modifications", Value: modifications})
+	}
+	return entry
+}
+
+// legacyConstraint turns a legacy modification into a constraint on its target
+func legacyConstraint(referenceID string, item yaml.MapSlice, ids map[string]bool) yaml.MapSlice {
+	target := text(value(item, "target-id"))
+	constraintText := "TODO: state the requirement."
+	for _, key := range []string{"text", "objective", "title", "modification-rationale"} {
+		if v := strings.TrimSpace(text(value(item, key))); v != "" {
+			constraintText = v
+			break
+		}
+	}
+	if recommendation := strings.TrimSpace(text(value(item, "recommendation"))); recommendation != "" {
+		constraintText += " Recommendation: " + recommendation
+	}
+	return yaml.MapSlice{
+		{Key: "id", Value: uniqueID(fmt.Sprintf("%s-%s-constraint", referenceID, target), ids)},
+		{Key: "target-id", Value: target},
+		{Key: "text", Value: constraintText},
+	}
+}
+
+// legacyModification gives a legacy assessment requirement modification an id and a current modification type
+func legacyModification(path string, item yaml.MapSlice, ids map[string]bool, r *recorder) yaml.MapSlice {
+	target := text(value(item, "target-id"))
+	modType := text(value(item, "modification-type"))
+	switch modType {
+	case "add", "modify", "remove", "replace", "override":
+	default:
+		r.record(path, "modification type %q of %s is now \"modify\"", modType, target)
+		modType = "modify"
+	}
+	modification := yaml.MapSlice{
+		{Key: "id", Value: uniqueID(fmt.Sprintf("%s-%s", target, modType), ids)},
+		{Key: "target-id", Value: target},
+		{Key: "modification-type", Value: modType},
+		{Key: "modification-rationale", Value: text(value(item, "modification-rationale"))},
+	}
+	for _, field := range item {
+		switch field.Key {
+		case "text", "applicability", "recommendation":
+			modification = append(modification, field)
+		case "target-id", "modification-type", "modification-rationale":
+		default:
+			r.record(path, "removed %v from the modification of %s", field.Key, target)
+		}
+	}
+	return modification
+}
+
+func migrateRequiredSections(_ int, root yaml.MapSlice, r *recorder) yaml.MapSlice {
+	for _, key := range []string{"imports", "adherence"} {
+		if !has(root, key) {
+			root = set(root, key, yaml.MapSlice{})
+			r.record("/"+key, "added empty section required by the schema")
+		}
+	}
+	return root
+}
+
+// describeItems renders legacy risk and outcome entries as sentences
+func describeItems(items []interface{}) []string {
+	var result []string
+	for _, item := range items {
+		itemMap, ok := item.(yaml.MapSlice)
+		if !ok {
+			if t := text(item); t != "" {
+				result = append(result, t)
+			}
+			continue
+		}
+		title := text(value(itemMap, "title"))
+		description := text(value(itemMap, "description"))
+		switch {
+		case title != "" && description != "":
+			result = append(result, title+": "+description)
+		case title != "":
+			result = append(result, title)
+		case description != "":
+			result = append(result, description)
+		}
+	}
+	return result
+}
+
+// parseDate reads a date or timestamp and returns it in the schema's date format
+func parseDate(s string) (string, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(s)); err == nil {
+			return t.Format("2006-01-02"), true
+		}
+	}
+	return "", false
+}
+
+// uniqueID returns id, or id with a numeric suffix if it is already taken
+func uniqueID(id string, taken map[string]bool) string {
+	candidate := id
+	for n := 2; taken[candidate]; n++ {
+		candidate = fmt.Sprintf("%s-%d", id, n)
+	}
+	taken[candidate] = true
+	return candidate
+}
+
+// slug builds an identifier from a title (lowercase, with other characters collapsed into hyphens)
+func slug(s string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, c := range strings.ToLower(s) {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+			b.WriteRune(c)
+			hyphen = false
+		} else if !hyphen && b.Len() > 0 {
+			b.WriteByte('-')
+			hyphen = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// anyItem reports whether any mapping in the list at key satisfies match
+func anyItem(root yaml.MapSlice, key string, match func(yaml.MapSlice) bool) bool {
+	for _, item := range list(root, key) {
+		if match(asMapping(item)) {
+			return true
+		}
+	}
+	return false
+}
+
+func get(m yaml.MapSlice, key string) (interface{}, bool) {
+	for _, item := range m {
+		if fmt.Sprint(item.Key) == key {
+			return item.Value, true
+		}
+	}
+	return nil, false
+}
+
+func has(m yaml.MapSlice, key string) bool {
+	_, ok := get(m, key)
+	return ok
+}
+
+func value(m yaml.MapSlice, key string) interface{} {
+	v, _ := get(m, key)
+	return v
+}
+
+// mapping returns the mapping at key, or nil
+func mapping(m yaml.MapSlice, key string) yaml.MapSlice {
+	return asMapping(value(m, key))
+}
+
+// list returns the sequence at key, or nil
+func list(m yaml.MapSlice, key string) []interface{} {
+	return asList(value(m, key))
+}
+
+// set replaces the value of key, or appends key if it is missing, without modifying m
+func set(m yaml.MapSlice, key string, v interface{}) yaml.MapSlice {
+	result := make(yaml.MapSlice, len(m), len(m)+1)
+	copy(result, m)
+	for i, item := range result {
+		if fmt.Sprint(item.Key) == key {
+			result[i].Value = v
+			return result
+		}
+	}
+	return append(result, yaml.MapItem{Key: key, Value: v})
+}
+
+// del removes key without modifying m
+func del(m yaml.MapSlice, key string) yaml.MapSlice {
+	result := make(yaml.MapSlice, 0, len(m))
+	for _, item := range m {
+		if fmt.Sprint(item.Key) != key {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func asMapping(v interface{}) yaml.MapSlice {
+	m, _ := v.(yaml.MapSlice)
+	return m
+}
+
+func asList(v interface{}) []interface{} {
+	l, _ := v.([]interface{})
+	return l
+}
+
+func isMapping(v interface{}) bool {
+	_, ok := v.(yaml.MapSlice)
+	return ok
+}
+
+func isList(v interface{}) bool {
+	_, ok := v.([]interface{})
+	return ok
+}
+
+// text renders a scalar as a string, or "" for null and collections
+func text(v interface{}) string {
+	switch v := v.(type) {
+	case nil, yaml.MapSlice, []interface{}:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// texts returns the scalar items of a sequence, or a scalar as a single item
+func texts(v interface{}) []string {
+	if items, ok := v.([]interface{}); ok {
+		var result []string
+		for _, item := range items {
+			if t := text(item); t != "" {
+				result = append(result, t)
+			}
+		}
+		return result
+	}
+	if t := text(v); t != "" {
+		return []string{t}
+	}
+	return nil
+}
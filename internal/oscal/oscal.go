@@ -0,0 +1,420 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package oscal holds the subset of the OSCAL 1.1 model that Gemara artifacts are imported from and
+// exported to: catalogs with their groups, controls, parameters and parts, profiles that select and
+// tailor them, component definitions, and assessment results. Documents are read from either the
+// JSON or the YAML serialization.
+package oscal
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Version is the OSCAL version exported documents declare
+const Version = "1.1.2"
+
+// Document is the root of an OSCAL file, which holds exactly one model
+type Document struct {
+	Catalog             *Catalog             `json:"catalog,omitempty" yaml:"catalog,omitempty"`
+	Profile             *Profile             `json:"profile,omitempty" yaml:"profile,omitempty"`
+	ComponentDefinition *ComponentDefinition `json:"component-definition,omitempty" yaml:"component-definition,omitempty"`
+	AssessmentResults   *AssessmentResults   `json:"assessment-results,omitempty" yaml:"assessment-results,omitempty"`
+}
+
+// Catalog is an OSCAL catalog
+type Catalog struct {
+	UUID       string      `json:"uuid" yaml:"uuid"`
+	Metadata   Metadata    `json:"metadata" yaml:"metadata"`
+	Params     []Parameter `json:"params,omitempty" yaml:"params,omitempty"`
+	Controls   []Control   `json:"controls,omitempty" yaml:"controls,omitempty"`
+	Groups     []Group     `json:"groups,omitempty" yaml:"groups,omitempty"`
+	BackMatter *BackMatter `json:"back-matter,omitempty" yaml:"back-matter,omitempty"`
+}
+
+// Profile is an OSCAL profile
+type Profile struct {
+	UUID       string          `json:"uuid" yaml:"uuid"`
+	Metadata   Metadata        `json:"metadata" yaml:"metadata"`
+	Imports    []ProfileImport `json:"imports" yaml:"imports"`
+	Modify     *Modify         `json:"modify,omitempty" yaml:"modify,omitempty"`
+	BackMatter *BackMatter     `json:"back-matter,omitempty" yaml:"back-matter,omitempty"`
+}
+
+// Metadata describes an OSCAL document
+type Metadata struct {
+	Title              string             `json:"title" yaml:"title"`
+	Published          string             `json:"published,omitempty" yaml:"published,omitempty"`
+	LastModified       string             `json:"last-modified" yaml:"last-modified"`
+	Version            string             `json:"version" yaml:"version"`
+	OscalVersion       string             `json:"oscal-version" yaml:"oscal-version"`
+	Props              []Property         `json:"props,omitempty" yaml:"props,omitempty"`
+	Links              []Link             `json:"links,omitempty" yaml:"links,omitempty"`
+	Parties            []Party            `json:"parties,omitempty" yaml:"parties,omitempty"`
+	ResponsibleParties []ResponsibleParty `json:"responsible-parties,omitempty" yaml:"responsible-parties,omitempty"`
+	Remarks            string             `json:"remarks,omitempty" yaml:"remarks,omitempty"`
+}
+
+// Party is a person or organization named in metadata
+type Party struct {
+	UUID           string   `json:"uuid" yaml:"uuid"`
+	Type           string   `json:"type" yaml:"type"`
+	Name           string   `json:"name,omitempty" yaml:"name,omitempty"`
+	EmailAddresses []string `json:"email-addresses,omitempty" yaml:"email-addresses,omitempty"`
+}
+
+// ResponsibleParty assigns parties to a role
+type ResponsibleParty struct {
+	RoleID     string   `json:"role-id" yaml:"role-id"`
+	PartyUUIDs []string `json:"party-uuids" yaml:"party-uuids"`
+}
+
+// Group is a set of controls, and possibly nested groups, in a catalog
+type Group struct {
+	ID       string      `json:"id,omitempty" yaml:"id,omitempty"`
+	Class    string      `json:"class,omitempty" yaml:"class,omitempty"`
+	Title    string      `json:"title" yaml:"title"`
+	Params   []Parameter `json:"params,omitempty" yaml:"params,omitempty"`
+	Props    []Property  `json:"props,omitempty" yaml:"props,omitempty"`
+	Parts    []Part      `json:"parts,omitempty" yaml:"parts,omitempty"`
+	Groups   []Group     `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Controls []Control   `json:"controls,omitempty" yaml:"controls,omitempty"`
+}
+
+// Control is a catalog control; nested controls are its enhancements
+type Control struct {
+	ID       string      `json:"id" yaml:"id"`
+	Class    string      `json:"class,omitempty" yaml:"class,omitempty"`
+	Title    string      `json:"title" yaml:"title"`
+	Params   []Parameter `json:"params,omitempty" yaml:"params,omitempty"`
+	Props    []Property  `json:"props,omitempty" yaml:"props,omitempty"`
+	Links    []Link      `json:"links,omitempty" yaml:"links,omitempty"`
+	Parts    []Part      `json:"parts,omitempty" yaml:"parts,omitempty"`
+	Controls []Control   `json:"controls,omitempty" yaml:"controls,omitempty"`
+}
+
+// Part is a named piece of control text such as a statement, item, or guidance
+type Part struct {
+	ID    string     `json:"id,omitempty" yaml:"id,omitempty"`
+	Name  string     `json:"name" yaml:"name"`
+	NS    string     `json:"ns,omitempty" yaml:"ns,omitempty"`
+	Class string     `json:"class,omitempty" yaml:"class,omitempty"`
+	Title string     `json:"title,omitempty" yaml:"title,omitempty"`
+	Props []Property `json:"props,omitempty" yaml:"props,omitempty"`
+	Prose string     `json:"prose,omitempty" yaml:"prose,omitempty"`
+	Parts []Part     `json:"parts,omitempty" yaml:"parts,omitempty"`
+	Links []Link     `json:"links,omitempty" yaml:"links,omitempty"`
+}
+
+// Parameter is a value that organizations assign or select when adopting a control
+type Parameter struct {
+	ID         string               `json:"id" yaml:"id"`
+	Class      string               `json:"class,omitempty" yaml:"class,omitempty"`
+	Props      []Property           `json:"props,omitempty" yaml:"props,omitempty"`
+	Label      string               `json:"label,omitempty" yaml:"label,omitempty"`
+	Usage      string               `json:"usage,omitempty" yaml:"usage,omitempty"`
+	Guidelines []ParameterGuideline `json:"guidelines,omitempty" yaml:"guidelines,omitempty"`
+	Values     []string             `json:"values,omitempty" yaml:"values,omitempty"`
+	Select     *ParameterSelection  `json:"select,omitempty" yaml:"select,omitempty"`
+}
+
+// ParameterGuideline is guidance on choosing a parameter value
+type ParameterGuideline struct {
+	Prose string `json:"prose" yaml:"prose"`
+}
+
+// ParameterSelection restricts a parameter to a set of choices
+type ParameterSelection struct {
+	HowMany string   `json:"how-many,omitempty" yaml:"how-many,omitempty"`
+	Choice  []string `json:"choice,omitempty" yaml:"choice,omitempty"`
+}
+
+// Property is a name/value annotation
+type Property struct {
+	Name    string `json:"name" yaml:"name"`
+	Value   string `json:"value" yaml:"value"`
+	NS      string `json:"ns,omitempty" yaml:"ns,omitempty"`
+	Class   string `json:"class,omitempty" yaml:"class,omitempty"`
+	Remarks string `json:"remarks,omitempty" yaml:"remarks,omitempty"`
+}
+
+// Link references another resource
+type Link struct {
+	Href string `json:"href" yaml:"href"`
+	Rel  string `json:"rel,omitempty" yaml:"rel,omitempty"`
+	Text string `json:"text,omitempty" yaml:"text,omitempty"`
+}
+
+// ProfileImport selects controls from a catalog or profile
+type ProfileImport struct {
+	Href            string              `json:"href" yaml:"href"`
+	IncludeAll      *struct{}           `json:"include-all,omitempty" yaml:"include-all,omitempty"`
+	IncludeControls []SelectControlByID `json:"include-controls,omitempty" yaml:"include-controls,omitempty"`
+	ExcludeControls []SelectControlByID `json:"exclude-controls,omitempty" yaml:"exclude-controls,omitempty"`
+}
+
+// SelectControlByID selects controls by ID, optionally with their enhancements
+type SelectControlByID struct {
+	WithChildControls string   `json:"with-child-controls,omitempty" yaml:"with-child-controls,omitempty"`
+	WithIDs           []string `json:"with-ids,omitempty" yaml:"with-ids,omitempty"`
+}
+
+// Modify tailors the selected controls
+type Modify struct {
+	SetParameters []SetParameter `json:"set-parameters,omitempty" yaml:"set-parameters,omitempty"`
+	Alters        []Alteration   `json:"alters,omitempty" yaml:"alters,omitempty"`
+}
+
+// SetParameter assigns or narrows a parameter of a selected control
+type SetParameter struct {
+	ParamID    string               `json:"param-id" yaml:"param-id"`
+	Label      string               `json:"label,omitempty" yaml:"label,omitempty"`
+	Values     []string             `json:"values,omitempty" yaml:"values,omitempty"`
+	Select     *ParameterSelection  `json:"select,omitempty" yaml:"select,omitempty"`
+	Guidelines []ParameterGuideline `json:"guidelines,omitempty" yaml:"guidelines,omitempty"`
+}
+
+// Alteration adds content to or removes content from a selected control
+type Alteration struct {
+	ControlID string     `json:"control-id" yaml:"control-id"`
+	Removes   []Removal  `json:"removes,omitempty" yaml:"removes,omitempty"`
+	Adds      []Addition `json:"adds,omitempty" yaml:"adds,omitempty"`
+}
+
+// Removal identifies content removed from a control
+type Removal struct {
+	ByName     string `json:"by-name,omitempty" yaml:"by-name,omitempty"`
+	ByClass    string `json:"by-class,omitempty" yaml:"by-class,omitempty"`
+	ByID       string `json:"by-id,omitempty" yaml:"by-id,omitempty"`
+	ByItemName string `json:"by-item-name,omitempty" yaml:"by-item-name,omitempty"`
+}
+
+// Addition is content added to a control
+type Addition struct {
+	Position string      `json:"position,omitempty" yaml:"position,omitempty"`
+	ByID     string      `json:"by-id,omitempty" yaml:"by-id,omitempty"`
+	Title    string      `json:"title,omitempty" yaml:"title,omitempty"`
+	Params   []Parameter `json:"params,omitempty" yaml:"params,omitempty"`
+	Props    []Property  `json:"props,omitempty" yaml:"props,omitempty"`
+	Parts    []Part      `json:"parts,omitempty" yaml:"parts,omitempty"`
+}
+
+// BackMatter holds resources referenced from the document
+type BackMatter struct {
+	Resources []Resource `json:"resources,omitempty" yaml:"resources,omitempty"`
+}
+
+// Resource is a document or citation in back matter
+type Resource struct {
+	UUID        string     `json:"uuid" yaml:"uuid"`
+	Title       string     `json:"title,omitempty" yaml:"title,omitempty"`
+	Description string     `json:"description,omitempty" yaml:"description,omitempty"`
+	Props       []Property `json:"props,omitempty" yaml:"props,omitempty"`
+	Rlinks      []RLink    `json:"rlinks,omitempty" yaml:"rlinks,omitempty"`
+}
+
+// RLink is a location a resource can be retrieved from
+type RLink struct {
+	Href      string `json:"href" yaml:"href"`
+	MediaType string `json:"media-type,omitempty" yaml:"media-type,omitempty"`
+}
+
+// Parse reads an OSCAL catalog or profile from JSON or YAML. JSON is valid YAML, so one decoder reads both.
+func Parse(data []byte) (*Document, error) {
+	var doc Document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OSCAL document: %w", err)
+	}
+	switch {
+	case doc.Catalog != nil && doc.Profile != nil:
+		return nil, fmt.Errorf("OSCAL document must contain exactly one of catalog or profile")
+	case doc.Catalog == nil && doc.Profile == nil:
+		return nil, fmt.Errorf("OSCAL document has no catalog or profile; other OSCAL models are not supported")
+	}
+	return &doc, nil
+}
+
+// Model returns the name of the OSCAL model the document holds
+func (d *Document) Model() string {
+	switch {
+	case d.Catalog != nil:
+		return "catalog"
+	case d.ComponentDefinition != nil:
+		return "component-definition"
+	case d.AssessmentResults != nil:
+		return "assessment-results"
+	}
+	return "profile"
+}
+
+// UUID returns a name-based (version 5 style) UUID for the given names, so exporting the same
+// artifact twice yields the same identifiers
+func UUID(names ...string) string {
+	sum := sha1.Sum([]byte("gemara-oscal:" + strings.Join(names, "\x00")))
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// ParamIndex returns every parameter of the catalog, keyed by ID
+func (c *Catalog) ParamIndex() map[string]Parameter {
+	params := make(map[string]Parameter)
+	addParams(params, c.Params)
+	var walkControls func(controls []Control)
+	walkControls = func(controls []Control) {
+		for _, control := range controls {
+			addParams(params, control.Params)
+			walkControls(control.Controls)
+		}
+	}
+	var walkGroups func(groups []Group)
+	walkGroups = func(groups []Group) {
+		for _, group := range groups {
+			addParams(params, group.Params)
+			walkControls(group.Controls)
+			walkGroups(group.Groups)
+		}
+	}
+	walkControls(c.Controls)
+	walkGroups(c.Groups)
+	return params
+}
+
+func addParams(index map[string]Parameter, params []Parameter) {
+	for _, param := range params {
+		index[param.ID] = param
+	}
+}
+
+// FindControl returns the control with the given ID anywhere in the catalog
+func (c *Catalog) FindControl(id string) *Control {
+	var found *Control
+	var walkControls func(controls []Control)
+	walkControls = func(controls []Control) {
+		for i := range controls {
+			if found != nil {
+				return
+			}
+			if controls[i].ID == id {
+				found = &controls[i]
+				return
+			}
+			walkControls(controls[i].Controls)
+		}
+	}
+	var walkGroups func(groups []Group)
+	walkGroups = func(groups []Group) {
+		for _, group := range groups {
+			walkControls(group.Controls)
+			walkGroups(group.Groups)
+		}
+	}
+	walkControls(c.Controls)
+	walkGroups(c.Groups)
+	return found
+}
+
+// Prop returns the value of the first property with the given name, or ""
+func Prop(props []Property, name string) string {
+	for _, prop := range props {
+		if prop.Name == name {
+			return prop.Value
+		}
+	}
+	return ""
+}
+
+// Withdrawn reports whether a control is marked as withdrawn
+func (c *Control) Withdrawn() bool {
+	return strings.EqualFold(Prop(c.Props, "status"), "withdrawn")
+}
+
+// Part returns the first part of the control with the given name, or nil
+func (c *Control) Part(name string) *Part {
+	return findPart(c.Parts, name)
+}
+
+// Part returns the first direct sub-part with the given name, or nil
+func (p *Part) Part(name string) *Part {
+	return findPart(p.Parts, name)
+}
+
+func findPart(parts []Part, name string) *Part {
+	for i := range parts {
+		if parts[i].Name == name {
+			return &parts[i]
+		}
+	}
+	return nil
+}
+
+// insertParam matches parameter insertion points in prose
+var insertParam = regexp.MustCompile(`\{\{\s*insert:\s*param,\s*([^\s}]+)\s*\}\}`)
+
+// ResolveProse replaces parameter insertion points with the parameter's values, or with an
+// assignment or selection placeholder in the style of NIST SP 800-53 when no value is set
+func ResolveProse(prose string, params map[string]Parameter) string {
+	return resolveProse(prose, params, 0)
+}
+
+func resolveProse(prose string, params map[string]Parameter, depth int) string {
+	return insertParam.ReplaceAllStringFunc(prose, func(match string) string {
+		id := insertParam.FindStringSubmatch(match)[1]
+		param, ok := params[id]
+		if !ok || depth > 3 {
+			return fmt.Sprintf("[Assignment: %s]", id)
+		}
+		return resolveProse(param.Text(), params, depth+1)
+	})
+}
+
+// Text renders a parameter as it reads inside control prose
+func (p Parameter) Text() string {
+	switch {
+	case len(p.Values) > 0:
+		return strings.Join(p.Values, ", ")
+	case p.Select != nil:
+		kind := "Selection"
+		if p.Select.HowMany == "one-or-more" {
+			kind = "Selection (one or more)"
+		}
+		return fmt.Sprintf("[%s: %s]", kind, strings.Join(p.Select.Choice, "; "))
+	case p.Label != "":
+		return fmt.Sprintf("[Assignment: %s]", p.Label)
+	}
+	return fmt.Sprintf("[Assignment: %s]", p.ID)
+}
+
+// Apply returns the parameter with a profile's settings applied
+func (s SetParameter) Apply(param Parameter) Parameter {
+	if param.ID == "" {
+		param.ID = s.ParamID
+	}
+	if s.Label != "" {
+		param.Label = s.Label
+	}
+	if len(s.Values) > 0 {
+		param.Values = s.Values
+	}
+	if s.Select != nil {
+		param.Select = s.Select
+	}
+	return param
+}
+
+// Resource returns the back-matter resource with the given UUID, or nil
+func (b *BackMatter) Resource(uuid string) *Resource {
+	if b == nil {
+		return nil
+	}
+	for i := range b.Resources {
+		if b.Resources[i].UUID == uuid {
+			return &b.Resources[i]
+		}
+	}
+	return nil
+}
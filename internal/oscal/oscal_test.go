@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package oscal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		model   string
+		wantErr string
+	}{
+		{
+			name:    "JSON catalog",
+			content: `{"catalog": {"uuid": "c1", "metadata": {"title": "Catalog"}, "groups": [{"id": "ac", "title": "Access Control", "controls": [{"id": "ac-1", "title": "Policy"}]}]}}`,
+			model:   "catalog",
+		},
+		{
+			name:    "YAML profile",
+			content: "profile:\n  uuid: p1\n  metadata:\n    title: Baseline\n  imports:\n    - href: catalog.json\n      include-all: {}\n",
+			model:   "profile",
+		},
+		{
+			name:    "unsupported model",
+			content: `{"system-security-plan": {"uuid": "s1"}}`,
+			wantErr: "no catalog or profile",
+		},
+		{
+			name:    "invalid content",
+			content: "catalog: [",
+			wantErr: "failed to parse",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse([]byte(tt.content))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.model, doc.Model())
+		})
+	}
+
+	doc, err := Parse([]byte(tests[0].content))
+	require.NoError(t, err)
+	require.NotNil(t, doc.Catalog.FindControl("ac-1"))
+	assert.Equal(t, "Policy", doc.Catalog.FindControl("ac-1").Title)
+	assert.Nil(t, doc.Catalog.FindControl("ac-2"))
+}
+
+func TestResolveProse(t *testing.T) {
+	params := map[string]Parameter{
+		"p1": {ID: "p1", Label: "organization-defined frequency"},
+		"p2": {ID: "p2", Select: &ParameterSelection{HowMany: "one-or-more", Choice: []string{"daily", "weekly"}}},
+		"p3": {ID: "p3", Values: []string{"30 days"}},
+		"p4": {ID: "p4", Select: &ParameterSelection{Choice: []string{"{{ insert: param, p3 }}", "never"}}},
+	}
+	tests := []struct {
+		prose string
+		want  string
+	}{
+		{"Review {{ insert: param, p1 }}.", "Review [Assignment: organization-defined frequency]."},
+		{"Scan {{insert: param, p2}}.", "Scan [Selection (one or more): daily; weekly]."},
+		{"Rotate every {{ insert: param, p3 }}.", "Rotate every 30 days."},
+		{"Expire after {{ insert: param, p4 }}.", "Expire after [Selection: 30 days; never]."},
+		{"Unknown {{ insert: param, p9 }}.", "Unknown [Assignment: p9]."},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ResolveProse(tt.prose, params))
+	}
+
+	set := SetParameter{ParamID: "p1", Values: []string{"quarterly"}}
+	assert.Equal(t, "quarterly", set.Apply(params["p1"]).Text())
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package oscal
+
+// ComponentDefinition describes how components implement the controls of a catalog or profile
+type ComponentDefinition struct {
+	UUID       string      `json:"uuid" yaml:"uuid"`
+	Metadata   Metadata    `json:"metadata" yaml:"metadata"`
+	Components []Component `json:"components,omitempty" yaml:"components,omitempty"`
+	BackMatter *BackMatter `json:"back-matter,omitempty" yaml:"back-matter,omitempty"`
+}
+
+// Component is a system element that implements controls
+type Component struct {
+	UUID                   string                  `json:"uuid" yaml:"uuid"`
+	Type                   string                  `json:"type" yaml:"type"`
+	Title                  string                  `json:"title" yaml:"title"`
+	Description            string                  `json:"description" yaml:"description"`
+	Props                  []Property              `json:"props,omitempty" yaml:"props,omitempty"`
+	ControlImplementations []ControlImplementation `json:"control-implementations,omitempty" yaml:"control-implementations,omitempty"`
+}
+
+// ControlImplementation is the set of controls a component implements from one source
+type ControlImplementation struct {
+	UUID                    string                   `json:"uuid" yaml:"uuid"`
+	Source                  string                   `json:"source" yaml:"source"`
+	Description             string                   `json:"description" yaml:"description"`
+	Props                   []Property               `json:"props,omitempty" yaml:"props,omitempty"`
+	ImplementedRequirements []ImplementedRequirement `json:"implemented-requirements" yaml:"implemented-requirements"`
+}
+
+// ImplementedRequirement describes how a component satisfies one control
+type ImplementedRequirement struct {
+	UUID        string      `json:"uuid" yaml:"uuid"`
+	ControlID   string      `json:"control-id" yaml:"control-id"`
+	Description string      `json:"description" yaml:"description"`
+	Props       []Property  `json:"props,omitempty" yaml:"props,omitempty"`
+	Statements  []Statement `json:"statements,omitempty" yaml:"statements,omitempty"`
+	Remarks     string      `json:"remarks,omitempty" yaml:"remarks,omitempty"`
+}
+
+// Statement describes how a component satisfies one part of a control
+type Statement struct {
+	StatementID string `json:"statement-id" yaml:"statement-id"`
+	UUID        string `json:"uuid" yaml:"uuid"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Remarks     string `json:"remarks,omitempty" yaml:"remarks,omitempty"`
+}
+
+// AssessmentResults records the outcome of assessing a system against its controls
+type AssessmentResults struct {
+	UUID       string      `json:"uuid" yaml:"uuid"`
+	Metadata   Metadata    `json:"metadata" yaml:"metadata"`
+	ImportAP   ImportAP    `json:"import-ap" yaml:"import-ap"`
+	Results    []Result    `json:"results" yaml:"results"`
+	BackMatter *BackMatter `json:"back-matter,omitempty" yaml:"back-matter,omitempty"`
+}
+
+// ImportAP references the assessment plan the results were produced under
+type ImportAP struct {
+	Href string `json:"href" yaml:"href"`
+}
+
+// Result is one assessment run
+type Result struct {
+	UUID             string           `json:"uuid" yaml:"uuid"`
+	Title            string           `json:"title" yaml:"title"`
+	Description      string           `json:"description" yaml:"description"`
+	Start            string           `json:"start" yaml:"start"`
+	End              string           `json:"end,omitempty" yaml:"end,omitempty"`
+	Props            []Property       `json:"props,omitempty" yaml:"props,omitempty"`
+	ReviewedControls ReviewedControls `json:"reviewed-controls" yaml:"reviewed-controls"`
+	Observations     []Observation    `json:"observations,omitempty" yaml:"observations,omitempty"`
+	Findings         []Finding        `json:"findings,omitempty" yaml:"findings,omitempty"`
+	Remarks          string           `json:"remarks,omitempty" yaml:"remarks,omitempty"`
+}
+
+// ReviewedControls lists the controls an assessment covered
+type ReviewedControls struct {
+	ControlSelections []ControlSelection `json:"control-selections" yaml:"control-selections"`
+}
+
+// ControlSelection selects assessed controls by ID
+type ControlSelection struct {
+	IncludeControls []AssessedControl `json:"include-controls,omitempty" yaml:"include-controls,omitempty"`
+}
+
+// AssessedControl names an assessed control
+type AssessedControl struct {
+	ControlID string `json:"control-id" yaml:"control-id"`
+}
+
+// Observation is evidence gathered by an assessment method
+type Observation struct {
+	UUID        string     `json:"uuid" yaml:"uuid"`
+	Title       string     `json:"title,omitempty" yaml:"title,omitempty"`
+	Description string     `json:"description" yaml:"description"`
+	Props       []Property `json:"props,omitempty" yaml:"props,omitempty"`
+	Methods     []string   `json:"methods" yaml:"methods"`
+	Collected   string     `json:"collected" yaml:"collected"`
+	Remarks     string     `json:"remarks,omitempty" yaml:"remarks,omitempty"`
+}
+
+// Finding is the conclusion of an assessment for one control or objective
+type Finding struct {
+	UUID                string               `json:"uuid" yaml:"uuid"`
+	Title               string               `json:"title" yaml:"title"`
+	Description         string               `json:"description" yaml:"description"`
+	Props               []Property           `json:"props,omitempty" yaml:"props,omitempty"`
+	Target              FindingTarget        `json:"target" yaml:"target"`
+	RelatedObservations []RelatedObservation `json:"related-observations,omitempty" yaml:"related-observations,omitempty"`
+	Remarks             string               `json:"remarks,omitempty" yaml:"remarks,omitempty"`
+}
+
+// FindingTarget is the control or objective a finding concludes on
+type FindingTarget struct {
+	Type     string          `json:"type" yaml:"type"`
+	TargetID string          `json:"target-id" yaml:"target-id"`
+	Status   ObjectiveStatus `json:"status" yaml:"status"`
+	Remarks  string          `json:"remarks,omitempty" yaml:"remarks,omitempty"`
+}
+
+// ObjectiveStatus reports whether a target was satisfied
+type ObjectiveStatus struct {
+	State  string `json:"state" yaml:"state"`
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// RelatedObservation references an observation supporting a finding
+type RelatedObservation struct {
+	ObservationUUID string `json:"observation-uuid" yaml:"observation-uuid"`
+}
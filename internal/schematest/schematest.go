@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schematest serves the Gemara CUE schemas to tests, so that tests which validate with CUE
+// run without network access.
+package schematest
+
+import (
+	"bytes"
+	"embed"
+	"io"
+	"net/http"
+	"path"
+)
+
+// schemas are the schemas of the pinned gemara module
+//
+//go:embed schemas/*.cue
+var schemas embed.FS
+
+// transport answers every request with the embedded schema of the same file name
+type transport struct{}
+
+func (transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	data, err := schemas.ReadFile(path.Join("schemas", path.Base(r.URL.Path)))
+	if err != nil {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewReader(nil)), Request: r}, nil
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(data)), Request: r}, nil
+}
+
+// Client returns an HTTP client that serves schema requests from the embedded schemas
+func Client() *http.Client {
+	return &http.Client{Transport: transport{}}
+}
@@ -0,0 +1,259 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package scope
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// TaxonomyFileName is the name of the taxonomy file looked up in the artifacts directory
+const TaxonomyFileName = "scope-taxonomy.yaml"
+
+//go:embed taxonomy.yaml
+var defaultTaxonomy []byte
+
+// Term is a controlled vocabulary entry with synonyms and an optional parent term
+type Term struct {
+	ID       string   `yaml:"id"`
+	Name     string   `yaml:"name"`
+	Synonyms []string `yaml:"synonyms,omitempty"`
+	Parent   string   `yaml:"parent,omitempty"`
+}
+
+// Taxonomy groups scope terms by dimension
+type Taxonomy struct {
+	Boundaries   []Term `yaml:"boundaries,omitempty"`
+	Technologies []Term `yaml:"technologies,omitempty"`
+	Providers    []Term `yaml:"providers,omitempty"`
+}
+
+// Dimension names a group of taxonomy terms. Synonyms only name terms of their own dimension, so
+// a technology scope of "IT" does not match Italy.
+type Dimension string
+
+// Dimensions scope values are resolved in. Sensitivity has no taxonomy terms, so its values match
+// term IDs and names only.
+const (
+	Boundaries   Dimension = "boundaries"
+	Technologies Dimension = "technologies"
+	Providers    Dimension = "providers"
+	Sensitivity  Dimension = "sensitivity"
+)
+
+// Engine matches scope terms against applicability values using a taxonomy.
+// Values are compared after normalization, never by substring: two values match when they are
+// equal, are names of the same term, or name terms where one is an ancestor of the other.
+// Term IDs and names resolve in every dimension; synonyms resolve in the engine's dimension, or in
+// any dimension they are unambiguous in when the engine has none.
+type Engine struct {
+	terms     map[string]*Term
+	names     map[string]string
+	synonyms  map[Dimension]map[string]string
+	dimension Dimension
+}
+
+// DefaultEngine returns an engine using the embedded default taxonomy
+func DefaultEngine() *Engine {
+	engine, err := ParseEngine(defaultTaxonomy)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded scope taxonomy: %v", err))
+	}
+	return engine
+}
+
+// LoadEngine returns an engine using the taxonomy file at path
+func LoadEngine(path string) (*Engine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scope taxonomy: %w", err)
+	}
+	engine, err := ParseEngine(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scope taxonomy %s: %w", path, err)
+	}
+	return engine, nil
+}
+
+// ParseEngine returns an engine using the given taxonomy YAML
+func ParseEngine(data []byte) (*Engine, error) {
+	taxonomy := &Taxonomy{}
+	if err := yaml.UnmarshalWithOptions(data, taxonomy, yaml.Strict()); err != nil {
+		return nil, fmt.Errorf("failed to parse taxonomy: %w", err)
+	}
+	return NewEngine(taxonomy)
+}
+
+// NewEngine indexes a taxonomy and checks that names are unique, synonyms are unique within their
+// dimension, and parents exist without cycles
+func NewEngine(taxonomy *Taxonomy) (*Engine, error) {
+	e := &Engine{
+		terms:    make(map[string]*Term),
+		names:    make(map[string]string),
+		synonyms: make(map[Dimension]map[string]string),
+	}
+
+	dimensions := []struct {
+		dimension Dimension
+		terms     []Term
+	}{
+		{Boundaries, taxonomy.Boundaries},
+		{Technologies, taxonomy.Technologies},
+		{Providers, taxonomy.Providers},
+	}
+
+	for _, d := range dimensions {
+		for i := range d.terms {
+			term := &d.terms[i]
+			if term.ID == "" {
+				return nil, fmt.Errorf("term %q has no id", term.Name)
+			}
+			if _, exists := e.terms[term.ID]; exists {
+				return nil, fmt.Errorf("duplicate term id %q", term.ID)
+			}
+			e.terms[term.ID] = term
+			for _, name := range []string{term.ID, term.Name} {
+				key := normalize(name)
+				if key == "" {
+					continue
+				}
+				if owner, exists := e.names[key]; exists && owner != term.ID {
+					return nil, fmt.Errorf("%q names both %q and %q", name, owner, term.ID)
+				}
+				e.names[key] = term.ID
+			}
+		}
+	}
+
+	for _, d := range dimensions {
+		synonyms := make(map[string]string)
+		for _, term := range d.terms {
+			for _, synonym := range term.Synonyms {
+				key := normalize(synonym)
+				if key == "" {
+					continue
+				}
+				owner, exists := synonyms[key]
+				if !exists {
+					owner, exists = e.names[key]
+				}
+				if exists && owner != term.ID {
+					return nil, fmt.Errorf("%q names both %q and %q", synonym, owner, term.ID)
+				}
+				synonyms[key] = term.ID
+			}
+		}
+		e.synonyms[d.dimension] = synonyms
+	}
+
+	for _, term := range e.terms {
+		seen := map[string]bool{term.ID: true}
+		for parent := term.Parent; parent != ""; parent = e.terms[parent].Parent {
+			if _, exists := e.terms[parent]; !exists {
+				return nil, fmt.Errorf("term %q has unknown parent %q", term.ID, parent)
+			}
+			if seen[parent] {
+				return nil, fmt.Errorf("term %q has a cyclic parent chain", term.ID)
+			}
+			seen[parent] = true
+		}
+	}
+
+	return e, nil
+}
+
+// Dimension returns an engine that resolves synonyms of the given dimension only
+func (e *Engine) Dimension(dimension Dimension) *Engine {
+	scoped := *e
+	scoped.dimension = dimension
+	return &scoped
+}
+
+// Canonical returns the term ID named by value, or the normalized value if it is not in the taxonomy
+func (e *Engine) Canonical(value string) string {
+	key := normalize(value)
+	if id, ok := e.names[key]; ok {
+		return id
+	}
+	if e.dimension != "" {
+		if id, ok := e.synonyms[e.dimension][key]; ok {
+			return id
+		}
+		return key
+	}
+	// Without a dimension, a synonym only resolves when every dimension that uses it names the same term
+	resolved := ""
+	for _, synonyms := range e.synonyms {
+		if id, ok := synonyms[key]; ok {
+			if resolved != "" && resolved != id {
+				return key
+			}
+			resolved = id
+		}
+	}
+	if resolved != "" {
+		return resolved
+	}
+	return key
+}
+
+// Matches reports whether a scope term and an applicability value refer to the same
+// or to hierarchically related terms, in either direction
+func (e *Engine) Matches(term, value string) bool {
+	return e.Covers(term, value) || e.Covers(value, term)
+}
+
+// Covers reports whether value names the same term as scope or one of its descendants
+func (e *Engine) Covers(scope, value string) bool {
+	scopeID := e.Canonical(scope)
+	valueID := e.Canonical(value)
+	if scopeID == "" || valueID == "" {
+		return false
+	}
+	if scopeID == valueID {
+		return true
+	}
+	term, ok := e.terms[valueID]
+	for ok && term.Parent != "" {
+		if term.Parent == scopeID {
+			return true
+		}
+		term, ok = e.terms[term.Parent]
+	}
+	return false
+}
+
+// MatchingTerms returns the scope terms that match any of the values
+func (e *Engine) MatchingTerms(terms, values []string) []string {
+	return e.filterTerms(terms, values, e.Matches)
+}
+
+// CoveringTerms returns the scope terms that cover any of the values
+func (e *Engine) CoveringTerms(terms, values []string) []string {
+	return e.filterTerms(terms, values, e.Covers)
+}
+
+func (e *Engine) filterTerms(terms, values []string, match func(term, value string) bool) []string {
+	var matched []string
+	for _, term := range terms {
+		for _, value := range values {
+			if match(term, value) {
+				matched = append(matched, term)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// normalize lowercases a value and collapses whitespace, hyphens, and underscores into single spaces
+func normalize(value string) string {
+	fields := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_' || r == '\t' || r == '\n'
+	})
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package scope
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngineMatches(t *testing.T) {
+	engine := DefaultEngine()
+
+	tests := []struct {
+		name    string
+		term    string
+		value   string
+		matches bool
+		covers  bool
+	}{
+		{name: "identical values", term: "Cloud Computing", value: "cloud computing", matches: true, covers: true},
+		{name: "synonym resolves to term", term: "EU", value: "European Union", matches: true, covers: true},
+		{name: "term id resolves to term", term: "european-union", value: "EU", matches: true, covers: true},
+		{name: "parent covers child", term: "European Union", value: "Germany", matches: true, covers: true},
+		{name: "grandparent covers grandchild", term: "AI", value: "LLM", matches: true, covers: true},
+		{name: "child matches parent but does not cover it", term: "Germany", value: "European Union", matches: true, covers: false},
+		{name: "no substring matching", term: "AI", value: "Mobile Devices", matches: false, covers: false},
+		{name: "siblings do not match", term: "Germany", value: "France", matches: false, covers: false},
+		{name: "unknown values match exactly", term: "Maturity2", value: "maturity2", matches: true, covers: true},
+		{name: "unknown values do not match by prefix", term: "Maturity", value: "Maturity2", matches: false, covers: false},
+		{name: "empty value never matches", term: "", value: "", matches: false, covers: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.matches, engine.Matches(tt.term, tt.value))
+			assert.Equal(t, tt.covers, engine.Covers(tt.term, tt.value))
+		})
+	}
+}
+
+func TestEngineDimensions(t *testing.T) {
+	engine := DefaultEngine()
+
+	tests := []struct {
+		name      string
+		dimension Dimension
+		term      string
+		value     string
+		matches   bool
+	}{
+		{name: "geopolitical synonym", dimension: Boundaries, term: "IT", value: "Italy", matches: true},
+		{name: "geopolitical synonym as value", dimension: Boundaries, term: "European Union", value: "IT", matches: true},
+		{name: "technology IT is not Italy", dimension: Technologies, term: "IT", value: "Italy", matches: false},
+		{name: "technology IT matches itself", dimension: Technologies, term: "IT", value: "it", matches: true},
+		{name: "provider CA is not Canada", dimension: Providers, term: "CA", value: "Canada", matches: false},
+		{name: "technology synonym", dimension: Technologies, term: "AI", value: "Large Language Models", matches: true},
+		{name: "names resolve in every dimension", dimension: Technologies, term: "European Union", value: "Germany", matches: true},
+		{name: "sensitivity has no synonyms", dimension: Sensitivity, term: "EU", value: "European Union", matches: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.matches, engine.Dimension(tt.dimension).Matches(tt.term, tt.value))
+		})
+	}
+}
+
+func TestParseEngine(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name: "valid taxonomy",
+			yaml: `boundaries:
+  - {id: region, name: Region}
+  - {id: city, name: City, synonyms: [Town], parent: region}`,
+		},
+		{
+			name:    "unknown parent",
+			yaml:    `technologies: [{id: child, name: Child, parent: missing}]`,
+			wantErr: "unknown parent",
+		},
+		{
+			name: "duplicate alias across terms",
+			yaml: `providers:
+  - {id: one, name: One, synonyms: [Shared]}
+  - {id: two, name: Two, synonyms: [Shared]}`,
+			wantErr: "names both",
+		},
+		{
+			name: "same synonym in different dimensions",
+			yaml: `boundaries: [{id: italy, name: Italy, synonyms: [IT]}]
+technologies: [{id: information-technology, name: Information Technology, synonyms: [IT]}]`,
+		},
+		{
+			name: "synonym of another term's name",
+			yaml: `boundaries: [{id: italy, name: Italy}]
+technologies: [{id: it, name: Information Technology, synonyms: [Italy]}]`,
+			wantErr: "names both",
+		},
+		{
+			name: "cyclic parents",
+			yaml: `boundaries:
+  - {id: a, name: A, parent: b}
+  - {id: b, name: B, parent: a}`,
+			wantErr: "cyclic",
+		},
+		{
+			name:    "unknown field",
+			yaml:    `boundaries: [{id: a, name: A, aliases: [x]}]`,
+			wantErr: "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseEngine([]byte(tt.yaml))
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
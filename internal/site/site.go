@@ -0,0 +1,350 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package site renders Gemara artifacts as a static, cross-linked documentation site.
+// Guidance documents, catalogs and policies each get a page; pages link guidelines to the
+// controls mapped to them and controls to the policies that import them. The site also
+// carries a family index and a search index (search.json) for client-side search.
+package site
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/ossf/gemara"
+)
+
+// Output formats
+const (
+	FormatHTML     = "html"
+	FormatMarkdown = "markdown"
+)
+
+// Page kinds, also used as the directory of artifact pages
+const (
+	KindGuidance = "guidance"
+	KindCatalog  = "catalogs"
+	KindPolicy   = "policies"
+)
+
+// SearchIndexFile is the name of the search index written at the site root
+const SearchIndexFile = "search.json"
+
+//go:embed templates
+var defaultTemplates embed.FS
+
+// Artifacts are the documents rendered into a site, keyed by artifact ID
+type Artifacts struct {
+	Guidance map[string]*gemara.GuidanceDocument
+	Catalogs map[string]*gemara.Catalog
+	Policies map[string]*gemara.Policy
+}
+
+// Options control how a site is rendered
+type Options struct {
+	// Format is html (default) or markdown
+	Format string
+	// Title is shown in the header of every page
+	Title string
+	// TemplateDir holds templates that replace the embedded templates of the same name
+	TemplateDir string
+}
+
+// Page is a rendered file of the site
+type Page struct {
+	Path    string `json:"path"`
+	Title   string `json:"title"`
+	Content []byte `json:"-"`
+}
+
+// Renderer turns a set of artifacts into pages
+type Renderer struct {
+	options   Options
+	artifacts Artifacts
+	links     *linkIndex
+}
+
+// New creates a renderer for the artifacts
+func New(artifacts Artifacts, options Options) (*Renderer, error) {
+	if options.Format == "" {
+		options.Format = FormatHTML
+	}
+	if options.Format != FormatHTML && options.Format != FormatMarkdown {
+		return nil, fmt.Errorf("invalid format %q: must be %s or %s", options.Format, FormatHTML, FormatMarkdown)
+	}
+	if options.Title == "" {
+		options.Title = "Gemara Artifacts"
+	}
+	if options.TemplateDir != "" {
+		if info, err := os.Stat(options.TemplateDir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("template directory %s does not exist", options.TemplateDir)
+		}
+	}
+	if artifacts.Guidance == nil {
+		artifacts.Guidance = map[string]*gemara.GuidanceDocument{}
+	}
+	if artifacts.Catalogs == nil {
+		artifacts.Catalogs = map[string]*gemara.Catalog{}
+	}
+	if artifacts.Policies == nil {
+		artifacts.Policies = map[string]*gemara.Policy{}
+	}
+	r := &Renderer{options: options, artifacts: artifacts}
+	r.links = r.buildLinkIndex()
+	return r, nil
+}
+
+// Pages renders every page of the site, including the search index
+func (r *Renderer) Pages() ([]Page, error) {
+	var pages []Page
+	add := func(page Page, err error) error {
+		if err != nil {
+			return err
+		}
+		pages = append(pages, page)
+		return nil
+	}
+
+	if err := add(r.render("index", "", r.options.Title, r.indexView())); err != nil {
+		return nil, err
+	}
+	if err := add(r.render("families", "", "Families", r.familiesView())); err != nil {
+		return nil, err
+	}
+	for _, kind := range []string{KindGuidance, KindCatalog, KindPolicy} {
+		for _, id := range r.ids(kind) {
+			if err := add(r.Page(kind, id)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if r.options.Format == FormatHTML {
+		style, err := r.readTemplate("style.css")
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, Page{Path: "style.css", Title: "Stylesheet", Content: style})
+	}
+
+	search, err := json.MarshalIndent(r.searchIndex(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal search index: %w", err)
+	}
+	pages = append(pages, Page{Path: SearchIndexFile, Title: "Search index", Content: append(search, '\n')})
+	return pages, nil
+}
+
+// Page renders the page of a single artifact
+func (r *Renderer) Page(kind, id string) (Page, error) {
+	root := "../"
+	switch kind {
+	case KindGuidance:
+		if guidance, ok := r.artifacts.Guidance[id]; ok {
+			return r.render("guidance", root, pageTitle(guidance.Title, id), r.guidanceView(id, guidance))
+		}
+	case KindCatalog:
+		if catalog, ok := r.artifacts.Catalogs[id]; ok {
+			return r.render("catalog", root, pageTitle(catalog.Title, id), r.catalogView(id, catalog))
+		}
+	case KindPolicy:
+		if policy, ok := r.artifacts.Policies[id]; ok {
+			return r.render("policy", root, pageTitle(policy.Title, id), r.policyView(id, policy))
+		}
+	default:
+		return Page{}, fmt.Errorf("unknown page kind %q", kind)
+	}
+	return Page{}, fmt.Errorf("artifact %s not found in %s", id, kind)
+}
+
+// Write renders the site into dir and returns the written pages
+func (r *Renderer) Write(dir string) ([]Page, error) {
+	pages, err := r.Pages()
+	if err != nil {
+		return nil, err
+	}
+	for _, page := range pages {
+		target := filepath.Join(dir, filepath.FromSlash(page.Path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", page.Path, err)
+		}
+		if err := os.WriteFile(target, page.Content, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", page.Path, err)
+		}
+	}
+	return pages, nil
+}
+
+// PagePath returns the site-relative path of an artifact page
+func (r *Renderer) PagePath(kind, id string) string {
+	return path.Join(kind, id+r.extension())
+}
+
+// pageData is passed to every page template
+type pageData struct {
+	Site  string
+	Title string
+	// Root is the relative path from the page to the site root
+	Root string
+	View any
+}
+
+// render executes the page template name wrapped in the layout template
+func (r *Renderer) render(name, root, title string, view any) (Page, error) {
+	data := pageData{Site: r.options.Title, Title: title, Root: root, View: view}
+
+	var buf bytes.Buffer
+	var execute func(io.Writer, any) error
+	layout, err := r.readTemplate("layout" + r.templateExtension())
+	if err != nil {
+		return Page{}, err
+	}
+	body, err := r.readTemplate(name + r.templateExtension())
+	if err != nil {
+		return Page{}, err
+	}
+	if r.options.Format == FormatHTML {
+		tmpl, err := htmltemplate.New("layout").Funcs(htmltemplate.FuncMap(r.funcs())).Parse(string(layout))
+		if err == nil {
+			_, err = tmpl.Parse(string(body))
+		}
+		if err != nil {
+			return Page{}, fmt.Errorf("failed to parse %s template: %w", name, err)
+		}
+		execute = tmpl.Execute
+	} else {
+		tmpl, err := texttemplate.New("layout").Funcs(texttemplate.FuncMap(r.funcs())).Parse(string(layout))
+		if err == nil {
+			_, err = tmpl.Parse(string(body))
+		}
+		if err != nil {
+			return Page{}, fmt.Errorf("failed to parse %s template: %w", name, err)
+		}
+		execute = tmpl.Execute
+	}
+	if err := execute(&buf, data); err != nil {
+		return Page{}, fmt.Errorf("failed to render %s page: %w", name, err)
+	}
+
+	pagePath := name + r.extension()
+	switch view := view.(type) {
+	case *guidanceView:
+		pagePath = r.PagePath(KindGuidance, view.ID)
+	case *catalogView:
+		pagePath = r.PagePath(KindCatalog, view.ID)
+	case *policyView:
+		pagePath = r.PagePath(KindPolicy, view.ID)
+	}
+	return Page{Path: pagePath, Title: title, Content: buf.Bytes()}, nil
+}
+
+// readTemplate returns the template file from the template directory if present,
+// falling back to the embedded default
+func (r *Renderer) readTemplate(name string) ([]byte, error) {
+	if r.options.TemplateDir != "" {
+		data, err := os.ReadFile(filepath.Join(r.options.TemplateDir, name))
+		if err == nil {
+			return data, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read template %s: %w", name, err)
+		}
+	}
+	data, err := defaultTemplates.ReadFile(path.Join("templates", r.options.Format, name))
+	if err != nil {
+		return nil, fmt.Errorf("template %s not found: %w", name, err)
+	}
+	return data, nil
+}
+
+// funcs are the helpers available to templates
+func (r *Renderer) funcs() map[string]any {
+	return map[string]any{
+		"join": strings.Join,
+		"list": func(values ...any) []any { return values },
+		// dict builds a map from key/value pairs so partial templates can take several arguments
+		"dict": func(pairs ...any) (map[string]any, error) {
+			if len(pairs)%2 != 0 {
+				return nil, fmt.Errorf("dict needs key/value pairs")
+			}
+			values := map[string]any{}
+			for i := 0; i < len(pairs); i += 2 {
+				key, ok := pairs[i].(string)
+				if !ok {
+					return nil, fmt.Errorf("dict key %v is not a string", pairs[i])
+				}
+				values[key] = pairs[i+1]
+			}
+			return values, nil
+		},
+		// cell escapes text for a Markdown table cell
+		"cell": func(text string) string {
+			text = strings.ReplaceAll(strings.TrimSpace(text), "|", "\\|")
+			return strings.ReplaceAll(text, "\n", "<br>")
+		},
+		"href": href,
+		// mdlink renders a link as Markdown, or plain text when it has no target
+		"mdlink": func(root string, link Link) string {
+			if link.URL == "" {
+				return link.Text
+			}
+			return fmt.Sprintf("[%s](%s)", link.Text, href(root, link))
+		},
+	}
+}
+
+// pageTitle returns the title of an artifact page, falling back to the artifact ID
+func pageTitle(title, id string) string {
+	if title == "" {
+		return id
+	}
+	return title
+}
+
+// href resolves a link against the relative path to the site root, leaving external URLs as they are
+func href(root string, link Link) string {
+	if strings.Contains(link.URL, "://") {
+		return link.URL
+	}
+	return root + link.URL
+}
+
+func (r *Renderer) extension() string {
+	if r.options.Format == FormatMarkdown {
+		return ".md"
+	}
+	return ".html"
+}
+
+func (r *Renderer) templateExtension() string {
+	return r.extension() + ".tmpl"
+}
+
+// ids returns the sorted IDs of the artifacts of a page kind
+func (r *Renderer) ids(kind string) []string {
+	var ids []string
+	switch kind {
+	case KindGuidance:
+		for id := range r.artifacts.Guidance {
+			ids = append(ids, id)
+		}
+	case KindCatalog:
+		for id := range r.artifacts.Catalogs {
+			ids = append(ids, id)
+		}
+	case KindPolicy:
+		for id := range r.artifacts.Policies {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package site
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ossf/gemara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testArtifacts() Artifacts {
+	return Artifacts{
+		Guidance: map[string]*gemara.GuidanceDocument{
+			"baseline-guidance": {
+				Title:    "Baseline Guidance",
+				Metadata: gemara.Metadata{Id: "baseline-guidance", Description: "Guidance <for> tests"},
+				Families: []gemara.Family{{Id: "ia", Title: "Identification"}},
+				Guidelines: []gemara.Guideline{{
+					Id: "G-1", Title: "Use MFA", Family: "ia", Objective: "Require a second factor.",
+					Statements: []gemara.Statement{{Id: "G-1.a", Text: "Administrators use MFA."}},
+				}},
+			},
+		},
+		Catalogs: map[string]*gemara.Catalog{
+			"platform-controls": {
+				Title: "Platform Controls",
+				Metadata: gemara.Metadata{
+					Id: "platform-controls",
+					MappingReferences: []gemara.MappingReference{
+						{Id: "baseline-guidance", Title: "Baseline Guidance"},
+						{Id: "NIST", Title: "NIST SP 800-53", Url: "https://example.com/800-53"},
+					},
+				},
+				Families: []gemara.Family{{Id: "ac", Title: "Access Control"}},
+				Controls: []gemara.Control{
+					{
+						Id: "AC-01", Title: "MFA", Objective: "Require MFA.", Family: "ac",
+						AssessmentRequirements: []gemara.AssessmentRequirement{{Id: "AC-01.01", Text: "MFA | enforced", Applicability: []string{"prod"}}},
+						GuidelineMappings: []gemara.MultiMapping{
+							{ReferenceId: "baseline-guidance", Entries: []gemara.MappingEntry{{ReferenceId: "G-1.a", Strength: 9}}},
+							{ReferenceId: "NIST", Entries: []gemara.MappingEntry{{ReferenceId: "ia-2"}}},
+						},
+					},
+					{Id: "AC-02", Title: "Sessions", Objective: "Expire sessions.", Family: "sessions"},
+				},
+			},
+		},
+		Policies: map[string]*gemara.Policy{
+			"platform-policy": {
+				Title:    "Platform Policy",
+				Metadata: gemara.Metadata{Id: "platform-policy"},
+				Imports: gemara.Imports{Catalogs: []gemara.CatalogImport{{
+					ReferenceId: "platform-controls",
+					Exclusions:  []string{"AC-02"},
+					Constraints: []gemara.Constraint{{Id: "C1", TargetId: "AC-01", Text: "Use hardware keys."}},
+				}}},
+			},
+		},
+	}
+}
+
+func pageContent(t *testing.T, pages []Page, path string) string {
+	t.Helper()
+	for _, page := range pages {
+		if page.Path == path {
+			return string(page.Content)
+		}
+	}
+	t.Fatalf("page %s was not rendered", path)
+	return ""
+}
+
+func TestRenderHTMLSite(t *testing.T) {
+	renderer, err := New(testArtifacts(), Options{Title: "Controls"})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	pages, err := renderer.Write(dir)
+	require.NoError(t, err)
+	var paths []string
+	for _, page := range pages {
+		paths = append(paths, page.Path)
+	}
+	assert.Equal(t, []string{
+		"index.html", "families.html", "guidance/baseline-guidance.html", "catalogs/platform-controls.html",
+		"policies/platform-policy.html", "style.css", SearchIndexFile,
+	}, paths)
+	_, err = os.Stat(filepath.Join(dir, "catalogs", "platform-controls.html"))
+	require.NoError(t, err)
+
+	index := pageContent(t, pages, "index.html")
+	assert.Contains(t, index, `<a href="catalogs/platform-controls.html">Platform Controls</a>`)
+	assert.Contains(t, index, "Guidance &lt;for&gt; tests")
+
+	// Guidelines link to the controls mapped to them, including mappings to their statements
+	guidance := pageContent(t, pages, "guidance/baseline-guidance.html")
+	assert.Contains(t, guidance, `<a href="../catalogs/platform-controls.html#AC-01">AC-01 MFA</a> (strength 9)`)
+	assert.Contains(t, guidance, `<li id="G-1.a">`)
+
+	catalog := pageContent(t, pages, "catalogs/platform-controls.html")
+	assert.Contains(t, catalog, `<a href="../guidance/baseline-guidance.html#G-1.a">G-1.a</a>`)
+	assert.Contains(t, catalog, `<a href="https://example.com/800-53">NIST SP 800-53</a>`)
+	assert.Contains(t, catalog, `<a href="../policies/platform-policy.html">Platform Policy</a>`)
+	// AC-02 is excluded by the policy and sits in a family the catalog does not declare
+	assert.Contains(t, catalog, "not imported by a stored policy")
+	assert.Contains(t, catalog, `<h2 id="family-sessions">sessions</h2>`)
+
+	policy := pageContent(t, pages, "policies/platform-policy.html")
+	assert.Contains(t, policy, `<a href="../catalogs/platform-controls.html#AC-02">AC-02</a>`)
+	assert.Contains(t, policy, "Use hardware keys.")
+
+	families := pageContent(t, pages, "families.html")
+	assert.Contains(t, families, `<a href="catalogs/platform-controls.html#family-ac">Access Control</a>`)
+
+	var search []searchEntry
+	require.NoError(t, json.Unmarshal([]byte(pageContent(t, pages, SearchIndexFile)), &search))
+	assert.Len(t, search, 7)
+	assert.Contains(t, search, searchEntry{
+		Kind: "assessment-requirement", ID: "AC-01.01", Title: "MFA", Text: "MFA | enforced",
+		Artifact: "platform-controls", URL: "catalogs/platform-controls.html#AC-01.01",
+	})
+}
+
+func TestRenderMarkdownPage(t *testing.T) {
+	renderer, err := New(testArtifacts(), Options{Format: FormatMarkdown})
+	require.NoError(t, err)
+
+	page, err := renderer.Page(KindCatalog, "platform-controls")
+	require.NoError(t, err)
+	assert.Equal(t, "catalogs/platform-controls.md", page.Path)
+	content := string(page.Content)
+	assert.Contains(t, content, "# Platform Controls")
+	assert.Contains(t, content, `| <a id="AC-01.01"></a>AC-01.01 | MFA \| enforced | prod |  |`)
+	assert.Contains(t, content, "| guideline | [Baseline Guidance](../guidance/baseline-guidance.md) | [G-1.a](../guidance/baseline-guidance.md#G-1.a) | 9 |  |")
+	assert.Contains(t, content, "**Policies:** [Platform Policy](../policies/platform-policy.md)")
+
+	_, err = renderer.Page(KindPolicy, "missing")
+	assert.ErrorContains(t, err, "artifact missing not found")
+}
+
+func TestRenderTemplateOverride(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "policy.html.tmpl"), []byte(`{{define "content"}}<p>custom {{.View.ID}}</p>{{end}}`), 0644))
+
+	renderer, err := New(testArtifacts(), Options{TemplateDir: dir})
+	require.NoError(t, err)
+	page, err := renderer.Page(KindPolicy, "platform-policy")
+	require.NoError(t, err)
+	assert.Contains(t, string(page.Content), "<p>custom platform-policy</p>")
+	// Templates that are not overridden fall back to the embedded defaults
+	assert.Contains(t, string(page.Content), `<link rel="stylesheet" href="../style.css">`)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "catalog.html.tmpl"), []byte(`{{define "content"}}{{.View.Missing}}{{end}}`), 0644))
+	_, err = renderer.Page(KindCatalog, "platform-controls")
+	assert.ErrorContains(t, err, "failed to render catalog page")
+
+	_, err = New(Artifacts{}, Options{Format: "pdf"})
+	assert.ErrorContains(t, err, "invalid format")
+	_, err = New(Artifacts{}, Options{TemplateDir: filepath.Join(dir, "missing")})
+	assert.ErrorContains(t, err, "does not exist")
+}
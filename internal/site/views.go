@@ -0,0 +1,498 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package site
+
+import (
+	"fmt"
+
+	"github.com/ossf/gemara"
+)
+
+// Link is a cross reference between pages. URL is relative to the site root, absolute for
+// external references, or empty when the target is not part of the site.
+type Link struct {
+	Text string
+	URL  string
+	Note string
+}
+
+// mappingRow is one entry of a mapping table
+type mappingRow struct {
+	Kind      string
+	Reference Link
+	Entry     Link
+	Strength  int64
+	Remarks   string
+}
+
+// targetRow is a policy constraint or modification and the element it applies to
+type targetRow struct {
+	ID        string
+	Target    Link
+	Type      string
+	Rationale string
+	Text      string
+}
+
+type indexEntry struct {
+	Link        Link
+	Description string
+	Version     string
+	Summary     string
+}
+
+type indexView struct {
+	Guidance []indexEntry
+	Catalogs []indexEntry
+	Policies []indexEntry
+}
+
+type familyEntry struct {
+	Family  gemara.Family
+	Anchor  string
+	Members []Link
+}
+
+type familyGroup struct {
+	Artifact Link
+	Families []familyEntry
+}
+
+type familiesView struct {
+	Groups []familyGroup
+}
+
+type guidelineView struct {
+	Guideline gemara.Guideline
+	Controls  []Link
+	Mappings  []mappingRow
+	SeeAlso   []Link
+}
+
+type guidanceFamily struct {
+	Family     gemara.Family
+	Anchor     string
+	Guidelines []guidelineView
+}
+
+type guidanceView struct {
+	ID       string
+	Guidance *gemara.GuidanceDocument
+	Families []guidanceFamily
+	Policies []Link
+}
+
+type controlView struct {
+	Control  gemara.Control
+	Mappings []mappingRow
+	Policies []Link
+}
+
+type catalogFamily struct {
+	Family   gemara.Family
+	Anchor   string
+	Controls []controlView
+}
+
+type catalogView struct {
+	ID       string
+	Catalog  *gemara.Catalog
+	Families []catalogFamily
+	Policies []Link
+}
+
+type policyImport struct {
+	Link          Link
+	Exclusions    []Link
+	Constraints   []targetRow
+	Modifications []targetRow
+}
+
+type policyView struct {
+	ID       string
+	Policy   *gemara.Policy
+	Policies []Link
+	Catalogs []policyImport
+	Guidance []policyImport
+}
+
+// searchEntry is an element of the search index
+type searchEntry struct {
+	Kind     string `json:"kind"`
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Text     string `json:"text,omitempty"`
+	Artifact string `json:"artifact"`
+	URL      string `json:"url"`
+}
+
+// linkIndex holds the cross references between artifacts
+type linkIndex struct {
+	// anchors maps artifact ID and element ID to the page URL of the element
+	anchors map[string]string
+	// controlsByGuideline lists the controls mapped to a guideline, keyed by guidance and guideline ID
+	controlsByGuideline map[string][]Link
+	// importers lists the policies importing an artifact
+	importers map[string][]policyRef
+}
+
+type policyRef struct {
+	id         string
+	exclusions []string
+}
+
+func elementKey(artifactID, elementID string) string {
+	return artifactID + "/" + elementID
+}
+
+func familyAnchor(familyID string) string {
+	return "family-" + familyID
+}
+
+// buildLinkIndex indexes the elements of every artifact and the relationships between them
+func (r *Renderer) buildLinkIndex() *linkIndex {
+	index := &linkIndex{
+		anchors:             map[string]string{},
+		controlsByGuideline: map[string][]Link{},
+		importers:           map[string][]policyRef{},
+	}
+	// Statements link to the guideline they belong to so mappings to a statement roll up to it
+	guidelineOf := map[string]string{}
+
+	for _, id := range r.ids(KindGuidance) {
+		page := r.PagePath(KindGuidance, id)
+		index.anchors[elementKey(id, "")] = page
+		for _, guideline := range r.artifacts.Guidance[id].Guidelines {
+			index.anchors[elementKey(id, guideline.Id)] = page + "#" + guideline.Id
+			guidelineOf[elementKey(id, guideline.Id)] = guideline.Id
+			for _, statement := range guideline.Statements {
+				index.anchors[elementKey(id, statement.Id)] = page + "#" + statement.Id
+				guidelineOf[elementKey(id, statement.Id)] = guideline.Id
+			}
+		}
+	}
+	for _, id := range r.ids(KindCatalog) {
+		page := r.PagePath(KindCatalog, id)
+		index.anchors[elementKey(id, "")] = page
+		for _, control := range r.artifacts.Catalogs[id].Controls {
+			index.anchors[elementKey(id, control.Id)] = page + "#" + control.Id
+			for _, requirement := range control.AssessmentRequirements {
+				index.anchors[elementKey(id, requirement.Id)] = page + "#" + requirement.Id
+			}
+		}
+	}
+	for _, id := range r.ids(KindPolicy) {
+		index.anchors[elementKey(id, "")] = r.PagePath(KindPolicy, id)
+	}
+
+	for _, id := range r.ids(KindCatalog) {
+		for _, control := range r.artifacts.Catalogs[id].Controls {
+			controlLink := Link{Text: control.Id + " " + control.Title, URL: index.anchors[elementKey(id, control.Id)]}
+			for _, mapping := range control.GuidelineMappings {
+				seen := map[string]bool{}
+				for _, entry := range mapping.Entries {
+					guideline, ok := guidelineOf[elementKey(mapping.ReferenceId, entry.ReferenceId)]
+					key := elementKey(mapping.ReferenceId, guideline)
+					if !ok || seen[key] {
+						continue
+					}
+					seen[key] = true
+					link := controlLink
+					link.Note = strengthNote(entry.Strength)
+					index.controlsByGuideline[key] = append(index.controlsByGuideline[key], link)
+				}
+			}
+		}
+	}
+	for _, id := range r.ids(KindPolicy) {
+		policy := r.artifacts.Policies[id]
+		for _, imported := range policy.Imports.Catalogs {
+			index.importers[imported.ReferenceId] = append(index.importers[imported.ReferenceId], policyRef{id: id, exclusions: imported.Exclusions})
+		}
+		for _, imported := range policy.Imports.Guidance {
+			index.importers[imported.ReferenceId] = append(index.importers[imported.ReferenceId], policyRef{id: id, exclusions: imported.Exclusions})
+		}
+		for _, imported := range policy.Imports.Policies {
+			index.importers[imported] = append(index.importers[imported], policyRef{id: id})
+		}
+	}
+	return index
+}
+
+// link returns a link to an element of a stored artifact, or plain text if it is not part of the site
+func (r *Renderer) link(artifactID, elementID, text string) Link {
+	if text == "" {
+		text = pageTitle(elementID, artifactID)
+	}
+	return Link{Text: text, URL: r.links.anchors[elementKey(artifactID, elementID)]}
+}
+
+// policyLinks returns links to the policies importing an artifact, optionally skipping
+// policies that exclude the given element
+func (r *Renderer) policyLinks(artifactID, elementID string) []Link {
+	var links []Link
+	for _, ref := range r.links.importers[artifactID] {
+		if elementID != "" && containsString(ref.exclusions, elementID) {
+			continue
+		}
+		links = append(links, r.link(ref.id, "", r.artifacts.Policies[ref.id].Title))
+	}
+	return links
+}
+
+// mappingRows flattens multi-mappings into table rows, linking stored targets and falling
+// back to the URL of the artifact's mapping reference
+func (r *Renderer) mappingRows(kind string, mappings []gemara.MultiMapping, references []gemara.MappingReference) []mappingRow {
+	var rows []mappingRow
+	for _, mapping := range mappings {
+		reference := r.link(mapping.ReferenceId, "", "")
+		for _, known := range references {
+			if known.Id != mapping.ReferenceId {
+				continue
+			}
+			if known.Title != "" {
+				reference.Text = known.Title
+			}
+			if reference.URL == "" {
+				reference.URL = known.Url
+			}
+		}
+		for _, entry := range mapping.Entries {
+			remarks := entry.Remarks
+			if remarks == "" {
+				remarks = mapping.Remarks
+			}
+			rows = append(rows, mappingRow{
+				Kind:      kind,
+				Reference: reference,
+				Entry:     r.link(mapping.ReferenceId, entry.ReferenceId, ""),
+				Strength:  entry.Strength,
+				Remarks:   remarks,
+			})
+		}
+	}
+	return rows
+}
+
+func (r *Renderer) indexView() *indexView {
+	view := &indexView{}
+	for _, id := range r.ids(KindGuidance) {
+		guidance := r.artifacts.Guidance[id]
+		view.Guidance = append(view.Guidance, indexEntry{
+			Link:        r.link(id, "", guidance.Title),
+			Description: guidance.Metadata.Description,
+			Version:     guidance.Metadata.Version,
+			Summary:     fmt.Sprintf("%d families, %d guidelines", len(guidance.Families), len(guidance.Guidelines)),
+		})
+	}
+	for _, id := range r.ids(KindCatalog) {
+		catalog := r.artifacts.Catalogs[id]
+		view.Catalogs = append(view.Catalogs, indexEntry{
+			Link:        r.link(id, "", catalog.Title),
+			Description: catalog.Metadata.Description,
+			Version:     catalog.Metadata.Version,
+			Summary:     fmt.Sprintf("%d families, %d controls", len(catalog.Families), len(catalog.Controls)),
+		})
+	}
+	for _, id := range r.ids(KindPolicy) {
+		policy := r.artifacts.Policies[id]
+		imports := len(policy.Imports.Catalogs) + len(policy.Imports.Guidance) + len(policy.Imports.Policies)
+		view.Policies = append(view.Policies, indexEntry{
+			Link:        r.link(id, "", policy.Title),
+			Description: policy.Metadata.Description,
+			Version:     policy.Metadata.Version,
+			Summary:     fmt.Sprintf("%d imports", imports),
+		})
+	}
+	return view
+}
+
+func (r *Renderer) familiesView() *familiesView {
+	view := &familiesView{}
+	for _, id := range r.ids(KindGuidance) {
+		guidance := r.artifacts.Guidance[id]
+		group := familyGroup{Artifact: r.link(id, "", guidance.Title)}
+		for _, family := range r.guidanceView(id, guidance).Families {
+			entry := familyEntry{Family: family.Family, Anchor: group.Artifact.URL + "#" + family.Anchor}
+			for _, guideline := range family.Guidelines {
+				entry.Members = append(entry.Members, r.link(id, guideline.Guideline.Id, guideline.Guideline.Id+" "+guideline.Guideline.Title))
+			}
+			group.Families = append(group.Families, entry)
+		}
+		view.Groups = append(view.Groups, group)
+	}
+	for _, id := range r.ids(KindCatalog) {
+		catalog := r.artifacts.Catalogs[id]
+		group := familyGroup{Artifact: r.link(id, "", catalog.Title)}
+		for _, family := range r.catalogView(id, catalog).Families {
+			entry := familyEntry{Family: family.Family, Anchor: group.Artifact.URL + "#" + family.Anchor}
+			for _, control := range family.Controls {
+				entry.Members = append(entry.Members, r.link(id, control.Control.Id, control.Control.Id+" "+control.Control.Title))
+			}
+			group.Families = append(group.Families, entry)
+		}
+		view.Groups = append(view.Groups, group)
+	}
+	return view
+}
+
+func (r *Renderer) guidanceView(id string, guidance *gemara.GuidanceDocument) *guidanceView {
+	view := &guidanceView{ID: id, Guidance: guidance, Policies: r.policyLinks(id, "")}
+	families := map[string]int{}
+	for _, family := range guidance.Families {
+		families[family.Id] = len(view.Families)
+		view.Families = append(view.Families, guidanceFamily{Family: family, Anchor: familyAnchor(family.Id)})
+	}
+	for _, guideline := range guidance.Guidelines {
+		i, ok := families[guideline.Family]
+		if !ok {
+			i = len(view.Families)
+			families[guideline.Family] = i
+			view.Families = append(view.Families, guidanceFamily{
+				Family: gemara.Family{Id: guideline.Family, Title: undeclaredFamilyTitle(guideline.Family)},
+				Anchor: familyAnchor(guideline.Family),
+			})
+		}
+		item := guidelineView{
+			Guideline: guideline,
+			Controls:  r.links.controlsByGuideline[elementKey(id, guideline.Id)],
+			Mappings:  r.mappingRows("guideline", guideline.GuidelineMappings, guidance.Metadata.MappingReferences),
+		}
+		item.Mappings = append(item.Mappings, r.mappingRows("principle", guideline.PrincipleMappings, guidance.Metadata.MappingReferences)...)
+		for _, related := range guideline.SeeAlso {
+			item.SeeAlso = append(item.SeeAlso, r.link(id, related, ""))
+		}
+		view.Families[i].Guidelines = append(view.Families[i].Guidelines, item)
+	}
+	return view
+}
+
+func (r *Renderer) catalogView(id string, catalog *gemara.Catalog) *catalogView {
+	view := &catalogView{ID: id, Catalog: catalog, Policies: r.policyLinks(id, "")}
+	families := map[string]int{}
+	for _, family := range catalog.Families {
+		families[family.Id] = len(view.Families)
+		view.Families = append(view.Families, catalogFamily{Family: family, Anchor: familyAnchor(family.Id)})
+	}
+	for _, control := range catalog.Controls {
+		i, ok := families[control.Family]
+		if !ok {
+			i = len(view.Families)
+			families[control.Family] = i
+			view.Families = append(view.Families, catalogFamily{
+				Family: gemara.Family{Id: control.Family, Title: undeclaredFamilyTitle(control.Family)},
+				Anchor: familyAnchor(control.Family),
+			})
+		}
+		item := controlView{
+			Control:  control,
+			Mappings: r.mappingRows("guideline", control.GuidelineMappings, catalog.Metadata.MappingReferences),
+			Policies: r.policyLinks(id, control.Id),
+		}
+		item.Mappings = append(item.Mappings, r.mappingRows("threat", control.ThreatMappings, catalog.Metadata.MappingReferences)...)
+		view.Families[i].Controls = append(view.Families[i].Controls, item)
+	}
+	return view
+}
+
+func (r *Renderer) policyView(id string, policy *gemara.Policy) *policyView {
+	view := &policyView{ID: id, Policy: policy}
+	for _, imported := range policy.Imports.Policies {
+		title := ""
+		if importedPolicy, ok := r.artifacts.Policies[imported]; ok {
+			title = importedPolicy.Title
+		}
+		view.Policies = append(view.Policies, r.link(imported, "", title))
+	}
+	for _, imported := range policy.Imports.Catalogs {
+		item := policyImport{Link: r.link(imported.ReferenceId, "", r.title(imported.ReferenceId))}
+		for _, excluded := range imported.Exclusions {
+			item.Exclusions = append(item.Exclusions, r.link(imported.ReferenceId, excluded, ""))
+		}
+		for _, constraint := range imported.Constraints {
+			item.Constraints = append(item.Constraints, targetRow{ID: constraint.Id, Target: r.link(imported.ReferenceId, constraint.TargetId, ""), Text: constraint.Text})
+		}
+		for _, modifier := range imported.AssessmentRequirementModifications {
+			item.Modifications = append(item.Modifications, targetRow{
+				ID:        modifier.Id,
+				Target:    r.link(imported.ReferenceId, modifier.TargetId, ""),
+				Type:      string(modifier.ModificationType),
+				Rationale: modifier.ModificationRationale,
+				Text:      modifier.Text,
+			})
+		}
+		view.Catalogs = append(view.Catalogs, item)
+	}
+	for _, imported := range policy.Imports.Guidance {
+		item := policyImport{Link: r.link(imported.ReferenceId, "", r.title(imported.ReferenceId))}
+		for _, excluded := range imported.Exclusions {
+			item.Exclusions = append(item.Exclusions, r.link(imported.ReferenceId, excluded, ""))
+		}
+		for _, constraint := range imported.Constraints {
+			item.Constraints = append(item.Constraints, targetRow{ID: constraint.Id, Target: r.link(imported.ReferenceId, constraint.TargetId, ""), Text: constraint.Text})
+		}
+		view.Guidance = append(view.Guidance, item)
+	}
+	return view
+}
+
+// searchIndex lists every artifact and element of the site for client-side search
+func (r *Renderer) searchIndex() []searchEntry {
+	entries := []searchEntry{}
+	for _, id := range r.ids(KindGuidance) {
+		guidance := r.artifacts.Guidance[id]
+		entries = append(entries, searchEntry{Kind: "guidance", ID: id, Title: guidance.Title, Text: guidance.Metadata.Description, Artifact: id, URL: r.links.anchors[elementKey(id, "")]})
+		for _, guideline := range guidance.Guidelines {
+			entries = append(entries, searchEntry{Kind: "guideline", ID: guideline.Id, Title: guideline.Title, Text: guideline.Objective, Artifact: id, URL: r.links.anchors[elementKey(id, guideline.Id)]})
+		}
+	}
+	for _, id := range r.ids(KindCatalog) {
+		catalog := r.artifacts.Catalogs[id]
+		entries = append(entries, searchEntry{Kind: "catalog", ID: id, Title: catalog.Title, Text: catalog.Metadata.Description, Artifact: id, URL: r.links.anchors[elementKey(id, "")]})
+		for _, control := range catalog.Controls {
+			entries = append(entries, searchEntry{Kind: "control", ID: control.Id, Title: control.Title, Text: control.Objective, Artifact: id, URL: r.links.anchors[elementKey(id, control.Id)]})
+			for _, requirement := range control.AssessmentRequirements {
+				entries = append(entries, searchEntry{Kind: "assessment-requirement", ID: requirement.Id, Title: control.Title, Text: requirement.Text, Artifact: id, URL: r.links.anchors[elementKey(id, requirement.Id)]})
+			}
+		}
+	}
+	for _, id := range r.ids(KindPolicy) {
+		policy := r.artifacts.Policies[id]
+		entries = append(entries, searchEntry{Kind: "policy", ID: id, Title: policy.Title, Text: policy.Metadata.Description, Artifact: id, URL: r.links.anchors[elementKey(id, "")]})
+	}
+	return entries
+}
+
+// title returns the title of a stored guidance document or catalog
+func (r *Renderer) title(artifactID string) string {
+	if guidance, ok := r.artifacts.Guidance[artifactID]; ok {
+		return guidance.Title
+	}
+	if catalog, ok := r.artifacts.Catalogs[artifactID]; ok {
+		return catalog.Title
+	}
+	return ""
+}
+
+func undeclaredFamilyTitle(familyID string) string {
+	if familyID == "" {
+		return "Unassigned"
+	}
+	return familyID
+}
+
+func strengthNote(strength int64) string {
+	if strength == 0 {
+		return ""
+	}
+	return fmt.Sprintf("strength %d", strength)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package yamldoc
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/goccy/go-yaml"
+)
+
+// itemKeys are the keys that identify the items of a sequence, in order of preference
+var itemKeys = []string{"id", "reference-id"}
+
+// Diff returns the operations that turn from into to. Mappings are compared key by key, and sequences
+// whose items are mappings with a unique id or reference-id are compared item by item, so that a
+// change to one control does not rewrite its neighbours. Other sequences that differ are replaced.
+func Diff(from, to interface{}) ([]Operation, error) {
+	plainFrom, err := orderedData(from)
+	if err != nil {
+		return nil, err
+	}
+	plainTo, err := orderedData(to)
+	if err != nil {
+		return nil, err
+	}
+	var operations []Operation
+	diffValues("", plainFrom, plainTo, &operations)
+	return operations, nil
+}
+
+// Merge applies the changes between base and target to the document. Anything in the document that
+// neither value holds, such as comments or fields the caller does not model, is left as it is.
+func (d *Document) Merge(base, target interface{}) error {
+	operations, err := Diff(base, target)
+	if err != nil {
+		return err
+	}
+	return d.ApplyPatch(operations)
+}
+
+func diffValues(pointer string, from, to interface{}, operations *[]Operation) {
+	switch f := from.(type) {
+	case yaml.MapSlice:
+		if t, ok := to.(yaml.MapSlice); ok {
+			diffMappings(pointer, f, t, operations)
+			return
+		}
+	case []interface{}:
+		if t, ok := to.([]interface{}); ok {
+			diffSequences(pointer, f, t, operations)
+			return
+		}
+	}
+	if !reflect.DeepEqual(from, to) {
+		*operations = append(*operations, Operation{Op: "replace", Path: pointer, Value: to, hasValue: true})
+	}
+}
+
+func diffMappings(pointer string, from, to yaml.MapSlice, operations *[]Operation) {
+	target := make(map[string]interface{}, len(to))
+	for _, item := range to {
+		if item.Value != nil {
+			target[fmt.Sprint(item.Key)] = item.Value
+		}
+	}
+	current := make(map[string]interface{}, len(from))
+	for _, item := range from {
+		key := fmt.Sprint(item.Key)
+		current[key] = item.Value
+		if _, ok := target[key]; !ok {
+			*operations = append(*operations, Operation{Op: "remove", Path: pointer + "/" + escape(key)})
+		}
+	}
+	for _, item := range to {
+		key := fmt.Sprint(item.Key)
+		value, ok := target[key]
+		if !ok {
+			continue
+		}
+		if existing, ok := current[key]; ok {
+			diffValues(pointer+"/"+escape(key), existing, value, operations)
+		} else {
+			*operations = append(*operations, Operation{Op: "add", Path: pointer + "/" + escape(key), Value: value})
+		}
+	}
+}
+
+func diffSequences(pointer string, from, to []interface{}, operations *[]Operation) {
+	if reflect.DeepEqual(from, to) {
+		return
+	}
+	key := sequenceKey(from, to)
+	if key == "" || len(from) == 0 || len(to) == 0 {
+		*operations = append(*operations, Operation{Op: "replace", Path: pointer, Value: to})
+		return
+	}
+
+	targetIndex := make(map[string]int, len(to))
+	for i, item := range to {
+		targetIndex[itemKey(item, key)] = i
+	}
+	currentIndex := make(map[string]int, len(from))
+	last := -1
+	for i, item := range from {
+		id := itemKey(item, key)
+		currentIndex[id] = i
+		if j, ok := targetIndex[id]; ok {
+			if j < last {
+				// Kept items were reordered; item-wise edits cannot express that
+				*operations = append(*operations, Operation{Op: "replace", Path: pointer, Value: to})
+				return
+			}
+			last = j
+		}
+	}
+
+	// Edit kept items in place before any insertion or removal shifts their indices
+	for i, item := range from {
+		if j, ok := targetIndex[itemKey(item, key)]; ok {
+			diffValues(pointer+"/"+strconv.Itoa(i), item, to[j], operations)
+		}
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		if _, ok := targetIndex[itemKey(from[i], key)]; !ok {
+			*operations = append(*operations, Operation{Op: "remove", Path: pointer + "/" + strconv.Itoa(i)})
+		}
+	}
+	// Kept items now appear in target order, so inserting new items in order puts each at its final index
+	for j, item := range to {
+		if _, ok := currentIndex[itemKey(item, key)]; !ok {
+			*operations = append(*operations, Operation{Op: "add", Path: pointer + "/" + strconv.Itoa(j), Value: item, hasValue: true})
+		}
+	}
+}
+
+// sequenceKey returns the key that uniquely identifies every item of both sequences, or "" if there is none
+func sequenceKey(sequences ...[]interface{}) string {
+	for _, key := range itemKeys {
+		if identifiedBy(key, sequences...) {
+			return key
+		}
+	}
+	return ""
+}
+
+func identifiedBy(key string, sequences ...[]interface{}) bool {
+	for _, sequence := range sequences {
+		seen := make(map[string]bool, len(sequence))
+		for _, item := range sequence {
+			id := itemKey(item, key)
+			if id == "" || seen[id] {
+				return false
+			}
+			seen[id] = true
+		}
+	}
+	return true
+}
+
+// itemKey returns the string value of key in a mapping item, or "" if it has none
+func itemKey(item interface{}, key string) string {
+	mapping, ok := item.(yaml.MapSlice)
+	if !ok {
+		return ""
+	}
+	for _, entry := range mapping {
+		if fmt.Sprint(entry.Key) == key {
+			if id, ok := entry.Value.(string); ok {
+				return id
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// orderedData converts a value into generic YAML data with mappings as yaml.MapSlice
+func orderedData(value interface{}) (interface{}, error) {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value: %w", err)
+	}
+	var plain interface{}
+	if err := yaml.UnmarshalWithOptions(data, &plain, yaml.UseOrderedMap()); err != nil {
+		return nil, fmt.Errorf("failed to decode value: %w", err)
+	}
+	return plain, nil
+}
+
+// escape escapes a single JSON Pointer reference token
+func escape(token string) string {
+	return joinPointer([]string{token})[1:]
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package yamldoc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Operation is a single JSON Patch (RFC 6902) operation
+type Operation struct {
+	Op    string      `json:"op" yaml:"op"`
+	Path  string      `json:"path" yaml:"path"`
+	From  string      `json:"from,omitempty" yaml:"from,omitempty"`
+	Value interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+
+	// hasValue records that a parsed operation names a value, which may be null
+	hasValue bool
+}
+
+// ParsePatch decodes a JSON Patch document, keeping the key order of object values
+func ParsePatch(data []byte) ([]Operation, error) {
+	var operations []Operation
+	if err := yaml.UnmarshalWithOptions(data, &operations, yaml.UseOrderedMap(), yaml.Strict()); err != nil {
+		return nil, fmt.Errorf("invalid JSON Patch: %w", err)
+	}
+	// A null value decodes like a missing one, so look for the key itself
+	var keys []map[string]interface{}
+	if err := yaml.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("invalid JSON Patch: %w", err)
+	}
+	for i := range operations {
+		_, operations[i].hasValue = keys[i]["value"]
+	}
+	return operations, nil
+}
+
+// ApplyPatch applies the operations in order. If any operation fails, the document is left as it
+// was before the patch and the error names the failing operation.
+func (d *Document) ApplyPatch(operations []Operation) error {
+	original := d.String()
+	for i, operation := range operations {
+		if err := d.applyOperation(operation); err != nil {
+			d.restore(original)
+			return fmt.Errorf("operation %d (%s %s): %w", i, operation.Op, operation.Path, err)
+		}
+	}
+	return nil
+}
+
+func (d *Document) applyOperation(operation Operation) error {
+	switch operation.Op {
+	case "add", "replace", "test":
+		if operation.Value == nil && !operation.hasValue {
+			return fmt.Errorf("value is required")
+		}
+	case "move", "copy":
+		if operation.From == "" {
+			return fmt.Errorf("from is required")
+		}
+	}
+
+	switch operation.Op {
+	case "add":
+		return d.Add(operation.Path, operation.Value)
+	case "remove":
+		return d.Remove(operation.Path)
+	case "replace":
+		return d.Replace(operation.Path, operation.Value)
+	case "move":
+		if strings.HasPrefix(operation.Path, operation.From+"/") {
+			return fmt.Errorf("cannot move %s into one of its children", operation.From)
+		}
+		value, err := d.Value(operation.From)
+		if err != nil {
+			return err
+		}
+		if err := d.Remove(operation.From); err != nil {
+			return err
+		}
+		return d.Add(operation.Path, value)
+	case "copy":
+		value, err := d.Value(operation.From)
+		if err != nil {
+			return err
+		}
+		return d.Add(operation.Path, value)
+	case "test":
+		value, err := d.Value(operation.Path)
+		if err != nil {
+			return err
+		}
+		same, err := sameData(value, operation.Value)
+		if err != nil {
+			return err
+		}
+		if !same {
+			return fmt.Errorf("test failed: value differs")
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown operation %q", operation.Op)
+}
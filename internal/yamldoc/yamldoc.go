@@ -0,0 +1,592 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package yamldoc edits YAML documents at the syntax tree level, so that comments, key order,
+// and the formatting of untouched nodes survive an edit.
+package yamldoc
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+// Document is a parsed YAML document addressed with JSON Pointers (RFC 6901)
+type Document struct {
+	file           *ast.File
+	indent         int
+	indentSequence bool
+}
+
+// location is the container holding the node a pointer refers to
+type location struct {
+	pointer string
+	parent  ast.Node
+	key     string
+	index   int
+	entry   *ast.MappingValueNode
+}
+
+// Parse parses a single YAML document, keeping its comments
+func Parse(data []byte) (*Document, error) {
+	file, err := parser.ParseBytes(data, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if len(file.Docs) != 1 || file.Docs[0].Body == nil {
+		return nil, fmt.Errorf("expected exactly one non-empty YAML document, found %d", len(file.Docs))
+	}
+	d := &Document{file: file, indent: 2}
+	d.detectStyle()
+	return d, nil
+}
+
+// String renders the document
+func (d *Document) String() string {
+	out := d.file.String()
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	return out
+}
+
+// Get returns the node at pointer
+func (d *Document) Get(pointer string) (ast.Node, error) {
+	tokens, err := splitPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	node := d.file.Docs[0].Body
+	for i, token := range tokens {
+		node, err = child(node, token)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", joinPointer(tokens[:i+1]), err)
+		}
+	}
+	return node, nil
+}
+
+// Has reports whether pointer refers to an existing node
+func (d *Document) Has(pointer string) bool {
+	_, err := d.Get(pointer)
+	return err == nil
+}
+
+// Decode decodes the node at pointer into v
+func (d *Document) Decode(pointer string, v interface{}) error {
+	node, err := d.Get(pointer)
+	if err != nil {
+		return err
+	}
+	return yaml.NodeToValue(node, v)
+}
+
+// Value returns the node at pointer as a generic value, with mappings as yaml.MapSlice in document order
+func (d *Document) Value(pointer string) (interface{}, error) {
+	node, err := d.Get(pointer)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := yaml.NodeToValue(node, &value, yaml.UseOrderedMap()); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// editMode says how an edit changes the container at a location
+type editMode int
+
+const (
+	modeSet editMode = iota
+	modeInsert
+	modeRemove
+)
+
+// Add sets a mapping key or inserts a sequence item at pointer; "-" as the last token appends
+func (d *Document) Add(pointer string, value interface{}) error {
+	return d.edit(pointer, value, func(loc *location) error {
+		if _, ok := loc.parent.(*ast.SequenceNode); ok {
+			return d.apply(loc, value, modeInsert)
+		}
+		return d.apply(loc, value, modeSet)
+	})
+}
+
+// Replace replaces the existing node at pointer
+func (d *Document) Replace(pointer string, value interface{}) error {
+	return d.edit(pointer, value, func(loc *location) error {
+		if !loc.exists() {
+			return fmt.Errorf("%s does not exist", pointer)
+		}
+		return d.apply(loc, value, modeSet)
+	})
+}
+
+// Remove deletes the node at pointer
+func (d *Document) Remove(pointer string) error {
+	return d.edit(pointer, nil, func(loc *location) error {
+		if !loc.exists() {
+			return fmt.Errorf("%s does not exist", pointer)
+		}
+		return d.apply(loc, nil, modeRemove)
+	})
+}
+
+// edit locates pointer, applies change, and reparses the result. The document is left unchanged
+// if the change fails or if the node written at pointer does not decode to value.
+func (d *Document) edit(pointer string, value interface{}, change func(loc *location) error) error {
+	loc, err := d.locate(pointer)
+	if err != nil {
+		return err
+	}
+	target := pointer
+	if seq, ok := loc.parent.(*ast.SequenceNode); ok && loc.index < 0 {
+		target = fmt.Sprintf("%s/%d", loc.pointer, len(seq.Values))
+	}
+
+	original := d.String()
+	if err := change(loc); err != nil {
+		d.restore(original)
+		return err
+	}
+
+	file, err := parser.ParseBytes([]byte(d.String()), parser.ParseComments)
+	if err != nil {
+		d.restore(original)
+		return fmt.Errorf("editing %s produced invalid YAML: %w", pointer, err)
+	}
+	d.file = file
+
+	if value != nil {
+		if err := d.verify(target, value); err != nil {
+			d.restore(original)
+			return err
+		}
+	}
+	return nil
+}
+
+// verify checks that the node at pointer decodes to the same data as value
+func (d *Document) verify(pointer string, value interface{}) error {
+	got, err := d.Value(pointer)
+	if err != nil {
+		return fmt.Errorf("failed to read back %s: %w", pointer, err)
+	}
+	same, err := sameData(got, value)
+	if err != nil {
+		return err
+	}
+	if !same {
+		return fmt.Errorf("editing %s did not produce the expected value", pointer)
+	}
+	return nil
+}
+
+// restore reverts the document to a previously rendered state
+func (d *Document) restore(original string) {
+	if file, err := parser.ParseBytes([]byte(original), parser.ParseComments); err == nil {
+		d.file = file
+	}
+}
+
+// locate resolves the container of the node at pointer
+func (d *Document) locate(pointer string) (*location, error) {
+	tokens, err := splitPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("the document root cannot be replaced")
+	}
+	parentPointer := joinPointer(tokens[:len(tokens)-1])
+	parent, err := d.Get(parentPointer)
+	if err != nil {
+		return nil, err
+	}
+	last := tokens[len(tokens)-1]
+	loc := &location{pointer: parentPointer, parent: parent, key: last, index: -1}
+
+	switch p := parent.(type) {
+	case *ast.MappingNode:
+		for _, entry := range p.Values {
+			if entry.Key.GetToken().Value == last {
+				loc.entry = entry
+				break
+			}
+		}
+	case *ast.SequenceNode:
+		if last != "-" {
+			index, err := strconv.Atoi(last)
+			if err != nil || index < 0 || index > len(p.Values) {
+				return nil, fmt.Errorf("%s: invalid sequence index %q", pointer, last)
+			}
+			loc.index = index
+		}
+	case *ast.NullNode:
+		// an empty key such as "families:" becomes a collection on first insert
+	default:
+		return nil, fmt.Errorf("%s: parent is not a mapping or sequence", pointer)
+	}
+	return loc, nil
+}
+
+// exists reports whether the location refers to an existing node
+func (l *location) exists() bool {
+	switch parent := l.parent.(type) {
+	case *ast.MappingNode:
+		return l.entry != nil
+	case *ast.SequenceNode:
+		return l.index >= 0 && l.index < len(parent.Values)
+	}
+	return false
+}
+
+// apply changes the container at loc. Block collections are edited in place; flow, empty, and null
+// containers have no block layout to extend and are re-rendered from their data instead.
+func (d *Document) apply(loc *location, value interface{}, mode editMode) error {
+	switch parent := loc.parent.(type) {
+	case *ast.MappingNode:
+		if parent.IsFlowStyle || len(parent.Values) == 0 || (mode == modeRemove && len(parent.Values) == 1) {
+			return d.rewrite(loc, value, mode)
+		}
+		switch {
+		case mode == modeRemove:
+			for i, entry := range parent.Values {
+				if entry == loc.entry {
+					parent.Values = append(parent.Values[:i], parent.Values[i+1:]...)
+					break
+				}
+			}
+		case loc.entry != nil:
+			column := loc.entry.Key.GetToken().Position.Column
+			node, err := d.render(yaml.MapSlice{{Key: loc.key, Value: value}}, column, false)
+			if err != nil {
+				return err
+			}
+			rendered := node.(*ast.MappingNode).Values[0].Value
+			if isFlow(loc.entry.Value) && !isScalar(value) {
+				if rendered, err = d.render(value, column, true); err != nil {
+					return err
+				}
+			}
+			loc.entry.Value = rendered
+		default:
+			node, err := d.render(yaml.MapSlice{{Key: loc.key, Value: value}}, parent.Values[0].Key.GetToken().Position.Column, false)
+			if err != nil {
+				return err
+			}
+			parent.Values = append(parent.Values, node.(*ast.MappingNode).Values[0])
+		}
+	case *ast.SequenceNode:
+		if parent.IsFlowStyle || len(parent.Values) == 0 || (mode == modeRemove && len(parent.Values) == 1) {
+			return d.rewrite(loc, value, mode)
+		}
+		index := loc.index
+		if index < 0 {
+			index = len(parent.Values)
+		}
+		if mode == modeRemove {
+			removeItem(parent, index)
+			return nil
+		}
+		node, err := d.render([]interface{}{value}, parent.Start.Position.Column, false)
+		if err != nil {
+			return err
+		}
+		rendered := node.(*ast.SequenceNode)
+		if mode == modeSet {
+			parent.Values[index] = rendered.Values[0]
+		} else {
+			insertItem(parent, index, rendered)
+		}
+	default:
+		return d.rewrite(loc, value, mode)
+	}
+	return nil
+}
+
+// rewrite applies the change to the container at loc as plain data and writes the whole container back
+func (d *Document) rewrite(loc *location, value interface{}, mode editMode) error {
+	current, err := d.Value(loc.pointer)
+	if err != nil {
+		return err
+	}
+
+	var updated interface{}
+	switch items := current.(type) {
+	case yaml.MapSlice:
+		next := yaml.MapSlice{}
+		found := false
+		for _, item := range items {
+			if fmt.Sprint(item.Key) == loc.key {
+				found = true
+				if mode == modeRemove {
+					continue
+				}
+				item.Value = value
+			}
+			next = append(next, item)
+		}
+		if !found && mode != modeRemove {
+			next = append(next, yaml.MapItem{Key: loc.key, Value: value})
+		}
+		updated = next
+	case []interface{}:
+		index := loc.index
+		if index < 0 {
+			index = len(items)
+		}
+		next := append([]interface{}{}, items[:index]...)
+		switch mode {
+		case modeInsert:
+			next = append(append(next, value), items[index:]...)
+		case modeSet:
+			next = append(append(next, value), items[index+1:]...)
+		case modeRemove:
+			next = append(next, items[index+1:]...)
+		}
+		updated = next
+	case nil:
+		if mode == modeRemove {
+			return fmt.Errorf("%s/%s does not exist", loc.pointer, loc.key)
+		}
+		if _, err := strconv.Atoi(loc.key); err == nil || loc.key == "-" {
+			updated = []interface{}{value}
+		} else {
+			updated = yaml.MapSlice{{Key: loc.key, Value: value}}
+		}
+	default:
+		return fmt.Errorf("%s is not a mapping or sequence", loc.pointer)
+	}
+
+	// collections that were flow style keep it, unless they were empty and are now being filled
+	flow := (isFlow(loc.parent) && !isEmpty(current)) || isEmpty(updated)
+	return d.writeAt(loc.pointer, updated, flow)
+}
+
+// writeAt writes value over the existing node at pointer
+func (d *Document) writeAt(pointer string, value interface{}, flow bool) error {
+	loc, err := d.locate(pointer)
+	if err != nil {
+		return err
+	}
+	if !loc.exists() {
+		return fmt.Errorf("%s does not exist", pointer)
+	}
+
+	switch parent := loc.parent.(type) {
+	case *ast.MappingNode:
+		if parent.IsFlowStyle {
+			return d.rewrite(loc, value, modeSet)
+		}
+		column := loc.entry.Key.GetToken().Position.Column
+		node, err := d.render(yaml.MapSlice{{Key: loc.key, Value: value}}, column, false)
+		if err != nil {
+			return err
+		}
+		rendered := node.(*ast.MappingNode).Values[0].Value
+		if flow {
+			if rendered, err = d.render(value, column, true); err != nil {
+				return err
+			}
+		}
+		loc.entry.Value = rendered
+	case *ast.SequenceNode:
+		if parent.IsFlowStyle {
+			return d.rewrite(loc, value, modeSet)
+		}
+		node, err := d.render([]interface{}{value}, parent.Start.Position.Column, flow)
+		if err != nil {
+			return err
+		}
+		parent.Values[loc.index] = node.(*ast.SequenceNode).Values[0]
+	}
+	return nil
+}
+
+// render marshals value in the document's style, indents it to start at column, and parses it back
+func (d *Document) render(value interface{}, column int, flow bool) (ast.Node, error) {
+	options := []yaml.EncodeOption{yaml.Indent(d.indent), yaml.IndentSequence(d.indentSequence)}
+	if flow {
+		options = append(options, yaml.Flow(true))
+	}
+	data, err := yaml.MarshalWithOptions(value, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	prefix := strings.Repeat(" ", column-1)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	file, err := parser.ParseBytes([]byte(strings.Join(lines, "\n")+"\n"), parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rendered value: %w", err)
+	}
+	if len(file.Docs) == 0 || file.Docs[0].Body == nil {
+		return nil, fmt.Errorf("rendered value is empty")
+	}
+	return file.Docs[0].Body, nil
+}
+
+// detectStyle records the indentation and sequence indentation used by the document
+func (d *Document) detectStyle() {
+	foundIndent, foundSequence := false, false
+	ast.Walk(visitorFunc(func(node ast.Node) {
+		entry, ok := node.(*ast.MappingValueNode)
+		if !ok {
+			return
+		}
+		keyColumn := entry.Key.GetToken().Position.Column
+		switch value := entry.Value.(type) {
+		case *ast.MappingNode:
+			if !foundIndent && !value.IsFlowStyle && len(value.Values) > 0 {
+				d.indent = value.Values[0].Key.GetToken().Position.Column - keyColumn
+				foundIndent = d.indent > 0
+			}
+		case *ast.SequenceNode:
+			if !foundSequence && !value.IsFlowStyle {
+				d.indentSequence = value.Start.Position.Column > keyColumn
+				foundSequence = true
+			}
+		}
+	}), d.file.Docs[0].Body)
+	if d.indent <= 0 {
+		d.indent = 2
+	}
+}
+
+// visitorFunc adapts a function to ast.Visitor
+type visitorFunc func(node ast.Node)
+
+// Visit implements ast.Visitor
+func (f visitorFunc) Visit(node ast.Node) ast.Visitor {
+	f(node)
+	return f
+}
+
+// child returns the node under a mapping key or sequence index
+func child(node ast.Node, token string) (ast.Node, error) {
+	switch n := node.(type) {
+	case *ast.MappingNode:
+		for _, entry := range n.Values {
+			if entry.Key.GetToken().Value == token {
+				return entry.Value, nil
+			}
+		}
+		return nil, fmt.Errorf("key %q not found", token)
+	case *ast.SequenceNode:
+		index, err := strconv.Atoi(token)
+		if err != nil || index < 0 || index >= len(n.Values) {
+			return nil, fmt.Errorf("index %q out of range", token)
+		}
+		return n.Values[index], nil
+	}
+	return nil, fmt.Errorf("cannot descend into %s", node.Type())
+}
+
+// insertItem inserts the single item of rendered into seq at index, keeping per-item comments aligned
+func insertItem(seq *ast.SequenceNode, index int, rendered *ast.SequenceNode) {
+	if len(seq.ValueHeadComments) == len(seq.Values) {
+		// the comment above the first item belongs to the sequence; keep it with that item
+		if index == 0 && seq.Comment != nil && seq.ValueHeadComments[0] == nil {
+			seq.ValueHeadComments[0] = seq.Comment
+			seq.Comment = nil
+		}
+		seq.ValueHeadComments = append(seq.ValueHeadComments[:index], append([]*ast.CommentGroupNode{nil}, seq.ValueHeadComments[index:]...)...)
+	}
+	if len(seq.Entries) == len(seq.Values) && len(rendered.Entries) > 0 {
+		seq.Entries = append(seq.Entries[:index], append([]*ast.SequenceEntryNode{rendered.Entries[0]}, seq.Entries[index:]...)...)
+	}
+	seq.Values = append(seq.Values[:index], append([]ast.Node{rendered.Values[0]}, seq.Values[index:]...)...)
+}
+
+// removeItem removes the item at index from seq, keeping per-item comments aligned
+func removeItem(seq *ast.SequenceNode, index int) {
+	if len(seq.ValueHeadComments) == len(seq.Values) {
+		seq.ValueHeadComments = append(seq.ValueHeadComments[:index], seq.ValueHeadComments[index+1:]...)
+	}
+	if len(seq.Entries) == len(seq.Values) {
+		seq.Entries = append(seq.Entries[:index], seq.Entries[index+1:]...)
+	}
+	seq.Values = append(seq.Values[:index], seq.Values[index+1:]...)
+}
+
+// sameData reports whether two values hold the same data once marshalled, ignoring key order
+func sameData(a, b interface{}) (bool, error) {
+	var plain [2]interface{}
+	for i, value := range []interface{}{a, b} {
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return false, fmt.Errorf("failed to marshal value: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &plain[i]); err != nil {
+			return false, fmt.Errorf("failed to decode value: %w", err)
+		}
+	}
+	return reflect.DeepEqual(plain[0], plain[1]), nil
+}
+
+// isFlow reports whether node is a flow-style collection
+func isFlow(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.MappingNode:
+		return n.IsFlowStyle
+	case *ast.SequenceNode:
+		return n.IsFlowStyle
+	}
+	return false
+}
+
+// isScalar reports whether value marshals to a scalar
+func isScalar(value interface{}) bool {
+	switch reflect.Indirect(reflect.ValueOf(value)).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		return false
+	}
+	return true
+}
+
+// isEmpty reports whether a generic collection has no items
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// splitPointer splits a JSON Pointer into unescaped reference tokens
+func splitPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q: must start with '/'", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// joinPointer builds a JSON Pointer from reference tokens
+func joinPointer(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
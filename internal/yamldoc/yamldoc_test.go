@@ -0,0 +1,267 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package yamldoc
+
+import (
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCatalog = `# Catalog header
+title: Test Catalog # inline comment
+metadata:
+  id: TEST
+  description: d
+controls:
+  # the first control
+  - id: C-1
+    title: One
+    assessment-requirements:
+      - id: C-1.1
+        text: First
+        applicability: [A, B]
+  - id: C-2
+    title: Two
+    assessment-requirements:
+      - id: C-2.1
+        text: |
+          Multi
+          line
+        applicability: [A]
+adherence: {}
+`
+
+func TestDocumentEdits(t *testing.T) {
+	tests := []struct {
+		name     string
+		edit     func(d *Document) error
+		contains []string
+		missing  []string
+		check    func(t *testing.T, d *Document)
+	}{
+		{
+			name:     "replace scalar keeps comments",
+			edit:     func(d *Document) error { return d.Replace("/controls/0/title", "Uno") },
+			contains: []string{"# Catalog header", "# the first control", "title: Uno", "Multi\n          line"},
+		},
+		{
+			name:     "add mapping key appends in order",
+			edit:     func(d *Document) error { return d.Add("/metadata/version", "1.0.0") },
+			contains: []string{"  description: d\n  version: 1.0.0\ncontrols:"},
+		},
+		{
+			name: "append control uses document indentation",
+			edit: func(d *Document) error {
+				return d.Add("/controls/-", yaml.MapSlice{
+					{Key: "id", Value: "C-3"},
+					{Key: "title", Value: "Three"},
+					{Key: "assessment-requirements", Value: []interface{}{yaml.MapSlice{{Key: "id", Value: "C-3.1"}, {Key: "text", Value: "Third"}}}},
+				})
+			},
+			contains: []string{"  - id: C-3\n    title: Three\n    assessment-requirements:\n      - id: C-3.1\n        text: Third\nadherence: {}"},
+		},
+		{
+			name:     "insert item keeps head comment on its item",
+			edit:     func(d *Document) error { return d.Add("/controls/0", yaml.MapSlice{{Key: "id", Value: "C-0"}}) },
+			contains: []string{"controls:\n  - id: C-0\n  # the first control\n  - id: C-1"},
+		},
+		{
+			name:    "remove item",
+			edit:    func(d *Document) error { return d.Remove("/controls/1") },
+			missing: []string{"C-2"},
+			check: func(t *testing.T, d *Document) {
+				var ids []map[string]interface{}
+				require.NoError(t, d.Decode("/controls", &ids))
+				assert.Len(t, ids, 1)
+			},
+		},
+		{
+			name:     "removing the last item leaves an empty list",
+			edit:     func(d *Document) error { return d.Remove("/controls/1/assessment-requirements/0") },
+			contains: []string{"title: Two\n    assessment-requirements: []"},
+		},
+		{
+			name:     "flow sequence stays flow",
+			edit:     func(d *Document) error { return d.Add("/controls/0/assessment-requirements/0/applicability/-", "C") },
+			contains: []string{"applicability: [A, B, C]"},
+		},
+		{
+			name:     "empty flow mapping becomes block when filled",
+			edit:     func(d *Document) error { return d.Add("/adherence/evaluation-methods", []interface{}{"manual"}) },
+			contains: []string{"adherence:\n  evaluation-methods:\n    - manual"},
+		},
+		{
+			name:     "escaped pointer tokens",
+			edit:     func(d *Document) error { return d.Add("/metadata/a~1b", "x") },
+			contains: []string{"a/b: x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := Parse([]byte(testCatalog))
+			require.NoError(t, err)
+			require.NoError(t, tt.edit(d))
+			out := d.String()
+			for _, s := range tt.contains {
+				assert.Contains(t, out, s)
+			}
+			for _, s := range tt.missing {
+				assert.NotContains(t, out, s)
+			}
+			if tt.check != nil {
+				tt.check(t, d)
+			}
+		})
+	}
+}
+
+func TestDocumentEditErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(d *Document) error
+	}{
+		{name: "replace missing key", edit: func(d *Document) error { return d.Replace("/metadata/version", "1") }},
+		{name: "remove missing index", edit: func(d *Document) error { return d.Remove("/controls/5") }},
+		{name: "pointer without slash", edit: func(d *Document) error { return d.Add("controls", "x") }},
+		{name: "descend into scalar", edit: func(d *Document) error { return d.Add("/title/x", "y") }},
+		{name: "replace root", edit: func(d *Document) error { return d.Replace("", "y") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := Parse([]byte(testCatalog))
+			require.NoError(t, err)
+			before := d.String()
+			assert.Error(t, tt.edit(d))
+			assert.Equal(t, before, d.String())
+		})
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		patch    string
+		wantErr  string
+		contains []string
+	}{
+		{
+			name:     "add and replace in order",
+			patch:    `[{"op": "add", "path": "/metadata/version", "value": "2.0"}, {"op": "replace", "path": "/controls/1/title", "value": "Deux"}]`,
+			contains: []string{"version: \"2.0\"", "title: Deux"},
+		},
+		{
+			name:     "object values keep their key order",
+			patch:    `[{"op": "add", "path": "/controls/-", "value": {"title": "Three", "id": "C-3"}}]`,
+			contains: []string{"  - title: Three\n    id: C-3"},
+		},
+		{
+			name:     "move an item",
+			patch:    `[{"op": "move", "from": "/controls/1", "path": "/controls/0"}]`,
+			contains: []string{"controls:\n  - id: C-2"},
+		},
+		{
+			name:     "passing test",
+			patch:    `[{"op": "test", "path": "/metadata/id", "value": "TEST"}, {"op": "copy", "from": "/metadata/id", "path": "/metadata/lexicon"}]`,
+			contains: []string{"lexicon: TEST"},
+		},
+		{
+			name:    "failing test rolls back earlier operations",
+			patch:   `[{"op": "remove", "path": "/controls/0"}, {"op": "test", "path": "/metadata/id", "value": "OTHER"}]`,
+			wantErr: "operation 1 (test /metadata/id)",
+		},
+		{
+			name:     "null values",
+			patch:    `[{"op": "add", "path": "/metadata/version", "value": null}, {"op": "test", "path": "/metadata/version", "value": null}]`,
+			contains: []string{"version: null"},
+		},
+		{
+			name:    "missing value",
+			patch:   `[{"op": "replace", "path": "/title"}]`,
+			wantErr: "value is required",
+		},
+		{
+			name:    "unknown operation",
+			patch:   `[{"op": "merge", "path": "/title"}]`,
+			wantErr: "unknown operation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := Parse([]byte(testCatalog))
+			require.NoError(t, err)
+			operations, err := ParsePatch([]byte(tt.patch))
+			require.NoError(t, err)
+
+			err = d.ApplyPatch(operations)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Equal(t, testCatalog, d.String())
+				return
+			}
+			require.NoError(t, err)
+			for _, s := range tt.contains {
+				assert.Contains(t, d.String(), s)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	d, err := Parse([]byte(testCatalog))
+	require.NoError(t, err)
+
+	// The base and target model everything except adherence, standing in for a typed struct
+	base, err := d.Value("")
+	require.NoError(t, err)
+	base = base.(yaml.MapSlice)[:3]
+
+	var target yaml.MapSlice
+	require.NoError(t, yaml.UnmarshalWithOptions([]byte(`
+title: Test Catalog
+metadata:
+  id: TEST
+  description: d
+  version: 1.1.0
+controls:
+  - id: C-2
+    title: Two
+    assessment-requirements:
+      - id: C-2.1
+        text: |
+          Multi
+          line
+        applicability: [A, B]
+  - id: C-3
+    title: Three
+`), &target, yaml.UseOrderedMap()))
+
+	operations, err := Diff(base, target)
+	require.NoError(t, err)
+	assert.Len(t, operations, 4)
+
+	require.NoError(t, d.Merge(base, target))
+	out := d.String()
+	assert.Contains(t, out, "# Catalog header")
+	assert.Contains(t, out, "title: Test Catalog # inline comment")
+	assert.Contains(t, out, "  version: 1.1.0\n")
+	assert.Contains(t, out, "applicability: [A, B]")
+	assert.Contains(t, out, "adherence: {}")
+	assert.NotContains(t, out, "C-1")
+
+	merged, err := d.Value("/controls")
+	require.NoError(t, err)
+	same, err := sameData(merged, target[2].Value)
+	require.NoError(t, err)
+	assert.True(t, same)
+
+	operations, err = Diff(target, target)
+	require.NoError(t, err)
+	assert.Empty(t, operations)
+}
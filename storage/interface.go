@@ -3,8 +3,9 @@ package storage
 // Storage defines the interface for storing and retrieving Gemara artifacts.
 // Implementations can provide local file-based storage or remote storage clients.
 type Storage interface {
-	// StoreRawYAML stores raw YAML content for a given layer and returns the artifact ID.
-	// The YAML content must include metadata.id. Returns the artifact ID on success.
+	// StoreRawYAML stores raw YAML or JSON content for a given layer and returns the artifact ID.
+	// The content must include metadata.id. New artifacts are stored in the format of the content;
+	// stored artifacts keep their format. Returns the artifact ID on success.
 	StoreRawYAML(layer int, yamlContent string) (string, error)
 
 	// Retrieve loads an artifact by layer and ID.
@@ -23,3 +24,34 @@ type Storage interface {
 	// For remote storage implementations, this may return an empty string or a logical identifier.
 	GetBaseDir() string
 }
+
+// RawStore is implemented by storage that keeps artifact files as they were written. Callers detect it
+// with a type assertion and fall back to Retrieve when the storage does not implement it.
+type RawStore interface {
+	// RetrieveRaw returns the stored YAML or JSON of an artifact exactly as it is stored.
+	RetrieveRaw(layer int, artifactID string) (string, error)
+}
+
+// QuarantineStore is implemented by storage that keeps the files it could not index. Callers detect it
+// with a type assertion; storage that does not implement it has no files to report or repair.
+// Files are identified by layer and file name.
+type QuarantineStore interface {
+	// ListProblems returns the files that could not be indexed, with the reason.
+	// If layer is 0, returns problems from all layers.
+	ListProblems(layer int) []*QuarantinedFile
+
+	// RetrieveQuarantined returns a file that could not be indexed exactly as it is stored.
+	RetrieveQuarantined(layer int, fileName string) (string, error)
+
+	// RepairQuarantined replaces the content of a file that could not be indexed and indexes it.
+	// Returns the artifact ID, or an error if the file still cannot be indexed.
+	RepairQuarantined(layer int, fileName string, content string) (string, error)
+}
+
+// CUESourceStore is implemented by storage that can keep the CUE source an artifact was exported from.
+// Callers detect it with a type assertion; storage that does not implement it keeps only the artifact.
+type CUESourceStore interface {
+	// StoreCUESource stores the CUE source a stored artifact was exported from, next to the artifact,
+	// and returns where it was stored.
+	StoreCUESource(layer int, artifactID string, cueContent string) (string, error)
+}
@@ -1,37 +1,67 @@
 package storage
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/complytime/gemara-mcp-server/internal/yamldoc"
 	"github.com/goccy/go-yaml"
 	"github.com/ossf/gemara"
 )
 
+// Serialization formats of stored artifact files
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
 // ArtifactIndexEntry represents an entry in the storage index
 type ArtifactIndexEntry struct {
 	ID       string `json:"id"`
 	Layer    int    `json:"layer"`
 	FilePath string `json:"file_path"`
 	Title    string `json:"title"`
+	Format   string `json:"format"`
+}
+
+// QuarantinedFile is an artifact file in a layer directory that could not be indexed, such as a
+// file with a YAML error or without metadata.id. List, get, and search do not see it.
+type QuarantinedFile struct {
+	Layer    int    `json:"layer"`
+	FileName string `json:"file_name"`
+	FilePath string `json:"file_path"`
+	Error    string `json:"error"`
 }
 
 // ArtifactStorage manages disk-based storage of Gemara artifacts with an in-memory index
 type ArtifactStorage struct {
-	baseDir string
-	index   map[string]*ArtifactIndexEntry // key: layer-id (e.g., "1-FINOS-AIR")
-	mu      sync.RWMutex                   // protects index and file operations
+	baseDir    string
+	index      map[string]*ArtifactIndexEntry // key: layer-id (e.g., "1-FINOS-AIR")
+	quarantine map[string]*QuarantinedFile    // key: layer-file name (e.g., "2-catalog.yaml")
+	mu         sync.RWMutex                   // protects index, quarantine, and file operations
 }
 
+// ArtifactStorage implements Storage and every optional storage interface
+var (
+	_ Storage         = (*ArtifactStorage)(nil)
+	_ RawStore        = (*ArtifactStorage)(nil)
+	_ QuarantineStore = (*ArtifactStorage)(nil)
+	_ CUESourceStore  = (*ArtifactStorage)(nil)
+)
+
 // NewArtifactStorage creates a new ArtifactStorage instance
 func NewArtifactStorage(baseDir string) (*ArtifactStorage, error) {
 	storage := &ArtifactStorage{
-		baseDir: baseDir,
-		index:   make(map[string]*ArtifactIndexEntry),
+		baseDir:    baseDir,
+		index:      make(map[string]*ArtifactIndexEntry),
+		quarantine: make(map[string]*QuarantinedFile),
 	}
 
 	// Ensure base directory exists
@@ -63,6 +93,7 @@ func (s *ArtifactStorage) loadIndex() error {
 
 	// Start with a clean index to avoid stale entries from deleted/renamed files
 	s.index = make(map[string]*ArtifactIndexEntry)
+	s.quarantine = make(map[string]*QuarantinedFile)
 
 	for layer := consts.MinLayer; layer <= consts.MaxLayer; layer++ {
 		layerDir := filepath.Join(s.baseDir, fmt.Sprintf("layer%d", layer))
@@ -82,46 +113,71 @@ func (s *ArtifactStorage) loadIndex() error {
 					continue
 				}
 
-				// Try to load the artifact to get its ID
-				var artifactID string
-				var title string
+				// Only layers 1-3 are indexed
+				if layer > consts.Layer3 {
+					continue
+				}
 
-				switch layer {
-				case consts.Layer1:
-					guidance := &gemara.GuidanceDocument{}
-					if err := guidance.LoadFile(fmt.Sprintf("file://%s", absPath)); err == nil {
-						artifactID = guidance.Metadata.Id
-						title = guidance.Title
-					}
-				case consts.Layer2:
-					catalog := &gemara.Catalog{}
-					if err := catalog.LoadFile(fmt.Sprintf("file://%s", absPath)); err == nil {
-						artifactID = catalog.Metadata.Id
-						title = catalog.Title
-					}
-				case consts.Layer3:
-					policy := &gemara.Policy{}
-					if err := policy.LoadFile(fmt.Sprintf("file://%s", absPath)); err == nil {
-						artifactID = policy.Metadata.Id
-						title = policy.Title
-					}
+				// Load the artifact to get its ID, quarantining files that cannot be indexed
+				artifactID, title, err := loadIndexEntry(layer, absPath)
+				if err == nil && artifactID == "" {
+					err = fmt.Errorf("metadata.id is missing")
+				}
+				if err != nil {
+					s.quarantine[quarantineKey(layer, entry.Name())] = &QuarantinedFile{Layer: layer, FileName: entry.Name(), FilePath: absPath, Error: err.Error()}
+					continue
 				}
 
-				if artifactID != "" {
-					key := fmt.Sprintf("%d-%s", layer, artifactID)
-					s.index[key] = &ArtifactIndexEntry{
-						ID:       artifactID,
+				// Files are read in name order and the last file with an ID is indexed
+				key := fmt.Sprintf("%d-%s", layer, artifactID)
+				if previous, exists := s.index[key]; exists {
+					previousName := filepath.Base(previous.FilePath)
+					s.quarantine[quarantineKey(layer, previousName)] = &QuarantinedFile{
 						Layer:    layer,
-						FilePath: absPath,
-						Title:    title,
+						FileName: previousName,
+						FilePath: previous.FilePath,
+						Error:    fmt.Sprintf("duplicate ID '%s': %s is indexed instead", artifactID, absPath),
 					}
 				}
+				s.index[key] = &ArtifactIndexEntry{
+					ID:       artifactID,
+					Layer:    layer,
+					FilePath: absPath,
+					Title:    title,
+					Format:   fileFormat(absPath),
+				}
 			}
 		}
 	}
 	return nil
 }
 
+// loadIndexEntry loads an artifact file through its gemara type and returns its ID and title.
+// Decoding errors quote the surrounding source after the first line, which is dropped.
+func loadIndexEntry(layer int, absPath string) (string, string, error) {
+	fileURI := fmt.Sprintf("file://%s", absPath)
+	var err error
+	var artifactID, title string
+	switch layer {
+	case consts.Layer1:
+		guidance := &gemara.GuidanceDocument{}
+		err = guidance.LoadFile(fileURI)
+		artifactID, title = guidance.Metadata.Id, guidance.Title
+	case consts.Layer2:
+		catalog := &gemara.Catalog{}
+		err = catalog.LoadFile(fileURI)
+		artifactID, title = catalog.Metadata.Id, catalog.Title
+	case consts.Layer3:
+		policy := &gemara.Policy{}
+		err = policy.LoadFile(fileURI)
+		artifactID, title = policy.Metadata.Id, policy.Title
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("%s", strings.SplitN(err.Error(), "\n", 2)[0])
+	}
+	return artifactID, title, nil
+}
+
 // Add stores an artifact to disk and adds it to the index
 func (s *ArtifactStorage) Add(layer int, artifactID string, artifact interface{}) error {
 	if layer < consts.MinLayer || layer > consts.MaxLayer {
@@ -135,23 +191,50 @@ func (s *ArtifactStorage) Add(layer int, artifactID string, artifact interface{}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Determine file path
-	layerDir := filepath.Join(s.baseDir, fmt.Sprintf("layer%d", layer))
-	filename := fmt.Sprintf("%s.yaml", artifactID)
-	filePath := filepath.Clean(filepath.Join(layerDir, filename))
-	absPath, err := filepath.Abs(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to resolve absolute path: %w", err)
+	// Determine file path, keeping the file an already stored artifact was loaded from
+	key := fmt.Sprintf("%d-%s", layer, artifactID)
+	var absPath string
+	if entry, exists := s.index[key]; exists {
+		absPath = entry.FilePath
+	} else {
+		layerDir := filepath.Join(s.baseDir, fmt.Sprintf("layer%d", layer))
+		filename := fmt.Sprintf("%s.yaml", artifactID)
+		filePath := filepath.Clean(filepath.Join(layerDir, filename))
+		var err error
+		absPath, err = filepath.Abs(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to resolve absolute path: %w", err)
+		}
 	}
 
 	// Marshal artifact to YAML
-	yamlBytes, err := yaml.Marshal(artifact)
+	yamlBytes, err := MarshalArtifact(artifact)
 	if err != nil {
 		return fmt.Errorf("failed to marshal artifact to YAML: %w", err)
 	}
 
+	// Apply only the changes to an existing file, so its comments, key order, and fields the
+	// gemara types do not model survive the update. JSON files are merged as YAML and written back as JSON.
+	format := fileFormat(absPath)
+	if existing, err := os.ReadFile(absPath); err == nil {
+		if format == FormatJSON {
+			if existing, err = ConvertFormat(existing, FormatYAML); err != nil {
+				existing = nil
+			}
+		}
+		yamlBytes, err = MergeArtifactYAML(layer, existing, yamlBytes)
+		if err != nil {
+			return fmt.Errorf("failed to update %s: %w", absPath, err)
+		}
+	}
+	if format == FormatJSON {
+		if yamlBytes, err = ConvertFormat(yamlBytes, FormatJSON); err != nil {
+			return fmt.Errorf("failed to convert artifact to JSON: %w", err)
+		}
+	}
+
 	// Write to disk
-	if err := os.WriteFile(absPath, yamlBytes, 0644); err != nil {
+	if err := writeFileAtomic(absPath, yamlBytes); err != nil {
 		return fmt.Errorf("failed to write artifact to disk: %w", err)
 	}
 
@@ -173,17 +256,73 @@ func (s *ArtifactStorage) Add(layer int, artifactID string, artifact interface{}
 	}
 
 	// Update index
-	key := fmt.Sprintf("%d-%s", layer, artifactID)
 	s.index[key] = &ArtifactIndexEntry{
 		ID:       artifactID,
 		Layer:    layer,
 		FilePath: absPath,
 		Title:    title,
+		Format:   format,
 	}
+	delete(s.quarantine, quarantineKey(layer, filepath.Base(absPath)))
 
 	return nil
 }
 
+// MarshalArtifact renders a gemara artifact as YAML, writing gemara.ActorType values as their
+// schema strings since its marshaler is only defined on the pointer receiver
+func MarshalArtifact(artifact interface{}) ([]byte, error) {
+	return yaml.MarshalWithOptions(artifact, yaml.CustomMarshaler[gemara.ActorType](func(t gemara.ActorType) ([]byte, error) {
+		return []byte(t.String()), nil
+	}))
+}
+
+// MergeArtifactYAML applies the difference between an existing file, as the gemara types see it,
+// and the updated artifact to the file's syntax tree. A file that cannot be parsed or decoded is
+// replaced by the updated YAML.
+func MergeArtifactYAML(layer int, existing, updated []byte) ([]byte, error) {
+	doc, err := yamldoc.Parse(existing)
+	if err != nil {
+		return updated, nil
+	}
+	base, err := newArtifact(layer)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(existing, base); err != nil {
+		return updated, nil
+	}
+	baseYAML, err := MarshalArtifact(base)
+	if err != nil {
+		return nil, err
+	}
+
+	var from, to interface{}
+	if err := yaml.UnmarshalWithOptions(baseYAML, &from, yaml.UseOrderedMap()); err != nil {
+		return nil, err
+	}
+	if err := yaml.UnmarshalWithOptions(updated, &to, yaml.UseOrderedMap()); err != nil {
+		return nil, err
+	}
+	if err := doc.Merge(from, to); err != nil {
+		return nil, err
+	}
+	return []byte(doc.String()), nil
+}
+
+// newArtifact returns an empty artifact of the type stored in layer
+func newArtifact(layer int) (interface{}, error) {
+	switch layer {
+	case consts.Layer1:
+		return &gemara.GuidanceDocument{}, nil
+	case consts.Layer2:
+		return &gemara.Catalog{}, nil
+	case consts.Layer3:
+		return &gemara.Policy{}, nil
+	default:
+		return nil, fmt.Errorf("layer %d storage not implemented", layer)
+	}
+}
+
 // List returns all artifacts for a given layer (or all layers if layer is 0)
 func (s *ArtifactStorage) List(layer int) []*ArtifactIndexEntry {
 	s.mu.RLock()
@@ -198,12 +337,96 @@ func (s *ArtifactStorage) List(layer int) []*ArtifactIndexEntry {
 				Layer:    entry.Layer,
 				FilePath: entry.FilePath,
 				Title:    entry.Title,
+				Format:   entry.Format,
 			})
 		}
 	}
 	return results
 }
 
+// ListProblems returns the quarantined files of a layer (or all layers if layer is 0), ordered by path
+func (s *ArtifactStorage) ListProblems(layer int) []*QuarantinedFile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var results []*QuarantinedFile
+	for _, file := range s.quarantine {
+		if layer == 0 || file.Layer == layer {
+			copied := *file
+			results = append(results, &copied)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].FilePath < results[j].FilePath })
+	return results
+}
+
+// quarantineKey returns the quarantine key of a file in a layer directory
+func quarantineKey(layer int, fileName string) string {
+	return fmt.Sprintf("%d-%s", layer, fileName)
+}
+
+// RetrieveQuarantined returns a quarantined file of a layer without decoding it
+func (s *ArtifactStorage) RetrieveQuarantined(layer int, fileName string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	file, exists := s.quarantine[quarantineKey(layer, fileName)]
+	if !exists {
+		return "", fmt.Errorf("file is not quarantined: layer %d, %s", layer, fileName)
+	}
+	data, err := os.ReadFile(file.FilePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read quarantined file: %w", err)
+	}
+	return string(data), nil
+}
+
+// RepairQuarantined writes YAML or JSON content over a quarantined file, in the format of the file,
+// and indexes it. A file that still cannot be indexed stays quarantined with the new error, and a file
+// whose ID another file already uses is not indexed.
+func (s *ArtifactStorage) RepairQuarantined(layer int, fileName string, content string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := quarantineKey(layer, fileName)
+	file, exists := s.quarantine[key]
+	if !exists {
+		return "", fmt.Errorf("file is not quarantined: layer %d, %s", layer, fileName)
+	}
+	filePath := file.FilePath
+	data, err := ConvertFormat([]byte(content), fileFormat(filePath))
+	if err != nil {
+		return "", fmt.Errorf("failed to convert content for %s: %w", filePath, err)
+	}
+	if err := writeFileAtomic(filePath, data); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+
+	artifactID, title, err := loadIndexEntry(file.Layer, filePath)
+	if err == nil && artifactID == "" {
+		err = fmt.Errorf("metadata.id is missing")
+	}
+	if err != nil {
+		file.Error = err.Error()
+		return "", fmt.Errorf("%s still cannot be indexed: %w", filePath, err)
+	}
+	indexKey := fmt.Sprintf("%d-%s", file.Layer, artifactID)
+	if entry, exists := s.index[indexKey]; exists && entry.FilePath != filePath {
+		file.Error = fmt.Sprintf("duplicate ID '%s': %s is indexed instead", artifactID, entry.FilePath)
+		return "", fmt.Errorf("%s: %s", filePath, file.Error)
+	}
+
+	s.index[indexKey] = &ArtifactIndexEntry{
+		ID:       artifactID,
+		Layer:    file.Layer,
+		FilePath: filePath,
+		Title:    title,
+		Format:   fileFormat(filePath),
+	}
+	delete(s.quarantine, key)
+	return artifactID, nil
+}
+
 // Retrieve loads an artifact from disk by layer and ID
 func (s *ArtifactStorage) Retrieve(layer int, artifactID string) (interface{}, error) {
 	if layer < consts.MinLayer || layer > consts.MaxLayer {
@@ -250,6 +473,44 @@ func (s *ArtifactStorage) Retrieve(layer int, artifactID string) (interface{}, e
 	}
 }
 
+// RetrieveRaw returns the stored file of an artifact without decoding it, as YAML or JSON
+// depending on the format it was stored in
+func (s *ArtifactStorage) RetrieveRaw(layer int, artifactID string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key := fmt.Sprintf("%d-%s", layer, artifactID)
+	entry, exists := s.index[key]
+	if !exists {
+		return "", fmt.Errorf("artifact not found: layer %d, id %s", layer, artifactID)
+	}
+
+	data, err := os.ReadFile(entry.FilePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read artifact: %w", err)
+	}
+	return string(data), nil
+}
+
+// StoreCUESource writes the CUE source of a stored artifact next to its file, with the .cue extension,
+// and returns the path. The source is not indexed; the stored YAML or JSON remains the artifact.
+func (s *ArtifactStorage) StoreCUESource(layer int, artifactID string, cueContent string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := fmt.Sprintf("%d-%s", layer, artifactID)
+	entry, exists := s.index[key]
+	if !exists {
+		return "", fmt.Errorf("artifact not found: layer %d, id %s", layer, artifactID)
+	}
+
+	sourcePath := strings.TrimSuffix(entry.FilePath, filepath.Ext(entry.FilePath)) + ".cue"
+	if err := writeFileAtomic(sourcePath, []byte(cueContent)); err != nil {
+		return "", fmt.Errorf("failed to write CUE source to %s: %w", sourcePath, err)
+	}
+	return sourcePath, nil
+}
+
 // GetBaseDir returns the base directory path
 func (s *ArtifactStorage) GetBaseDir() string {
 	return s.baseDir
@@ -266,8 +527,10 @@ func (s *ArtifactStorage) Rescan() error {
 	return s.loadIndex()
 }
 
-// StoreRawYAML stores raw YAML content to disk and updates the index
-// This is the preferred method for storing artifacts as it preserves all YAML content without data loss
+// StoreRawYAML stores raw YAML or JSON content to disk and updates the index
+// This is the preferred method for storing artifacts as it preserves all YAML content without data loss.
+// New artifacts are written as <id>.yaml or <id>.json in the format of the content. An artifact that is
+// already stored keeps its file and format, and content in the other format is converted to it.
 func (s *ArtifactStorage) StoreRawYAML(layer int, yamlContent string) (string, error) {
 	if layer < consts.MinLayer || layer > consts.MaxLayer {
 		return "", fmt.Errorf("invalid layer: %d (must be %d-%d)", layer, consts.MinLayer, consts.MaxLayer)
@@ -295,37 +558,98 @@ func (s *ArtifactStorage) StoreRawYAML(layer int, yamlContent string) (string, e
 		}
 	}
 
+	// The title is top-level in the current schema and inside metadata in legacy artifacts
+	if t, ok := metadata["title"].(string); ok && t != "" {
+		title = t
+	}
+
 	if artifactID == "" {
 		return "", fmt.Errorf("metadata.id is required in YAML content")
 	}
 
-	// Determine file path
+	// Determine file path, keeping the file an already stored artifact was loaded from
+	key := fmt.Sprintf("%d-%s", layer, artifactID)
 	layerDir := filepath.Join(s.baseDir, fmt.Sprintf("layer%d", layer))
-	filename := fmt.Sprintf("%s.yaml", artifactID)
-	filePath := filepath.Join(layerDir, filename)
-	absPath, err := filepath.Abs(filePath)
-	if err != nil {
-		return "", fmt.Errorf("failed to resolve absolute path: %w", err)
+	content := []byte(yamlContent)
+	format := DetectFormat(content)
+	var absPath string
+	if entry, exists := s.index[key]; exists {
+		absPath = entry.FilePath
+	} else {
+		filename := fmt.Sprintf("%s.%s", artifactID, format)
+		filePath := filepath.Join(layerDir, filename)
+		var err error
+		absPath, err = filepath.Abs(filePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve absolute path: %w", err)
+		}
+	}
+	if fileFormat(absPath) != format {
+		format = fileFormat(absPath)
+		converted, err := ConvertFormat(content, format)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert content to %s for %s: %w", format, absPath, err)
+		}
+		content = converted
 	}
 
-	// Write raw YAML to disk
-	if err := os.WriteFile(absPath, []byte(yamlContent), 0644); err != nil {
+	// Write raw content to disk
+	if err := writeFileAtomic(absPath, content); err != nil {
 		return "", fmt.Errorf("failed to write YAML to disk at %s: %w (current uid: %d, gid: %d, directory: %s)",
 			absPath, err, os.Getuid(), os.Getgid(), layerDir)
 	}
 
 	// Update index
-	key := fmt.Sprintf("%d-%s", layer, artifactID)
 	s.index[key] = &ArtifactIndexEntry{
 		ID:       artifactID,
 		Layer:    layer,
 		FilePath: absPath,
 		Title:    title,
+		Format:   format,
 	}
+	delete(s.quarantine, quarantineKey(layer, filepath.Base(absPath)))
 
 	return artifactID, nil
 }
 
+// DetectFormat returns FormatJSON for content that is a JSON object and FormatYAML otherwise
+func DetectFormat(content []byte) string {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(content, []byte("\ufeff")))
+	if bytes.HasPrefix(trimmed, []byte("{")) && json.Valid(trimmed) {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+// ConvertFormat re-serializes YAML or JSON content in the given format, keeping the key order.
+// JSON is indented with two spaces. Comments do not survive the conversion.
+func ConvertFormat(content []byte, format string) ([]byte, error) {
+	if DetectFormat(content) == format {
+		return content, nil
+	}
+	if format == FormatYAML {
+		return yaml.JSONToYAML(content)
+	}
+	compact, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, err
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, bytes.TrimSpace(compact), "", "  "); err != nil {
+		return nil, err
+	}
+	indented.WriteString("\n")
+	return indented.Bytes(), nil
+}
+
+// fileFormat returns the format of an artifact file from its extension
+func fileFormat(path string) string {
+	if filepath.Ext(path) == ".json" {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
 // MarshalJSON implements json.Marshaler for ArtifactIndexEntry
 func (e *ArtifactIndexEntry) MarshalJSON() ([]byte, error) {
 	type Alias ArtifactIndexEntry
@@ -335,3 +659,26 @@ func (e *ArtifactIndexEntry) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(e),
 	})
 }
+
+// writeFileAtomic writes data to a temporary file in the target directory and renames it into place,
+// so readers never observe a partially written artifact
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,319 @@
+package authoring
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+)
+
+// categoryBaseline is the subset of a catalog that applies to one applicability category
+type categoryBaseline struct {
+	CatalogID    string           `json:"catalog_id" yaml:"catalog_id"`
+	CatalogTitle string           `json:"catalog_title" yaml:"catalog_title"`
+	Category     gemara.Category  `json:"category" yaml:"category"`
+	Controls     []gemara.Control `json:"controls" yaml:"controls"`
+}
+
+// handleGetBaselineForCategory returns the controls and assessment requirements applicable to a category
+func (g *GemaraAuthoringTools) handleGetBaselineForCategory(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	category := request.GetString("category", "")
+	catalogID := request.GetString("catalog_id", "")
+	outputFormat := request.GetString("output_format", "markdown")
+
+	if category == "" {
+		return mcp.NewToolResultError("category is required"), nil
+	}
+
+	var catalogIDs []string
+	if catalogID != "" {
+		if g.loadLayer2Catalog(catalogID) == nil {
+			return mcp.NewToolResultErrorf("Catalog with ID '%s' not found. Use list_layer2_controls to see available catalogs.", catalogID), nil
+		}
+		catalogIDs = []string{catalogID}
+	} else {
+		for _, entry := range g.getLayerEntries(consts.Layer2) {
+			catalogIDs = append(catalogIDs, entry.ID)
+		}
+	}
+
+	var baselines []categoryBaseline
+	var declared []string
+	for _, id := range catalogIDs {
+		catalog := g.loadLayer2Catalog(id)
+		if catalog == nil {
+			continue
+		}
+		for _, c := range catalog.Metadata.ApplicabilityCategories {
+			declared = append(declared, fmt.Sprintf("%s (%s)", c.Id, id))
+		}
+		if baseline := g.buildCategoryBaseline(id, catalog, category); len(baseline.Controls) > 0 {
+			baselines = append(baselines, baseline)
+		}
+	}
+
+	if len(baselines) == 0 {
+		msg := fmt.Sprintf("No controls have assessment requirements applicable to '%s'.", category)
+		if len(declared) > 0 {
+			msg += fmt.Sprintf("\n\nDeclared applicability categories: %s", strings.Join(declared, ", "))
+		}
+		return mcp.NewToolResultText(msg), nil
+	}
+
+	if outputFormat == "json" || outputFormat == "yaml" {
+		output, err := marshalOutput(baselines, outputFormat)
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal output: %v", err), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("# Baseline for '%s'\n\n", category))
+	for _, baseline := range baselines {
+		requirementCount := 0
+		for _, control := range baseline.Controls {
+			requirementCount += len(control.AssessmentRequirements)
+		}
+		result.WriteString(fmt.Sprintf("## Catalog: %s (`%s`)\n\n", baseline.CatalogTitle, baseline.CatalogID))
+		if baseline.Category.Title != "" {
+			result.WriteString(fmt.Sprintf("- **Category**: %s - %s\n", baseline.Category.Id, baseline.Category.Title))
+		}
+		if baseline.Category.Description != "" {
+			result.WriteString(fmt.Sprintf("- **Description**: %s\n", baseline.Category.Description))
+		}
+		result.WriteString(fmt.Sprintf("- **Controls**: %d\n", len(baseline.Controls)))
+		result.WriteString(fmt.Sprintf("- **Assessment Requirements**: %d\n\n", requirementCount))
+
+		for _, control := range baseline.Controls {
+			result.WriteString(fmt.Sprintf("### %s: %s\n\n", control.Id, control.Title))
+			for _, req := range control.AssessmentRequirements {
+				result.WriteString(fmt.Sprintf("- **%s**: %s\n", req.Id, req.Text))
+			}
+			result.WriteString("\n")
+		}
+	}
+	result.WriteString("Use `get_layer2_control` with a control_id to see all assessment requirements of a control.\n")
+
+	return mcp.NewToolResultText(result.String()), nil
+}
+
+// buildCategoryBaseline returns the controls of a catalog trimmed to the assessment requirements applicable to a category
+func (g *GemaraAuthoringTools) buildCategoryBaseline(catalogID string, catalog *gemara.Catalog, category string) categoryBaseline {
+	baseline := categoryBaseline{
+		CatalogID:    catalogID,
+		CatalogTitle: catalog.Title,
+		Category:     gemara.Category{Id: category},
+		Controls:     []gemara.Control{},
+	}
+	for _, c := range catalog.Metadata.ApplicabilityCategories {
+		if g.scopeEngine.Matches(category, c.Id) || g.scopeEngine.Matches(category, c.Title) {
+			baseline.Category = c
+			break
+		}
+	}
+
+	for _, control := range catalog.Controls {
+		requirements := g.applicableRequirements(control, catalog.Metadata.ApplicabilityCategories, category)
+		if len(requirements) == 0 {
+			continue
+		}
+		control.AssessmentRequirements = requirements
+		baseline.Controls = append(baseline.Controls, control)
+	}
+	return baseline
+}
+
+// applicableRequirements returns the assessment requirements of a control whose applicability
+// includes the given category ID or title
+func (g *GemaraAuthoringTools) applicableRequirements(control gemara.Control, categories []gemara.Category, category string) []gemara.AssessmentRequirement {
+	var requirements []gemara.AssessmentRequirement
+	for _, req := range control.AssessmentRequirements {
+		values := requirementApplicabilityValues(req.Applicability, categories)
+		if len(g.scopeEngine.MatchingTerms([]string{category}, values)) > 0 {
+			requirements = append(requirements, req)
+		}
+	}
+	return requirements
+}
+
+// baselineSelection holds the controls and requirements chosen from a catalog for a generated baseline
+type baselineSelection struct {
+	Included          []string
+	Excluded          []string
+	RemovedByCategory []gemara.AssessmentRequirement
+}
+
+// handleGenerateBaseline builds a Layer 3 Policy that tailors a catalog to a category and a selection of families and controls
+func (g *GemaraAuthoringTools) handleGenerateBaseline(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	catalogID := request.GetString("catalog_id", "")
+	category := request.GetString("category", "")
+	policyID := request.GetString("policy_id", "")
+	title := request.GetString("title", "")
+	includeFamilies := g.extractStringArray(request, "include_families")
+	excludeFamilies := g.extractStringArray(request, "exclude_families")
+	includeControls := g.extractStringArray(request, "include_controls")
+	excludeControls := g.extractStringArray(request, "exclude_controls")
+
+	if catalogID == "" {
+		return mcp.NewToolResultError("catalog_id is required"), nil
+	}
+	catalog := g.loadLayer2Catalog(catalogID)
+	if catalog == nil {
+		return mcp.NewToolResultErrorf("Catalog with ID '%s' not found. Use list_layer2_controls to see available catalogs.", catalogID), nil
+	}
+
+	selection := g.selectBaselineControls(catalog, category, includeFamilies, excludeFamilies, includeControls, excludeControls)
+	if len(selection.Included) == 0 {
+		return mcp.NewToolResultErrorf("No controls of catalog '%s' remain after applying the category and include/exclude filters.", catalogID), nil
+	}
+
+	policy := g.buildBaselinePolicy(catalogID, catalog, category, policyID, title, selection)
+	yamlContent, err := marshalArtifactYAML(policy)
+	if err != nil {
+		return mcp.NewToolResultErrorf("failed to marshal policy YAML: %v", err), nil
+	}
+
+	if failure := g.cueValidationFailure(yamlContent, consts.Layer3); failure != "" {
+		return mcp.NewToolResultErrorf("Generated policy failed CUE validation:\n%s\n\n```yaml\n%s```", failure, yamlContent), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("# Generated Baseline: %s\n\n", policy.Title))
+	result.WriteString(fmt.Sprintf("- **Policy ID**: `%s`\n", policy.Metadata.Id))
+	result.WriteString(fmt.Sprintf("- **Catalog**: %s (`%s`)\n", catalog.Title, catalogID))
+	if category != "" {
+		result.WriteString(fmt.Sprintf("- **Category**: %s\n", category))
+	}
+	result.WriteString(fmt.Sprintf("- **Controls Included**: %d\n", len(selection.Included)))
+	result.WriteString(fmt.Sprintf("- **Controls Excluded**: %d\n", len(selection.Excluded)))
+	result.WriteString(fmt.Sprintf("- **Requirements Removed**: %d\n", len(selection.RemovedByCategory)))
+	result.WriteString("- **CUE Validation**: ✅ PASSED\n\n")
+	result.WriteString("Replace the TODO placeholders (author, contacts, scope, rationale) and store the policy with `store_layer3_yaml`.\n\n")
+	result.WriteString(fmt.Sprintf("```yaml\n%s```\n", yamlContent))
+
+	return mcp.NewToolResultText(result.String()), nil
+}
+
+// selectBaselineControls applies family and control filters and the category to the controls of a catalog.
+// Include lists narrow the selection, exclude lists always win, and controls without any requirement
+// applicable to the category are excluded.
+func (g *GemaraAuthoringTools) selectBaselineControls(catalog *gemara.Catalog, category string, includeFamilies, excludeFamilies, includeControls, excludeControls []string) baselineSelection {
+	selection := baselineSelection{}
+	restricted := len(includeFamilies) > 0 || len(includeControls) > 0
+
+	for _, control := range catalog.Controls {
+		selected := !restricted || slices.Contains(includeFamilies, control.Family) || slices.Contains(includeControls, control.Id)
+		if slices.Contains(excludeFamilies, control.Family) || slices.Contains(excludeControls, control.Id) {
+			selected = false
+		}
+
+		var applicable []gemara.AssessmentRequirement
+		if selected && category != "" {
+			applicable = g.applicableRequirements(control, catalog.Metadata.ApplicabilityCategories, category)
+			if len(applicable) == 0 {
+				selected = false
+			}
+		}
+
+		if !selected {
+			selection.Excluded = append(selection.Excluded, control.Id)
+			continue
+		}
+		selection.Included = append(selection.Included, control.Id)
+
+		if category != "" {
+			for _, req := range control.AssessmentRequirements {
+				if !containsRequirement(applicable, req.Id) {
+					selection.RemovedByCategory = append(selection.RemovedByCategory, req)
+				}
+			}
+		}
+	}
+
+	return selection
+}
+
+// buildBaselinePolicy assembles the Policy document for a baseline selection, with TODO placeholders
+// for the fields only the organization can fill in
+func (g *GemaraAuthoringTools) buildBaselinePolicy(catalogID string, catalog *gemara.Catalog, category, policyID, title string, selection baselineSelection) *gemara.Policy {
+	if policyID == "" {
+		policyID = g.sanitizeID(fmt.Sprintf("%s %s baseline", catalogID, category))
+		if category == "" {
+			policyID = g.sanitizeID(catalogID + " baseline")
+		}
+	}
+	if title == "" {
+		title = fmt.Sprintf("%s Baseline", catalog.Title)
+		if category != "" {
+			title = fmt.Sprintf("%s Baseline (%s)", catalog.Title, category)
+		}
+	}
+	catalogVersion := catalog.Metadata.Version
+	if catalogVersion == "" {
+		catalogVersion = "TODO: catalog version"
+	}
+
+	description := fmt.Sprintf("Organization baseline tailored from the %s catalog.", catalog.Title)
+	if category != "" {
+		description = fmt.Sprintf("Organization baseline tailored from the %s catalog for applicability category %s.", catalog.Title, category)
+	}
+
+	imported := gemara.CatalogImport{
+		ReferenceId: catalogID,
+		Exclusions:  selection.Excluded,
+	}
+	for _, req := range selection.RemovedByCategory {
+		imported.AssessmentRequirementModifications = append(imported.AssessmentRequirementModifications, gemara.AssessmentRequirementModifier{
+			Id:                    fmt.Sprintf("%s-remove-%s", policyID, req.Id),
+			TargetId:              req.Id,
+			ModificationType:      "remove",
+			ModificationRationale: fmt.Sprintf("Not applicable to %s. TODO: confirm rationale.", category),
+		})
+	}
+
+	return &gemara.Policy{
+		Title: title,
+		Metadata: gemara.Metadata{
+			Id:          policyID,
+			Version:     "0.1.0",
+			Description: description,
+			Author: gemara.Actor{
+				Id:   "TODO-author-id",
+				Name: "TODO: policy author",
+				Type: gemara.Human,
+			},
+			MappingReferences: []gemara.MappingReference{{
+				Id:      catalogID,
+				Title:   catalog.Title,
+				Version: catalogVersion,
+			}},
+		},
+		Contacts: gemara.Contacts{
+			Responsible: []gemara.Contact{{Name: "TODO: team responsible for implementing the controls"}},
+			Accountable: []gemara.Contact{{Name: "TODO: owner accountable for the policy"}},
+		},
+		Scope: gemara.Scope{
+			In: gemara.Dimensions{
+				Technologies: []string{"TODO: in-scope technologies"},
+			},
+		},
+		Imports: gemara.Imports{
+			Catalogs: []gemara.CatalogImport{imported},
+		},
+	}
+}
+
+// containsRequirement reports whether requirements contains one with the given ID
+func containsRequirement(requirements []gemara.AssessmentRequirement, id string) bool {
+	for _, req := range requirements {
+		if req.Id == id {
+			return true
+		}
+	}
+	return false
+}
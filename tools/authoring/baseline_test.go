@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"testing"
+
+	"github.com/complytime/gemara-mcp-server/internal/scope"
+	"github.com/ossf/gemara"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBaselineCatalog returns a catalog with two families whose requirements apply to Kubernetes,
+// virtual machines, or both
+func testBaselineCatalog() *gemara.Catalog {
+	requirement := func(id string, applicability ...string) gemara.AssessmentRequirement {
+		return gemara.AssessmentRequirement{Id: id, Text: id, Applicability: applicability}
+	}
+	return &gemara.Catalog{
+		Title: "Baseline Catalog",
+		Metadata: gemara.Metadata{Id: "BASE", ApplicabilityCategories: []gemara.Category{
+			{Id: "k8s", Title: "Kubernetes"},
+			{Id: "vm", Title: "Virtual Machines"},
+		}},
+		Controls: []gemara.Control{
+			{Id: "A-1", Family: "FAM-A", AssessmentRequirements: []gemara.AssessmentRequirement{requirement("A-1.1", "k8s"), requirement("A-1.2", "vm")}},
+			{Id: "A-2", Family: "FAM-A", AssessmentRequirements: []gemara.AssessmentRequirement{requirement("A-2.1", "vm")}},
+			{Id: "B-1", Family: "FAM-B", AssessmentRequirements: []gemara.AssessmentRequirement{requirement("B-1.1", "k8s", "vm")}},
+			{Id: "B-2", Family: "FAM-B"},
+		},
+	}
+}
+
+func TestSelectBaselineControls(t *testing.T) {
+	g := &GemaraAuthoringTools{scopeEngine: scope.DefaultEngine()}
+
+	tests := []struct {
+		name            string
+		category        string
+		includeFamilies []string
+		excludeFamilies []string
+		includeControls []string
+		excludeControls []string
+		included        []string
+		excluded        []string
+		removed         []string
+	}{
+		{name: "no filters", included: []string{"A-1", "A-2", "B-1", "B-2"}},
+		{name: "category synonym", category: "Container Orchestration", included: []string{"A-1", "B-1"}, excluded: []string{"A-2", "B-2"}, removed: []string{"A-1.2"}},
+		{name: "category title in another case", category: "kubernetes", included: []string{"A-1", "B-1"}, excluded: []string{"A-2", "B-2"}, removed: []string{"A-1.2"}},
+		{name: "unknown category", category: "quantum", excluded: []string{"A-1", "A-2", "B-1", "B-2"}},
+		{name: "include family", includeFamilies: []string{"FAM-B"}, included: []string{"B-1", "B-2"}, excluded: []string{"A-1", "A-2"}},
+		{name: "include lists combine", includeFamilies: []string{"FAM-B"}, includeControls: []string{"A-1"}, included: []string{"A-1", "B-1", "B-2"}, excluded: []string{"A-2"}},
+		{name: "exclude control wins over its family", includeFamilies: []string{"FAM-A"}, excludeControls: []string{"A-1"}, included: []string{"A-2"}, excluded: []string{"A-1", "B-1", "B-2"}},
+		{name: "exclude family wins over its control", includeControls: []string{"B-1"}, excludeFamilies: []string{"FAM-B"}, excluded: []string{"A-1", "A-2", "B-1", "B-2"}},
+		{name: "family IDs are case sensitive", includeFamilies: []string{"fam-a"}, excluded: []string{"A-1", "A-2", "B-1", "B-2"}},
+		{name: "unknown excluded control", excludeControls: []string{"Z-9"}, included: []string{"A-1", "A-2", "B-1", "B-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selection := g.selectBaselineControls(testBaselineCatalog(), tt.category, tt.includeFamilies, tt.excludeFamilies, tt.includeControls, tt.excludeControls)
+			assert.Equal(t, tt.included, selection.Included)
+			assert.Equal(t, tt.excluded, selection.Excluded)
+			var removed []string
+			for _, req := range selection.RemovedByCategory {
+				removed = append(removed, req.Id)
+			}
+			assert.Equal(t, tt.removed, removed)
+		})
+	}
+}
+
+func TestBuildCategoryBaseline(t *testing.T) {
+	g := &GemaraAuthoringTools{scopeEngine: scope.DefaultEngine()}
+
+	tests := []struct {
+		name         string
+		category     string
+		want         gemara.Category
+		requirements map[string][]string
+	}{
+		{
+			name:         "category ID",
+			category:     "vm",
+			want:         gemara.Category{Id: "vm", Title: "Virtual Machines"},
+			requirements: map[string][]string{"A-1": {"A-1.2"}, "A-2": {"A-2.1"}, "B-1": {"B-1.1"}},
+		},
+		{
+			name:         "taxonomy synonym of the category title",
+			category:     "Container Orchestration",
+			want:         gemara.Category{Id: "k8s", Title: "Kubernetes"},
+			requirements: map[string][]string{"A-1": {"A-1.1"}, "B-1": {"B-1.1"}},
+		},
+		{
+			name:         "unknown category",
+			category:     "quantum",
+			want:         gemara.Category{Id: "quantum"},
+			requirements: map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseline := g.buildCategoryBaseline("BASE", testBaselineCatalog(), tt.category)
+			assert.Equal(t, "Baseline Catalog", baseline.CatalogTitle)
+			assert.Equal(t, tt.want, baseline.Category)
+			requirements := make(map[string][]string)
+			for _, control := range baseline.Controls {
+				for _, req := range control.AssessmentRequirements {
+					requirements[control.Id] = append(requirements[control.Id], req.Id)
+				}
+			}
+			assert.Equal(t, tt.requirements, requirements)
+		})
+	}
+}
@@ -0,0 +1,639 @@
+package authoring
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/complytime/gemara-mcp-server/storage"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+)
+
+// controlsCSVColumns is the column layout of export_controls_csv and import_controls_csv. Each row
+// is one assessment requirement. The family and control columns repeat on every row of a control,
+// and a control without assessment requirements has one row with empty requirement columns.
+// Applicability values are separated by semicolons.
+var controlsCSVColumns = []string{
+	"family_id",
+	"family_title",
+	"family_description",
+	"control_id",
+	"control_title",
+	"objective",
+	"requirement_id",
+	"requirement_text",
+	"applicability",
+	"recommendation",
+}
+
+// mappingsCSVColumns is the column layout of export_mappings_csv, with one row per mapping entry.
+// mapping_type is guideline or threat.
+var mappingsCSVColumns = []string{
+	"catalog_id",
+	"control_id",
+	"control_title",
+	"mapping_type",
+	"reference_id",
+	"entry_id",
+	"strength",
+	"remarks",
+}
+
+// csvListSeparator separates multiple values within a CSV cell
+const csvListSeparator = ";"
+
+// controlsCSVImport is the result of building or updating a Layer 2 catalog from CSV
+type controlsCSVImport struct {
+	CatalogID       string   `json:"catalog_id"`
+	Created         bool     `json:"created"`
+	Rows            int      `json:"rows"`
+	FamiliesAdded   int      `json:"families_added"`
+	ControlsAdded   int      `json:"controls_added"`
+	ControlsUpdated int      `json:"controls_updated"`
+	ControlsRemoved int      `json:"controls_removed"`
+	Requirements    int      `json:"requirements"`
+	Warnings        []string `json:"warnings,omitempty"`
+	Valid           bool     `json:"valid"`
+	Errors          []string `json:"errors,omitempty"`
+	Stored          bool     `json:"stored"`
+	Diff            string   `json:"diff,omitempty"`
+	YAML            string   `json:"yaml,omitempty"`
+}
+
+// csvControl is a control read from CSV rows
+type csvControl struct {
+	control gemara.Control
+	row     int
+	// requirementRows is the number of rows that carried an assessment requirement
+	requirementRows int
+}
+
+// handleExportControlsCSV flattens the controls and assessment requirements of a catalog into CSV
+func (g *GemaraAuthoringTools) handleExportControlsCSV(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	catalogID := request.GetString("catalog_id", "")
+	if catalogID == "" {
+		return mcp.NewToolResultError("catalog_id is required"), nil
+	}
+	catalog := g.loadLayer2Catalog(catalogID)
+	if catalog == nil {
+		return mcp.NewToolResultErrorf("Catalog with ID '%s' not found. Use list_layer2_controls to see available catalogs.", catalogID), nil
+	}
+
+	output, err := controlsToCSV(catalog)
+	if err != nil {
+		return mcp.NewToolResultErrorf("failed to write CSV: %v", err), nil
+	}
+	return mcp.NewToolResultText(output), nil
+}
+
+// handleExportMappingsCSV flattens the guideline and threat mappings of one or all catalogs into CSV
+func (g *GemaraAuthoringTools) handleExportMappingsCSV(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	catalogID := request.GetString("catalog_id", "")
+	referenceID := request.GetString("reference_id", "")
+
+	var catalogs []*gemara.Catalog
+	if catalogID != "" {
+		catalog := g.loadLayer2Catalog(catalogID)
+		if catalog == nil {
+			return mcp.NewToolResultErrorf("Catalog with ID '%s' not found. Use list_layer2_controls to see available catalogs.", catalogID), nil
+		}
+		catalogs = append(catalogs, catalog)
+	} else {
+		for _, entry := range g.getLayerEntries(consts.Layer2) {
+			if catalog := g.loadLayer2Catalog(entry.ID); catalog != nil {
+				catalogs = append(catalogs, catalog)
+			}
+		}
+	}
+
+	output, err := mappingsToCSV(catalogs, referenceID)
+	if err != nil {
+		return mcp.NewToolResultErrorf("failed to write CSV: %v", err), nil
+	}
+	return mcp.NewToolResultText(output), nil
+}
+
+// handleImportControlsCSV builds a new Layer 2 catalog or updates a stored one from CSV rows
+func (g *GemaraAuthoringTools) handleImportControlsCSV(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	content := request.GetString("csv_content", "")
+	catalogID := strings.TrimSpace(request.GetString("catalog_id", ""))
+	outputFormat := request.GetString("output_format", "markdown")
+	if content == "" {
+		return mcp.NewToolResultError("csv_content is required"), nil
+	}
+
+	authorType, err := parseActorType(request.GetString("author_type", "Human"))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	metadata := gemara.Metadata{
+		Id:          catalogID,
+		Version:     request.GetString("version", "0.1.0"),
+		Description: request.GetString("description", ""),
+		Author:      gemara.Actor{Id: "todo-author", Name: "TODO: author", Type: authorType},
+	}
+	if author := request.GetString("author", ""); author != "" {
+		metadata.Author.Id, metadata.Author.Name = g.sanitizeID(author), author
+	}
+
+	store := request.GetBool("store", true)
+	result, err := g.importControlsCSV(content, request.GetString("title", ""), metadata, request.GetBool("remove_missing", false), store)
+	if err != nil {
+		return mcp.NewToolResultErrorf("Failed to import CSV: %v", err), nil
+	}
+
+	output := result.toMarkdown()
+	if outputFormat == "json" {
+		if output, err = marshalOutput(result, "json"); err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal JSON: %v", err), nil
+		}
+	}
+	// A store that was requested and did not happen is a failure; the report says why
+	if store && !result.Stored {
+		return mcp.NewToolResultErrorf("Catalog '%s' was not stored.\n\n%s", result.CatalogID, output), nil
+	}
+	return mcp.NewToolResultText(output), nil
+}
+
+// importControlsCSV reads controls from CSV and applies them to the stored catalog with the ID in
+// metadata, or to a new catalog built from title and metadata when none is stored. The catalog is
+// validated with CUE and stored only when every row is valid.
+func (g *GemaraAuthoringTools) importControlsCSV(content, title string, metadata gemara.Metadata, removeMissing, store bool) (*controlsCSVImport, error) {
+	families, controls, rows, warnings, rowErrors, err := g.readControlsCSV(content)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &controlsCSVImport{CatalogID: metadata.Id, Rows: rows, Warnings: warnings, Errors: rowErrors}
+
+	derivedID := metadata.Id == ""
+	if derivedID {
+		metadata.Id = g.sanitizeID(title)
+	}
+
+	var original string
+	var catalog, before *gemara.Catalog
+	if metadata.Id != "" && g.loadLayer2Catalog(metadata.Id) != nil {
+		if derivedID {
+			return nil, fmt.Errorf("a catalog with ID '%s' is already stored; pass catalog_id to update it or choose another title", metadata.Id)
+		}
+		if original, err = g.storedCatalogYAML(metadata.Id); err != nil {
+			return nil, err
+		}
+		loaded, err := g.loadArtifactForDiff(consts.Layer2, "", original)
+		if err != nil {
+			return nil, err
+		}
+		catalog = loaded.(*gemara.Catalog)
+		loaded, _ = g.loadArtifactForDiff(consts.Layer2, "", original)
+		before = loaded.(*gemara.Catalog)
+		if title != "" {
+			catalog.Title = title
+		}
+	} else {
+		if title == "" {
+			return nil, fmt.Errorf("title is required to create a new catalog")
+		}
+		if metadata.Id == "" {
+			return nil, fmt.Errorf("could not derive an ID from title '%s'; provide catalog_id", title)
+		}
+		if metadata.Description == "" {
+			metadata.Description = fmt.Sprintf("Imported from CSV on %s.", time.Now().Format("2006-01-02"))
+		}
+		metadata.Date = gemara.Date(time.Now().Format("2006-01-02"))
+		catalog = &gemara.Catalog{Title: title, Metadata: metadata}
+		result.CatalogID = metadata.Id
+		result.Created = true
+	}
+	if len(rowErrors) > 0 {
+		return result, nil
+	}
+
+	result.apply(catalog, families, controls, removeMissing)
+	for _, control := range catalog.Controls {
+		result.Requirements += len(control.AssessmentRequirements)
+	}
+	result.Warnings = append(result.Warnings, unknownApplicability(catalog)...)
+
+	updated, err := marshalArtifactYAML(catalog)
+	if err != nil {
+		return nil, err
+	}
+	result.YAML = updated
+	if before != nil {
+		diff, err := diffArtifacts(before, catalog)
+		if err != nil {
+			return nil, err
+		}
+		result.Diff = diff.toMarkdown()
+		// Apply the changes to the stored file so its comments and layout survive the import
+		merged, err := storage.MergeArtifactYAML(consts.Layer2, []byte(original), []byte(updated))
+		if err != nil {
+			return nil, err
+		}
+		result.YAML = string(merged)
+	}
+
+	if !store {
+		if failure := g.cueValidationFailure(result.YAML, consts.Layer2); failure != "" {
+			result.Errors = append(result.Errors, failure)
+		} else {
+			result.Valid = true
+		}
+		return result, nil
+	}
+
+	storedID, err := g.StoreValidatedYAML(consts.Layer2, result.YAML)
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		return result, nil
+	}
+	g.refreshCachedArtifact(consts.Layer2, storedID)
+	result.Valid = true
+	result.Stored = true
+	return result, nil
+}
+
+// storedCatalogYAML returns the YAML of a stored catalog as written, or as marshalled from the cache without storage
+func (g *GemaraAuthoringTools) storedCatalogYAML(catalogID string) (string, error) {
+	if g.storage != nil {
+		return g.storedArtifactYAML(consts.Layer2, catalogID)
+	}
+	return marshalArtifactYAML(g.loadLayer2Catalog(catalogID))
+}
+
+// readControlsCSV parses CSV rows in the controlsCSVColumns layout into families and controls in
+// row order. Row problems are collected so that all of them can be reported at once.
+func (g *GemaraAuthoringTools) readControlsCSV(content string) (families []gemara.Family, controls []*csvControl, rows int, warnings, rowErrors []string, err error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, 0, nil, nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		name = strings.ReplaceAll(name, " ", "_")
+		if !slices.Contains(controlsCSVColumns, name) {
+			warnings = append(warnings, fmt.Sprintf("Column '%s' is not part of the layout and was ignored.", header[i]))
+			continue
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["control_id"]; !ok {
+		return nil, nil, 0, nil, nil, fmt.Errorf("CSV header has no control_id column; expected columns: %s", strings.Join(controlsCSVColumns, ", "))
+	}
+
+	familyIndex := make(map[string]int)
+	controlIndex := make(map[string]*csvControl)
+	for line := 2; ; line++ {
+		record, readErr := reader.Read()
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, nil, 0, nil, nil, fmt.Errorf("failed to read CSV: %w", readErr)
+		}
+		cell := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		rows++
+
+		controlID := cell("control_id")
+		if controlID == "" {
+			rowErrors = append(rowErrors, fmt.Sprintf("Row %d: control_id is empty.", line))
+			continue
+		}
+
+		familyID := cell("family_id")
+		if familyID == "" {
+			familyID = g.sanitizeID(cell("family_title"))
+		}
+		if familyID != "" {
+			index, ok := familyIndex[familyID]
+			if !ok {
+				index = len(families)
+				familyIndex[familyID] = index
+				families = append(families, gemara.Family{Id: familyID})
+			}
+			if families[index].Title == "" {
+				families[index].Title = cell("family_title")
+			}
+			if families[index].Description == "" {
+				families[index].Description = cell("family_description")
+			}
+		}
+
+		entry, ok := controlIndex[controlID]
+		if !ok {
+			entry = &csvControl{row: line, control: gemara.Control{Id: controlID, AssessmentRequirements: []gemara.AssessmentRequirement{}}}
+			controlIndex[controlID] = entry
+			controls = append(controls, entry)
+		}
+		for _, field := range []struct {
+			column string
+			value  string
+			target *string
+		}{
+			{"control_title", cell("control_title"), &entry.control.Title},
+			{"objective", cell("objective"), &entry.control.Objective},
+			{"family", familyID, &entry.control.Family},
+		} {
+			switch {
+			case field.value == "":
+			case *field.target == "":
+				*field.target = field.value
+			case *field.target != field.value:
+				warnings = append(warnings, fmt.Sprintf("Row %d: %s of %s differs from row %d; the first value was kept.", line, field.column, controlID, entry.row))
+			}
+		}
+
+		requirementID := cell("requirement_id")
+		requirementText := cell("requirement_text")
+		switch {
+		case requirementID == "" && requirementText == "" && cell("applicability") == "" && cell("recommendation") == "":
+			continue
+		case requirementID == "":
+			rowErrors = append(rowErrors, fmt.Sprintf("Row %d: requirement_id is empty but the row has requirement columns.", line))
+			continue
+		case requirementText == "":
+			rowErrors = append(rowErrors, fmt.Sprintf("Row %d: requirement_text of %s is empty.", line, requirementID))
+			continue
+		case containsRequirement(entry.control.AssessmentRequirements, requirementID):
+			rowErrors = append(rowErrors, fmt.Sprintf("Row %d: requirement %s is listed twice for %s.", line, requirementID, controlID))
+			continue
+		}
+		entry.requirementRows++
+		entry.control.AssessmentRequirements = append(entry.control.AssessmentRequirements, gemara.AssessmentRequirement{
+			Id:             requirementID,
+			Text:           requirementText,
+			Applicability:  splitCSVList(cell("applicability")),
+			Recommendation: cell("recommendation"),
+		})
+	}
+
+	for _, family := range families {
+		if family.Title == "" {
+			rowErrors = append(rowErrors, fmt.Sprintf("Family %s has no family_title.", family.Id))
+		}
+	}
+	return families, controls, rows, warnings, rowErrors, nil
+}
+
+// apply merges families and controls read from CSV into the catalog. Listed controls take the
+// CSV's non-empty fields and, when the CSV lists any of their requirements, its requirement set.
+// Mappings are kept. Controls missing from the CSV are removed only when removeMissing is set.
+func (r *controlsCSVImport) apply(catalog *gemara.Catalog, families []gemara.Family, controls []*csvControl, removeMissing bool) {
+	for _, family := range families {
+		found := false
+		for i := range catalog.Families {
+			if catalog.Families[i].Id != family.Id {
+				continue
+			}
+			found = true
+			if family.Title != "" {
+				catalog.Families[i].Title = family.Title
+			}
+			if family.Description != "" {
+				catalog.Families[i].Description = family.Description
+			}
+		}
+		if !found {
+			if family.Description == "" {
+				family.Description = family.Title
+			}
+			catalog.Families = append(catalog.Families, family)
+			r.FamiliesAdded++
+		}
+	}
+
+	listed := make(map[string]bool)
+	for _, entry := range controls {
+		listed[entry.control.Id] = true
+		index := -1
+		for i := range catalog.Controls {
+			if catalog.Controls[i].Id == entry.control.Id {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			catalog.Controls = append(catalog.Controls, entry.control)
+			r.ControlsAdded++
+			continue
+		}
+
+		existing := &catalog.Controls[index]
+		if entry.control.Title != "" {
+			existing.Title = entry.control.Title
+		}
+		if entry.control.Objective != "" {
+			existing.Objective = entry.control.Objective
+		}
+		if entry.control.Family != "" {
+			existing.Family = entry.control.Family
+		}
+		if entry.requirementRows > 0 {
+			existing.AssessmentRequirements = entry.control.AssessmentRequirements
+		}
+		r.ControlsUpdated++
+	}
+
+	if !removeMissing {
+		return
+	}
+	kept := catalog.Controls[:0]
+	for _, control := range catalog.Controls {
+		if listed[control.Id] {
+			kept = append(kept, control)
+			continue
+		}
+		r.ControlsRemoved++
+	}
+	catalog.Controls = kept
+}
+
+// unknownApplicability warns about requirement applicability values that are not categories of the catalog
+func unknownApplicability(catalog *gemara.Catalog) []string {
+	if len(catalog.Metadata.ApplicabilityCategories) == 0 {
+		return nil
+	}
+	known := make(map[string]bool)
+	for _, category := range catalog.Metadata.ApplicabilityCategories {
+		known[category.Id] = true
+	}
+	var unknown []string
+	for _, control := range catalog.Controls {
+		for _, requirement := range control.AssessmentRequirements {
+			for _, value := range requirement.Applicability {
+				if !known[value] {
+					unknown = append(unknown, value)
+				}
+			}
+		}
+	}
+	if unknown = uniqueStrings(unknown); len(unknown) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("Applicability values that are not catalog categories: %s.", strings.Join(unknown, ", "))}
+}
+
+// toMarkdown renders the import summary, with the changes to an updated catalog or the YAML of a preview
+func (r *controlsCSVImport) toMarkdown() string {
+	var result strings.Builder
+	action := "Update"
+	if r.Created {
+		action = "New Catalog"
+	}
+	result.WriteString(fmt.Sprintf("## CSV Import (%s): Layer 2 `%s`\n\n", action, r.CatalogID))
+	result.WriteString(fmt.Sprintf("- **Rows**: %d\n", r.Rows))
+	result.WriteString(fmt.Sprintf("- **Families Added**: %d\n", r.FamiliesAdded))
+	result.WriteString(fmt.Sprintf("- **Controls Added**: %d\n", r.ControlsAdded))
+	result.WriteString(fmt.Sprintf("- **Controls Updated**: %d\n", r.ControlsUpdated))
+	if r.ControlsRemoved > 0 {
+		result.WriteString(fmt.Sprintf("- **Controls Removed**: %d\n", r.ControlsRemoved))
+	}
+	result.WriteString(fmt.Sprintf("- **Assessment Requirements**: %d\n", r.Requirements))
+	if r.Valid {
+		result.WriteString("- **CUE Validation**: ✅ PASSED\n")
+	} else {
+		result.WriteString("- **CUE Validation**: ❌ FAILED\n")
+	}
+	if r.Stored {
+		result.WriteString("- **Stored**: ✅\n")
+	} else {
+		result.WriteString("- **Stored**: no\n")
+	}
+	result.WriteString("\n")
+
+	for _, err := range r.Errors {
+		result.WriteString(fmt.Sprintf("❌ %s\n", err))
+	}
+	if len(r.Errors) > 0 {
+		result.WriteString("\n")
+	}
+	if len(r.Warnings) > 0 {
+		result.WriteString("### Warnings\n\n")
+		for _, warning := range r.Warnings {
+			result.WriteString(fmt.Sprintf("- %s\n", warning))
+		}
+		result.WriteString("\n")
+	}
+	if r.Diff != "" {
+		result.WriteString(r.Diff)
+	}
+	if !r.Stored && r.YAML != "" {
+		result.WriteString(fmt.Sprintf("```yaml\n%s```\n", r.YAML))
+	}
+	return result.String()
+}
+
+// controlsToCSV writes the catalog in the controlsCSVColumns layout
+func controlsToCSV(catalog *gemara.Catalog) (string, error) {
+	families := make(map[string]gemara.Family)
+	for _, family := range catalog.Families {
+		families[family.Id] = family
+	}
+
+	var records [][]string
+	for _, control := range catalog.Controls {
+		family := families[control.Family]
+		prefix := []string{control.Family, family.Title, family.Description, control.Id, control.Title, control.Objective}
+		if len(control.AssessmentRequirements) == 0 {
+			records = append(records, append(prefix, "", "", "", ""))
+			continue
+		}
+		for _, requirement := range control.AssessmentRequirements {
+			record := append(append([]string{}, prefix...),
+				requirement.Id,
+				requirement.Text,
+				strings.Join(requirement.Applicability, csvListSeparator),
+				requirement.Recommendation,
+			)
+			records = append(records, record)
+		}
+	}
+	return writeCSV(controlsCSVColumns, records)
+}
+
+// mappingsToCSV writes the guideline and threat mapping entries of the catalogs in the
+// mappingsCSVColumns layout, optionally only those to one reference
+func mappingsToCSV(catalogs []*gemara.Catalog, referenceID string) (string, error) {
+	var records [][]string
+	for _, catalog := range catalogs {
+		for _, control := range catalog.Controls {
+			for _, mappings := range []struct {
+				kind    string
+				entries []gemara.MultiMapping
+			}{{"guideline", control.GuidelineMappings}, {"threat", control.ThreatMappings}} {
+				for _, mapping := range mappings.entries {
+					if referenceID != "" && mapping.ReferenceId != referenceID {
+						continue
+					}
+					for _, entry := range mapping.Entries {
+						strength := ""
+						if entry.Strength != 0 {
+							strength = strconv.FormatInt(entry.Strength, 10)
+						}
+						remarks := entry.Remarks
+						if remarks == "" {
+							remarks = mapping.Remarks
+						}
+						records = append(records, []string{
+							catalog.Metadata.Id,
+							control.Id,
+							control.Title,
+							mappings.kind,
+							mapping.ReferenceId,
+							entry.ReferenceId,
+							strength,
+							remarks,
+						})
+					}
+				}
+			}
+		}
+	}
+	return writeCSV(mappingsCSVColumns, records)
+}
+
+// writeCSV renders a header and records as CSV
+func writeCSV(header []string, records [][]string) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return "", err
+	}
+	if err := w.WriteAll(records); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// splitCSVList splits a cell of separated values, dropping empty values
+func splitCSVList(cell string) []string {
+	values := []string{}
+	for _, value := range strings.Split(cell, csvListSeparator) {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
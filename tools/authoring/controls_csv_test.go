@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControlsCSVRoundTrip(t *testing.T) {
+	g := &GemaraAuthoringTools{}
+	catalog := testExportCatalog()
+
+	output, err := controlsToCSV(catalog)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, strings.Join(controlsCSVColumns, ","), lines[0])
+	assert.Equal(t, "ac,Access Control,Control access.,AC-01,MFA,Require MFA.,AC-01.01,MFA is enforced.,prod;dev,Use hardware keys.", lines[1])
+
+	families, controls, rows, warnings, rowErrors, err := g.readControlsCSV(output)
+	require.NoError(t, err)
+	assert.Equal(t, 3, rows)
+	assert.Empty(t, warnings)
+	assert.Empty(t, rowErrors)
+	assert.Equal(t, catalog.Families, families)
+	require.Len(t, controls, 2)
+	for i, control := range catalog.Controls {
+		control.GuidelineMappings = nil
+		assert.Equal(t, control, controls[i].control)
+	}
+}
+
+func TestReadControlsCSV(t *testing.T) {
+	g := &GemaraAuthoringTools{}
+	content := "\ufeffControl ID,Control Title,Family Title,Requirement ID,Requirement Text,Owner\n" +
+		"C-1,First,Data Protection,,,alice\n" +
+		"C-1,Renamed,Data Protection,C-1.1,Encrypt data,alice\n" +
+		",,,,,\n" +
+		"C-2,Second,Data Protection,C-2.1,,bob\n" +
+		",Orphan,,,,\n" +
+		"C-1,,,C-1.1,Duplicate,\n"
+
+	families, controls, rows, warnings, rowErrors, err := g.readControlsCSV(content)
+	require.NoError(t, err)
+	assert.Equal(t, 5, rows)
+	assert.Equal(t, []gemara.Family{{Id: "data-protection", Title: "Data Protection"}}, families)
+	require.Len(t, controls, 2)
+	assert.Equal(t, "First", controls[0].control.Title)
+	assert.Equal(t, "data-protection", controls[0].control.Family)
+	assert.Equal(t, []gemara.AssessmentRequirement{{Id: "C-1.1", Text: "Encrypt data", Applicability: []string{}}}, controls[0].control.AssessmentRequirements)
+	assert.Equal(t, []string{
+		"Column 'Owner' is not part of the layout and was ignored.",
+		"Row 3: control_title of C-1 differs from row 2; the first value was kept.",
+	}, warnings)
+	assert.Equal(t, []string{
+		"Row 5: requirement_text of C-2.1 is empty.",
+		"Row 6: control_id is empty.",
+		"Row 7: requirement C-1.1 is listed twice for C-1.",
+	}, rowErrors)
+
+	_, _, _, _, _, err = g.readControlsCSV("title,objective\nA,B\n")
+	assert.ErrorContains(t, err, "no control_id column")
+
+	result, err := g.importControlsCSV(content, "Broken", gemara.Metadata{}, false, true)
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.False(t, result.Stored)
+	assert.Len(t, result.Errors, 3)
+
+	// The handler reports a requested store that did not happen as a tool error, and a preview as text
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"csv_content": content, "title": "Broken"}
+	toolResult, err := g.handleImportControlsCSV(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, toolResult.IsError)
+	assert.Contains(t, toolResult.Content[0].(mcp.TextContent).Text, "Catalog 'broken' was not stored.")
+	assert.Contains(t, toolResult.Content[0].(mcp.TextContent).Text, "Row 6: control_id is empty.")
+
+	request.Params.Arguments = map[string]any{"csv_content": content, "title": "Broken", "store": false}
+	toolResult, err = g.handleImportControlsCSV(context.Background(), request)
+	require.NoError(t, err)
+	assert.False(t, toolResult.IsError)
+}
+
+func TestApplyControlsCSV(t *testing.T) {
+	catalog := testExportCatalog()
+	families := []gemara.Family{{Id: "ac", Title: "Access Control"}, {Id: "au", Title: "Audit"}}
+	controls := []*csvControl{
+		{control: gemara.Control{Id: "AC-01", Title: "Multi-factor authentication", AssessmentRequirements: []gemara.AssessmentRequirement{}}},
+		{control: gemara.Control{
+			Id: "AU-01", Title: "Logging", Objective: "Log events.", Family: "au",
+			AssessmentRequirements: []gemara.AssessmentRequirement{{Id: "AU-01.01", Text: "Events are logged.", Applicability: []string{}}},
+		}, requirementRows: 1},
+	}
+
+	result := &controlsCSVImport{}
+	result.apply(catalog, families, controls, true)
+	assert.Equal(t, 1, result.FamiliesAdded)
+	assert.Equal(t, 1, result.ControlsAdded)
+	assert.Equal(t, 1, result.ControlsUpdated)
+	assert.Equal(t, 1, result.ControlsRemoved)
+
+	require.Len(t, catalog.Families, 2)
+	assert.Equal(t, gemara.Family{Id: "au", Title: "Audit", Description: "Audit"}, catalog.Families[1])
+	require.Len(t, catalog.Controls, 2)
+	updated := catalog.Controls[0]
+	assert.Equal(t, "Multi-factor authentication", updated.Title)
+	assert.Equal(t, "Require MFA.", updated.Objective)
+	// Rows without requirements leave the stored requirements and mappings alone
+	assert.Len(t, updated.AssessmentRequirements, 2)
+	assert.Len(t, updated.GuidelineMappings, 1)
+	assert.Equal(t, "AU-01", catalog.Controls[1].Id)
+}
+
+func TestMappingsToCSV(t *testing.T) {
+	catalog := testExportCatalog()
+	catalog.Controls[1].ThreatMappings = []gemara.MultiMapping{
+		{ReferenceId: "THREATS", Remarks: "initial review", Entries: []gemara.MappingEntry{{ReferenceId: "TH-1"}}},
+	}
+
+	output, err := mappingsToCSV([]*gemara.Catalog{catalog}, "")
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join(mappingsCSVColumns, ",")+"\n"+
+		"export-catalog,AC-01,MFA,guideline,NIST,ia-2,8,\n"+
+		"export-catalog,AC-02,Sessions,threat,THREATS,TH-1,,initial review\n", output)
+
+	output, err = mappingsToCSV([]*gemara.Catalog{catalog}, "NIST")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "THREATS")
+}
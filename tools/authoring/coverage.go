@@ -0,0 +1,235 @@
+package authoring
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// coverageControl is a Layer 2 control mapped to a guideline or guideline part
+type coverageControl struct {
+	CatalogID string `json:"catalog_id"`
+	ControlID string `json:"control_id"`
+	Title     string `json:"title"`
+	Strength  int64  `json:"strength,omitempty"`
+}
+
+// coverageRow holds the coverage of a single guideline or guideline part
+type coverageRow struct {
+	GuidelineID       string            `json:"guideline_id"`
+	PartID            string            `json:"part_id,omitempty"`
+	Title             string            `json:"title"`
+	Controls          []coverageControl `json:"controls"`
+	MaxStrength       int64             `json:"max_strength"`
+	AggregateStrength int64             `json:"aggregate_strength"`
+}
+
+// coverageReport summarizes how well the guidelines of a Layer 1 document are covered by Layer 2 controls
+type coverageReport struct {
+	GuidanceID          string        `json:"guidance_id"`
+	GuidanceTitle       string        `json:"guidance_title"`
+	TotalGuidelines     int           `json:"total_guidelines"`
+	CoveredGuidelines   int           `json:"covered_guidelines"`
+	Rows                []coverageRow `json:"rows"`
+	UncoveredGuidelines []string      `json:"uncovered_guidelines"`
+}
+
+// handleCoverageReport reports which Layer 2 controls cover each guideline of a Layer 1 Guidance document
+func (g *GemaraAuthoringTools) handleCoverageReport(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	guidanceID := request.GetString("guidance_id", "")
+	outputFormat := request.GetString("output_format", "markdown")
+
+	if guidanceID == "" {
+		return mcp.NewToolResultError("guidance_id is required"), nil
+	}
+
+	report, err := g.buildCoverageReport(guidanceID)
+	if err != nil {
+		return mcp.NewToolResultErrorf("%v", err), nil
+	}
+
+	switch outputFormat {
+	case "json":
+		output, err := marshalOutput(report, "json")
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal JSON: %v", err), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	case "csv":
+		output, err := report.toCSV()
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to write CSV: %v", err), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	default:
+		return mcp.NewToolResultText(report.toMarkdown()), nil
+	}
+}
+
+// buildCoverageReport joins the guidelines of a Layer 1 document with the Layer 2 controls mapping to them
+func (g *GemaraAuthoringTools) buildCoverageReport(guidanceID string) (*coverageReport, error) {
+	guidance := g.loadLayer1Guidance(guidanceID)
+	if guidance == nil {
+		return nil, fmt.Errorf("Guidance with ID '%s' not found. Use list_layer1_guidance to see available guidance.", guidanceID)
+	}
+
+	// Index Layer 2 controls by the guideline (or part) ID they map to. Keys are lower-cased because
+	// mappings match entry IDs regardless of case.
+	controlsByEntry := make(map[string][]coverageControl)
+	for _, m := range g.collectReverseMappings(guidanceID, "") {
+		if m.Layer != consts.Layer2 {
+			continue
+		}
+		key := strings.ToLower(m.EntryID)
+		controlsByEntry[key] = append(controlsByEntry[key], coverageControl{
+			CatalogID: m.ArtifactID,
+			ControlID: m.ElementID,
+			Title:     m.ElementTitle,
+			Strength:  m.Strength,
+		})
+	}
+
+	report := &coverageReport{
+		GuidanceID:      guidanceID,
+		GuidanceTitle:   guidance.Title,
+		TotalGuidelines: len(guidance.Guidelines),
+	}
+
+	for _, guideline := range guidance.Guidelines {
+		covered := false
+
+		row := newCoverageRow(guideline.Id, "", guideline.Title, controlsByEntry[strings.ToLower(guideline.Id)])
+		report.Rows = append(report.Rows, row)
+		if len(row.Controls) > 0 {
+			covered = true
+		}
+
+		for _, part := range guideline.Statements {
+			partRow := newCoverageRow(guideline.Id, part.Id, part.Title, controlsByEntry[strings.ToLower(part.Id)])
+			report.Rows = append(report.Rows, partRow)
+			if len(partRow.Controls) > 0 {
+				covered = true
+			}
+		}
+
+		if covered {
+			report.CoveredGuidelines++
+		} else {
+			report.UncoveredGuidelines = append(report.UncoveredGuidelines, guideline.Id)
+		}
+	}
+
+	return report, nil
+}
+
+// newCoverageRow builds a coverage row and computes its strength figures
+func newCoverageRow(guidelineID, partID, title string, controls []coverageControl) coverageRow {
+	row := coverageRow{
+		GuidelineID: guidelineID,
+		PartID:      partID,
+		Title:       title,
+		Controls:    controls,
+	}
+	if row.Controls == nil {
+		row.Controls = []coverageControl{}
+	}
+	for _, c := range controls {
+		row.AggregateStrength += c.Strength
+		if c.Strength > row.MaxStrength {
+			row.MaxStrength = c.Strength
+		}
+	}
+	return row
+}
+
+// toMarkdown renders the coverage report as markdown
+func (r *coverageReport) toMarkdown() string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("# Coverage Report: %s\n\n", r.GuidanceTitle))
+	result.WriteString(fmt.Sprintf("- **Guidance ID**: `%s`\n", r.GuidanceID))
+	result.WriteString(fmt.Sprintf("- **Guidelines**: %d\n", r.TotalGuidelines))
+	result.WriteString(fmt.Sprintf("- **Covered Guidelines**: %d", r.CoveredGuidelines))
+	if r.TotalGuidelines > 0 {
+		result.WriteString(fmt.Sprintf(" (%.0f%%)", float64(r.CoveredGuidelines)*100/float64(r.TotalGuidelines)))
+	}
+	result.WriteString("\n")
+	result.WriteString(fmt.Sprintf("- **Uncovered Guidelines**: %d\n\n", len(r.UncoveredGuidelines)))
+
+	result.WriteString("## Guideline Coverage\n\n")
+	if len(r.Rows) == 0 {
+		result.WriteString("This guidance document has no guidelines.\n\n")
+	} else {
+		result.WriteString("| Guideline | Part | Title | Controls | Max Strength | Aggregate Strength |\n")
+		result.WriteString("|---|---|---|---|---|---|\n")
+		for _, row := range r.Rows {
+			result.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s | %d | %d |\n",
+				row.GuidelineID, formatOptionalID(row.PartID), row.Title, formatCoverageControls(row.Controls, ", "), row.MaxStrength, row.AggregateStrength))
+		}
+		result.WriteString("\n")
+	}
+
+	result.WriteString("## Guidelines Without Coverage\n\n")
+	if len(r.UncoveredGuidelines) == 0 {
+		result.WriteString("All guidelines are covered by at least one Layer 2 control.\n")
+	} else {
+		for _, id := range r.UncoveredGuidelines {
+			result.WriteString(fmt.Sprintf("- `%s`\n", id))
+		}
+	}
+
+	return result.String()
+}
+
+// toCSV renders the coverage report as CSV with one row per guideline or guideline part
+func (r *coverageReport) toCSV() (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := []string{"guidance_id", "guideline_id", "part_id", "title", "control_count", "controls", "max_strength", "aggregate_strength"}
+	if err := w.Write(header); err != nil {
+		return "", err
+	}
+	for _, row := range r.Rows {
+		record := []string{
+			r.GuidanceID,
+			row.GuidelineID,
+			row.PartID,
+			row.Title,
+			strconv.Itoa(len(row.Controls)),
+			formatCoverageControls(row.Controls, ";"),
+			strconv.FormatInt(row.MaxStrength, 10),
+			strconv.FormatInt(row.AggregateStrength, 10),
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// formatCoverageControls joins controls as catalog:control pairs
+func formatCoverageControls(controls []coverageControl, sep string) string {
+	refs := make([]string, len(controls))
+	for i, c := range controls {
+		refs[i] = fmt.Sprintf("%s:%s", c.CatalogID, c.ControlID)
+	}
+	return strings.Join(refs, sep)
+}
+
+// formatOptionalID renders an ID in backticks, or a dash if it is empty
+func formatOptionalID(id string) string {
+	if id == "" {
+		return "-"
+	}
+	return fmt.Sprintf("`%s`", id)
+}
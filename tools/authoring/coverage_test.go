@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCoverageReport(t *testing.T) {
+	g := testMappingTools()
+
+	report, err := g.buildCoverageReport("GUIDE")
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.TotalGuidelines)
+	assert.Equal(t, 1, report.CoveredGuidelines)
+	assert.Equal(t, []string{"G-2", "G-3"}, report.UncoveredGuidelines)
+
+	tests := []struct {
+		name      string
+		row       int
+		guideline string
+		part      string
+		controls  []coverageControl
+		max       int64
+		aggregate int64
+	}{
+		{
+			name:      "guideline mapped in a different case by controls with the same ID",
+			row:       0,
+			guideline: "G-1",
+			controls: []coverageControl{
+				{CatalogID: "CAT-B", ControlID: "CTL-1", Title: "Logging", Strength: 2},
+				{CatalogID: "CAT-A", ControlID: "CTL-1", Title: "Monitoring", Strength: 4},
+			},
+			max:       4,
+			aggregate: 6,
+		},
+		{
+			name:      "part mapped in a different case",
+			row:       1,
+			guideline: "G-1",
+			part:      "G-1.a",
+			controls:  []coverageControl{{CatalogID: "CAT-A", ControlID: "CTL-1", Title: "Monitoring", Strength: 7}},
+			max:       7,
+			aggregate: 7,
+		},
+		{
+			name:      "uncovered guideline",
+			row:       2,
+			guideline: "G-2",
+			controls:  []coverageControl{},
+		},
+	}
+
+	require.Len(t, report.Rows, 4)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := report.Rows[tt.row]
+			assert.Equal(t, tt.guideline, row.GuidelineID)
+			assert.Equal(t, tt.part, row.PartID)
+			assert.Equal(t, tt.controls, row.Controls)
+			assert.Equal(t, tt.max, row.MaxStrength)
+			assert.Equal(t, tt.aggregate, row.AggregateStrength)
+		})
+	}
+
+	_, err = g.buildCoverageReport("MISSING")
+	assert.ErrorContains(t, err, "Guidance with ID 'MISSING' not found")
+}
@@ -0,0 +1,79 @@
+package authoring
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/complytime/gemara-mcp-server/storage"
+	"github.com/goccy/go-yaml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// handleStoreLayer1CUE stores a Layer 1 Guidance document authored in CUE
+func (g *GemaraAuthoringTools) handleStoreLayer1CUE(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return g.storeCUE(ctx, request, 1, g.handleStoreLayer1YAML)
+}
+
+// handleStoreLayer2CUE stores a Layer 2 Catalog authored in CUE
+func (g *GemaraAuthoringTools) handleStoreLayer2CUE(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return g.storeCUE(ctx, request, 2, g.handleStoreLayer2YAML)
+}
+
+// handleStoreLayer3CUE stores a Layer 3 Policy authored in CUE
+func (g *GemaraAuthoringTools) handleStoreLayer3CUE(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return g.storeCUE(ctx, request, 3, g.handleStoreLayer3YAML)
+}
+
+// storeCUE evaluates CUE source against the layer schema, stores the exported YAML with the layer's
+// store handler, and writes the CUE source next to the stored artifact when the storage keeps CUE sources
+func (g *GemaraAuthoringTools) storeCUE(ctx context.Context, request mcp.CallToolRequest, layer int, storeYAML server.ToolHandlerFunc) (*mcp.CallToolResult, error) {
+	cueContent := request.GetString("cue_content", "")
+	if cueContent == "" {
+		return mcp.NewToolResultError("cue_content is required"), nil
+	}
+	if g.storage == nil {
+		return mcp.NewToolResultError("storage not available"), nil
+	}
+
+	evaluation := g.infoTools.EvaluateCUE(cueContent, layer)
+	if !evaluation.Valid {
+		errorMsg := fmt.Sprintf("CUE evaluation failed:\n%s\n", evaluation.Error)
+		if len(evaluation.Errors) > 0 {
+			errorMsg += "Errors:\n"
+			for _, err := range evaluation.Errors {
+				errorMsg += fmt.Sprintf("  - %s\n", err)
+			}
+		}
+		return mcp.NewToolResultError(errorMsg), nil
+	}
+
+	storeRequest := mcp.CallToolRequest{}
+	storeRequest.Params.Name = fmt.Sprintf("store_layer%d_yaml", layer)
+	storeRequest.Params.Arguments = map[string]any{"yaml_content": evaluation.YAML}
+	result, err := storeYAML(ctx, storeRequest)
+	if err != nil || result.IsError {
+		return result, err
+	}
+
+	var exported struct {
+		Metadata struct {
+			ID string `yaml:"id"`
+		} `yaml:"metadata"`
+	}
+	if err := yaml.Unmarshal([]byte(evaluation.YAML), &exported); err != nil {
+		return mcp.NewToolResultErrorf("Stored the exported YAML but could not read its metadata.id: %v", err), nil
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	sources, ok := g.storage.(storage.CUESourceStore)
+	if !ok {
+		text += "\nThe CUE source was not stored because this storage does not keep CUE sources.\n"
+		return mcp.NewToolResultText(text), nil
+	}
+	sourcePath, err := sources.StoreCUESource(layer, exported.Metadata.ID, cueContent)
+	if err != nil {
+		return mcp.NewToolResultErrorf("Stored the exported YAML but failed to store the CUE source: %v", err), nil
+	}
+	text += fmt.Sprintf("\nCUE source stored at %s. Edits made with other tools change the exported artifact, not the CUE source.\n", sourcePath)
+	return mcp.NewToolResultText(text), nil
+}
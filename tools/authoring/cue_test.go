@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/complytime/gemara-mcp-server/storage"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cueCatalog = `package catalog
+
+title: "CUE Catalog"
+metadata: {
+	id:          "cue-catalog"
+	description: "A catalog authored in CUE"
+	author: {id: "test", name: "TEST", type: "Human"}
+}
+families: [{id: "DATA", title: "Data", description: "Data protection"}]
+controls: [{
+	id:        "DATA-1"
+	title:     "Control 1"
+	objective: "Objective 1"
+	family:    "DATA"
+	"assessment-requirements": [{id: "DATA-1.1", text: "Requirement 1", applicability: ["all"]}]
+}]
+`
+
+func TestStoreLayer2CUE(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewArtifactStorage(dir)
+	require.NoError(t, err)
+	g, err := NewGemaraAuthoringToolsWithInfo(store, localInfoTools(t))
+	require.NoError(t, err)
+
+	storeCUE := func() string {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = map[string]any{"cue_content": cueCatalog}
+		result, err := g.handleStoreLayer2CUE(context.Background(), request)
+		require.NoError(t, err)
+		text := result.Content[0].(mcp.TextContent).Text
+		require.False(t, result.IsError, text)
+		return text
+	}
+
+	sourcePath := filepath.Join(dir, "layer2", "cue-catalog.cue")
+	assert.Contains(t, storeCUE(), "CUE source stored at "+sourcePath)
+	source, err := os.ReadFile(sourcePath)
+	require.NoError(t, err)
+	assert.Equal(t, cueCatalog, string(source))
+	assert.FileExists(t, filepath.Join(dir, "layer2", "cue-catalog.yaml"))
+
+	// Storage that does not keep CUE sources still stores the exported artifact
+	require.NoError(t, os.Remove(sourcePath))
+	g.storage = plainStorage{store}
+	assert.Contains(t, storeCUE(), "The CUE source was not stored because this storage does not keep CUE sources.")
+	assert.NoFileExists(t, sourcePath)
+}
@@ -0,0 +1,469 @@
+package authoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/goccy/go-yaml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+)
+
+// Element kinds compared by the artifact diff
+const (
+	elementGuideline             = nodeGuideline
+	elementGuidelinePart         = nodeGuidelinePart
+	elementControl               = nodeControl
+	elementAssessmentRequirement = "assessment-requirement"
+	elementCatalogImport         = "catalog-import"
+	elementGuidanceImport        = "guidance-import"
+	elementConstraint            = "constraint"
+	elementModification          = "assessment-requirement-modification"
+)
+
+// Change types reported by the artifact diff
+const (
+	changeAdded    = "added"
+	changeRemoved  = "removed"
+	changeModified = "modified"
+)
+
+// fieldChange is a single field that differs between two versions of an element
+type fieldChange struct {
+	Path string `json:"path"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+// elementDiff is an element that was added, removed, or modified between two artifact versions
+type elementDiff struct {
+	ID     string        `json:"id"`
+	Kind   string        `json:"kind"`
+	Change string        `json:"change"`
+	Fields []fieldChange `json:"fields,omitempty"`
+}
+
+// artifactDiff is the semantic difference between two versions of a Gemara artifact
+type artifactDiff struct {
+	Layer    int           `json:"layer"`
+	LeftID   string        `json:"left_id"`
+	RightID  string        `json:"right_id"`
+	Document []fieldChange `json:"document_changes"`
+	Elements []elementDiff `json:"elements"`
+}
+
+// diffElement is an identifiable element of an artifact, with nested elements stripped from its value
+type diffElement struct {
+	ID    string
+	Kind  string
+	Value interface{}
+}
+
+// handleDiffArtifacts compares two versions of an artifact by element identity
+func (g *GemaraAuthoringTools) handleDiffArtifacts(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	layer := request.GetInt("layer", 0)
+	leftID := request.GetString("left_id", "")
+	leftYAML := request.GetString("left_yaml", "")
+	rightID := request.GetString("right_id", "")
+	rightYAML := request.GetString("right_yaml", "")
+	outputFormat := request.GetString("output_format", "markdown")
+
+	if layer < consts.Layer1 || layer > consts.Layer3 {
+		return mcp.NewToolResultErrorf("layer must be between %d and %d, got %d", consts.Layer1, consts.Layer3, layer), nil
+	}
+	if (leftID == "") == (leftYAML == "") {
+		return mcp.NewToolResultError("provide exactly one of left_id or left_yaml"), nil
+	}
+	if (rightID == "") == (rightYAML == "") {
+		return mcp.NewToolResultError("provide exactly one of right_id or right_yaml"), nil
+	}
+
+	left, err := g.loadArtifactForDiff(layer, leftID, leftYAML)
+	if err != nil {
+		return mcp.NewToolResultErrorf("left artifact: %v", err), nil
+	}
+	right, err := g.loadArtifactForDiff(layer, rightID, rightYAML)
+	if err != nil {
+		return mcp.NewToolResultErrorf("right artifact: %v", err), nil
+	}
+
+	diff, err := diffArtifacts(left, right)
+	if err != nil {
+		return mcp.NewToolResultErrorf("Failed to diff artifacts: %v", err), nil
+	}
+
+	if outputFormat == "json" {
+		output, err := marshalOutput(diff, "json")
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal JSON: %v", err), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}
+
+	return mcp.NewToolResultText(diff.toMarkdown()), nil
+}
+
+// loadArtifactForDiff returns a stored artifact by ID, or parses the given YAML into the typed model of the layer
+func (g *GemaraAuthoringTools) loadArtifactForDiff(layer int, artifactID, yamlContent string) (interface{}, error) {
+	if artifactID != "" {
+		var artifact interface{}
+		switch layer {
+		case consts.Layer1:
+			if guidance := g.loadLayer1Guidance(artifactID); guidance != nil {
+				artifact = guidance
+			}
+		case consts.Layer2:
+			if catalog := g.loadLayer2Catalog(artifactID); catalog != nil {
+				artifact = catalog
+			}
+		case consts.Layer3:
+			if policy := g.loadLayer3Policy(artifactID); policy != nil {
+				artifact = policy
+			}
+		}
+		if artifact == nil {
+			return nil, fmt.Errorf("Layer %d artifact with ID '%s' not found", layer, artifactID)
+		}
+		return artifact, nil
+	}
+
+	var artifact interface{}
+	switch layer {
+	case consts.Layer1:
+		artifact = &gemara.GuidanceDocument{}
+	case consts.Layer2:
+		artifact = &gemara.Catalog{}
+	case consts.Layer3:
+		artifact = &gemara.Policy{}
+	default:
+		return nil, fmt.Errorf("diff is not supported for layer %d", layer)
+	}
+	if err := yaml.Unmarshal([]byte(yamlContent), artifact); err != nil {
+		return nil, fmt.Errorf("failed to parse Layer %d YAML: %w", layer, err)
+	}
+	return artifact, nil
+}
+
+// diffArtifacts compares two artifacts of the same layer by element identity
+func diffArtifacts(left, right interface{}) (*artifactDiff, error) {
+	switch l := left.(type) {
+	case *gemara.GuidanceDocument:
+		r, ok := right.(*gemara.GuidanceDocument)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare a Guidance document with %T", right)
+		}
+		return diffGuidance(l, r)
+	case *gemara.Catalog:
+		r, ok := right.(*gemara.Catalog)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare a Catalog with %T", right)
+		}
+		return diffCatalogs(l, r)
+	case *gemara.Policy:
+		r, ok := right.(*gemara.Policy)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare a Policy with %T", right)
+		}
+		return diffPolicies(l, r)
+	default:
+		return nil, fmt.Errorf("unsupported artifact type %T", left)
+	}
+}
+
+// diffGuidance compares two Layer 1 Guidance documents by guideline and guideline part ID
+func diffGuidance(left, right *gemara.GuidanceDocument) (*artifactDiff, error) {
+	elements := func(doc *gemara.GuidanceDocument) []diffElement {
+		var result []diffElement
+		for _, guideline := range doc.Guidelines {
+			parts := guideline.Statements
+			guideline.Statements = nil
+			result = append(result, diffElement{ID: guideline.Id, Kind: elementGuideline, Value: guideline})
+			for _, part := range parts {
+				result = append(result, diffElement{ID: part.Id, Kind: elementGuidelinePart, Value: part})
+			}
+		}
+		return result
+	}
+	leftDoc, rightDoc := *left, *right
+	leftDoc.Guidelines, rightDoc.Guidelines = nil, nil
+
+	return buildArtifactDiff(consts.Layer1, left.Metadata.Id, right.Metadata.Id, leftDoc, rightDoc, elements(left), elements(right))
+}
+
+// diffCatalogs compares two Layer 2 Catalogs by control and assessment requirement ID
+func diffCatalogs(left, right *gemara.Catalog) (*artifactDiff, error) {
+	elements := func(catalog *gemara.Catalog) []diffElement {
+		var result []diffElement
+		for _, control := range catalog.Controls {
+			requirements := control.AssessmentRequirements
+			control.AssessmentRequirements = nil
+			result = append(result, diffElement{ID: control.Id, Kind: elementControl, Value: control})
+			for _, req := range requirements {
+				result = append(result, diffElement{ID: req.Id, Kind: elementAssessmentRequirement, Value: req})
+			}
+		}
+		return result
+	}
+	leftDoc, rightDoc := *left, *right
+	leftDoc.Controls, rightDoc.Controls = nil, nil
+
+	return buildArtifactDiff(consts.Layer2, left.Metadata.Id, right.Metadata.Id, leftDoc, rightDoc, elements(left), elements(right))
+}
+
+// diffPolicies compares two Layer 3 Policies by imported reference, constraint, and modification ID
+func diffPolicies(left, right *gemara.Policy) (*artifactDiff, error) {
+	elements := func(policy *gemara.Policy) []diffElement {
+		var result []diffElement
+		for _, imported := range policy.Imports.Catalogs {
+			constraints := imported.Constraints
+			modifications := imported.AssessmentRequirementModifications
+			imported.Constraints, imported.AssessmentRequirementModifications = nil, nil
+			result = append(result, diffElement{ID: imported.ReferenceId, Kind: elementCatalogImport, Value: imported})
+			for _, constraint := range constraints {
+				result = append(result, diffElement{ID: constraint.Id, Kind: elementConstraint, Value: constraint})
+			}
+			for _, modification := range modifications {
+				result = append(result, diffElement{ID: modification.Id, Kind: elementModification, Value: modification})
+			}
+		}
+		for _, imported := range policy.Imports.Guidance {
+			constraints := imported.Constraints
+			imported.Constraints = nil
+			result = append(result, diffElement{ID: imported.ReferenceId, Kind: elementGuidanceImport, Value: imported})
+			for _, constraint := range constraints {
+				result = append(result, diffElement{ID: constraint.Id, Kind: elementConstraint, Value: constraint})
+			}
+		}
+		return result
+	}
+	leftDoc, rightDoc := *left, *right
+	leftDoc.Imports.Catalogs, leftDoc.Imports.Guidance = nil, nil
+	rightDoc.Imports.Catalogs, rightDoc.Imports.Guidance = nil, nil
+
+	return buildArtifactDiff(consts.Layer3, left.Metadata.Id, right.Metadata.Id, leftDoc, rightDoc, elements(left), elements(right))
+}
+
+// buildArtifactDiff compares the document-level fields and the identified elements of two artifact versions.
+// Elements are matched by kind and ID; removed and modified elements keep the left order, added ones the right order.
+func buildArtifactDiff(layer int, leftID, rightID string, leftDoc, rightDoc interface{}, left, right []diffElement) (*artifactDiff, error) {
+	diff := &artifactDiff{Layer: layer, LeftID: leftID, RightID: rightID, Elements: []elementDiff{}}
+
+	documentChanges, err := compareFields(leftDoc, rightDoc)
+	if err != nil {
+		return nil, err
+	}
+	diff.Document = documentChanges
+
+	rightByKey := make(map[string]diffElement)
+	for _, e := range right {
+		rightByKey[e.Kind+"/"+e.ID] = e
+	}
+	leftKeys := make(map[string]bool)
+	for _, e := range left {
+		key := e.Kind + "/" + e.ID
+		leftKeys[key] = true
+		counterpart, ok := rightByKey[key]
+		if !ok {
+			diff.Elements = append(diff.Elements, elementDiff{ID: e.ID, Kind: e.Kind, Change: changeRemoved})
+			continue
+		}
+		fields, err := compareFields(e.Value, counterpart.Value)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) > 0 {
+			diff.Elements = append(diff.Elements, elementDiff{ID: e.ID, Kind: e.Kind, Change: changeModified, Fields: fields})
+		}
+	}
+	for _, e := range right {
+		if !leftKeys[e.Kind+"/"+e.ID] {
+			diff.Elements = append(diff.Elements, elementDiff{ID: e.ID, Kind: e.Kind, Change: changeAdded})
+		}
+	}
+
+	return diff, nil
+}
+
+// compareFields flattens two values into field paths and returns the paths whose values differ
+func compareFields(left, right interface{}) ([]fieldChange, error) {
+	leftFields, err := flattenFields(left)
+	if err != nil {
+		return nil, err
+	}
+	rightFields, err := flattenFields(right)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]bool)
+	for path := range leftFields {
+		paths[path] = true
+	}
+	for path := range rightFields {
+		paths[path] = true
+	}
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+
+	changes := []fieldChange{}
+	for _, path := range sorted {
+		if leftFields[path] != rightFields[path] {
+			changes = append(changes, fieldChange{Path: path, Old: leftFields[path], New: rightFields[path]})
+		}
+	}
+	return changes, nil
+}
+
+// flattenFields converts a value into a map of field paths to scalar values using its JSON field names.
+// List items with an id or reference-id are addressed by that ID so reordering is not reported as a change.
+func flattenFields(value interface{}) (map[string]string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode value for comparison: %w", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, fmt.Errorf("failed to decode value for comparison: %w", err)
+	}
+
+	fields := make(map[string]string)
+	flattenInto(fields, "", generic)
+	return fields, nil
+}
+
+// flattenInto adds the scalar leaves of a decoded JSON value to fields
+func flattenInto(fields map[string]string, path string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			flattenInto(fields, childPath, child)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return
+		}
+		scalars := make([]string, 0, len(v))
+		for i, item := range v {
+			switch child := item.(type) {
+			case map[string]interface{}:
+				flattenInto(fields, fmt.Sprintf("%s[%s]", path, listItemKey(child, i)), child)
+			case []interface{}:
+				flattenInto(fields, fmt.Sprintf("%s[%d]", path, i), child)
+			default:
+				scalars = append(scalars, fmt.Sprint(child))
+			}
+		}
+		if len(scalars) > 0 {
+			fields[path] = "[" + strings.Join(scalars, ", ") + "]"
+		}
+	case nil:
+		return
+	default:
+		if s := fmt.Sprint(v); s != "" {
+			fields[path] = s
+		}
+	}
+}
+
+// listItemKey returns the identity of a list item, falling back to its index
+func listItemKey(item map[string]interface{}, index int) string {
+	for _, key := range []string{"id", "reference-id"} {
+		if id, ok := item[key].(string); ok && id != "" {
+			return id
+		}
+	}
+	return fmt.Sprint(index)
+}
+
+// countChanges returns the number of added, removed, and modified elements
+func (d *artifactDiff) countChanges() (added, removed, modified int) {
+	for _, e := range d.Elements {
+		switch e.Change {
+		case changeAdded:
+			added++
+		case changeRemoved:
+			removed++
+		case changeModified:
+			modified++
+		}
+	}
+	return added, removed, modified
+}
+
+// toMarkdown renders the artifact diff as markdown
+func (d *artifactDiff) toMarkdown() string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("# Artifact Diff: Layer %d `%s` → `%s`\n\n", d.Layer, d.LeftID, d.RightID))
+
+	added, removed, modified := d.countChanges()
+	result.WriteString(fmt.Sprintf("- **Document Fields Changed**: %d\n", len(d.Document)))
+	result.WriteString(fmt.Sprintf("- **Added**: %d\n", added))
+	result.WriteString(fmt.Sprintf("- **Removed**: %d\n", removed))
+	result.WriteString(fmt.Sprintf("- **Modified**: %d\n\n", modified))
+
+	if len(d.Document) == 0 && len(d.Elements) == 0 {
+		result.WriteString("The artifacts are semantically identical.\n")
+		return result.String()
+	}
+
+	if len(d.Document) > 0 {
+		result.WriteString("## Document\n\n")
+		writeFieldChanges(&result, d.Document)
+		result.WriteString("\n")
+	}
+
+	for _, section := range []struct {
+		title  string
+		change string
+	}{{"Added", changeAdded}, {"Removed", changeRemoved}, {"Modified", changeModified}} {
+		var elements []elementDiff
+		for _, e := range d.Elements {
+			if e.Change == section.change {
+				elements = append(elements, e)
+			}
+		}
+		if len(elements) == 0 {
+			continue
+		}
+		result.WriteString(fmt.Sprintf("## %s\n\n", section.title))
+		for _, e := range elements {
+			result.WriteString(fmt.Sprintf("- `%s` (%s)\n", e.ID, e.Kind))
+			if len(e.Fields) > 0 {
+				var fields strings.Builder
+				writeFieldChanges(&fields, e.Fields)
+				for _, line := range strings.SplitAfter(strings.TrimSuffix(fields.String(), "\n"), "\n") {
+					result.WriteString("  " + line)
+				}
+				result.WriteString("\n")
+			}
+		}
+		result.WriteString("\n")
+	}
+
+	return result.String()
+}
+
+// writeFieldChanges renders field changes as a markdown list
+func writeFieldChanges(result *strings.Builder, changes []fieldChange) {
+	for _, c := range changes {
+		switch {
+		case c.Old == "":
+			result.WriteString(fmt.Sprintf("- `%s`: added %q\n", c.Path, c.New))
+		case c.New == "":
+			result.WriteString(fmt.Sprintf("- `%s`: removed %q\n", c.Path, c.Old))
+		default:
+			result.WriteString(fmt.Sprintf("- `%s`: %q → %q\n", c.Path, c.Old, c.New))
+		}
+	}
+}
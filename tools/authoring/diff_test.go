@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"testing"
+
+	"github.com/ossf/gemara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCatalog() *gemara.Catalog {
+	return &gemara.Catalog{
+		Title:    "Test Catalog",
+		Metadata: gemara.Metadata{Id: "test-catalog"},
+		Controls: []gemara.Control{
+			{
+				Id:    "CTL-1",
+				Title: "First Control",
+				AssessmentRequirements: []gemara.AssessmentRequirement{
+					{Id: "CTL-1.1", Text: "Requirement one", Applicability: []string{"tlp-green"}},
+					{Id: "CTL-1.2", Text: "Requirement two", Applicability: []string{"tlp-amber"}},
+				},
+			},
+			{Id: "CTL-2", Title: "Second Control"},
+		},
+	}
+}
+
+func TestDiffCatalogs(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(c *gemara.Catalog)
+		document []fieldChange
+		elements []elementDiff
+	}{
+		{
+			name:     "identical catalogs",
+			modify:   func(c *gemara.Catalog) {},
+			elements: []elementDiff{},
+		},
+		{
+			name: "reordered controls are not a change",
+			modify: func(c *gemara.Catalog) {
+				c.Controls[0], c.Controls[1] = c.Controls[1], c.Controls[0]
+			},
+			elements: []elementDiff{},
+		},
+		{
+			name:     "document title changed",
+			modify:   func(c *gemara.Catalog) { c.Title = "Renamed Catalog" },
+			document: []fieldChange{{Path: "title", Old: "Test Catalog", New: "Renamed Catalog"}},
+			elements: []elementDiff{},
+		},
+		{
+			name: "control removed with its requirements",
+			modify: func(c *gemara.Catalog) {
+				c.Controls = c.Controls[1:]
+			},
+			elements: []elementDiff{
+				{ID: "CTL-1", Kind: elementControl, Change: changeRemoved},
+				{ID: "CTL-1.1", Kind: elementAssessmentRequirement, Change: changeRemoved},
+				{ID: "CTL-1.2", Kind: elementAssessmentRequirement, Change: changeRemoved},
+			},
+		},
+		{
+			name: "requirement modified only reports the requirement",
+			modify: func(c *gemara.Catalog) {
+				c.Controls[0].AssessmentRequirements[1].Applicability = []string{"tlp-amber", "tlp-red"}
+			},
+			elements: []elementDiff{
+				{ID: "CTL-1.2", Kind: elementAssessmentRequirement, Change: changeModified, Fields: []fieldChange{
+					{Path: "applicability", Old: "[tlp-amber]", New: "[tlp-amber, tlp-red]"},
+				}},
+			},
+		},
+		{
+			name: "control added",
+			modify: func(c *gemara.Catalog) {
+				c.Controls = append(c.Controls, gemara.Control{Id: "CTL-3", Title: "Third Control"})
+			},
+			elements: []elementDiff{
+				{ID: "CTL-3", Kind: elementControl, Change: changeAdded},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			right := testCatalog()
+			tt.modify(right)
+
+			diff, err := diffCatalogs(testCatalog(), right)
+			require.NoError(t, err)
+
+			document := tt.document
+			if document == nil {
+				document = []fieldChange{}
+			}
+			assert.Equal(t, document, diff.Document)
+			assert.Equal(t, tt.elements, diff.Elements)
+		})
+	}
+}
+
+func TestFlattenFields(t *testing.T) {
+	fields, err := flattenFields(gemara.Control{
+		Id:    "CTL-1",
+		Title: "First Control",
+		GuidelineMappings: []gemara.MultiMapping{
+			{ReferenceId: "NIST-800-53", Entries: []gemara.MappingEntry{{ReferenceId: "CA-7", Strength: 8}}},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "CTL-1", fields["id"])
+	assert.Equal(t, "First Control", fields["title"])
+	assert.Equal(t, "8", fields["guideline-mappings[NIST-800-53].entries[CA-7].strength"])
+	assert.NotContains(t, fields, "objective")
+}
@@ -0,0 +1,420 @@
+package authoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/complytime/gemara-mcp-server/internal/yamldoc"
+	"github.com/goccy/go-yaml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+)
+
+// handlePatchArtifact applies a JSON Patch (RFC 6902) to a stored artifact
+func (g *GemaraAuthoringTools) handlePatchArtifact(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	layer := request.GetInt("layer", 0)
+	artifactID := request.GetString("artifact_id", "")
+	if layer < consts.Layer1 || layer > consts.Layer3 {
+		return mcp.NewToolResultErrorf("layer must be between %d and %d, got %d", consts.Layer1, consts.Layer3, layer), nil
+	}
+	if artifactID == "" {
+		return mcp.NewToolResultError("artifact_id is required"), nil
+	}
+
+	patch, err := rawArgument(request, "patch")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	operations, err := yamldoc.ParsePatch(patch)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(operations) == 0 {
+		return mcp.NewToolResultError("patch must contain at least one operation"), nil
+	}
+
+	return g.editArtifact(layer, artifactID, fmt.Sprintf("%d patch operation(s)", len(operations)), func(doc *yamldoc.Document) error {
+		return doc.ApplyPatch(operations)
+	})
+}
+
+// handleAddControl adds a control to a stored catalog, after the last control of the same family
+func (g *GemaraAuthoringTools) handleAddControl(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	catalogID := request.GetString("catalog_id", "")
+	content := request.GetString("control", "")
+	if catalogID == "" || content == "" {
+		return mcp.NewToolResultError("catalog_id and control are required"), nil
+	}
+
+	control := gemara.Control{}
+	if err := yaml.UnmarshalWithOptions([]byte(content), &control, yaml.Strict()); err != nil {
+		return mcp.NewToolResultErrorf("invalid control: %v", err), nil
+	}
+	if control.Id == "" {
+		return mcp.NewToolResultError("control must have an id"), nil
+	}
+	value, err := orderedValue([]byte(content))
+	if err != nil {
+		return mcp.NewToolResultErrorf("invalid control: %v", err), nil
+	}
+
+	return g.editArtifact(consts.Layer2, catalogID, fmt.Sprintf("add_control %s", control.Id), func(doc *yamldoc.Document) error {
+		controls, err := decodeList(doc, "/controls")
+		if err != nil {
+			return err
+		}
+		if indexOf(controls, "id", control.Id) >= 0 {
+			return fmt.Errorf("control '%s' already exists", control.Id)
+		}
+		position := "-"
+		for i, existing := range controls {
+			if existing["family"] == control.Family {
+				position = fmt.Sprint(i + 1)
+			}
+		}
+		if !doc.Has("/controls") {
+			return doc.Add("/controls", []interface{}{value})
+		}
+		return doc.Add("/controls/"+position, value)
+	})
+}
+
+// handleUpdateControl sets or removes individual fields of a control in a stored catalog
+func (g *GemaraAuthoringTools) handleUpdateControl(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	catalogID := request.GetString("catalog_id", "")
+	controlID := request.GetString("control_id", "")
+	content := request.GetString("fields", "")
+	if catalogID == "" || controlID == "" || content == "" {
+		return mcp.NewToolResultError("catalog_id, control_id, and fields are required"), nil
+	}
+
+	value, err := orderedValue([]byte(content))
+	if err != nil {
+		return mcp.NewToolResultErrorf("invalid fields: %v", err), nil
+	}
+	fields, ok := value.(yaml.MapSlice)
+	if !ok || len(fields) == 0 {
+		return mcp.NewToolResultError("fields must be a non-empty mapping of control fields"), nil
+	}
+
+	return g.editArtifact(consts.Layer2, catalogID, fmt.Sprintf("update_control %s", controlID), func(doc *yamldoc.Document) error {
+		controls, err := decodeList(doc, "/controls")
+		if err != nil {
+			return err
+		}
+		index := indexOf(controls, "id", controlID)
+		if index < 0 {
+			return fmt.Errorf("control '%s' not found", controlID)
+		}
+		for _, field := range fields {
+			key := fmt.Sprint(field.Key)
+			if key == "id" && field.Value != controlID && indexOf(controls, "id", fmt.Sprint(field.Value)) >= 0 {
+				return fmt.Errorf("control '%v' already exists", field.Value)
+			}
+			pointer := fmt.Sprintf("/controls/%d/%s", index, escapePointer(key))
+			if field.Value == nil {
+				if doc.Has(pointer) {
+					if err := doc.Remove(pointer); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			if err := doc.Add(pointer, field.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// handleRemoveControl removes a control from a stored catalog
+func (g *GemaraAuthoringTools) handleRemoveControl(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	catalogID := request.GetString("catalog_id", "")
+	controlID := request.GetString("control_id", "")
+	if catalogID == "" || controlID == "" {
+		return mcp.NewToolResultError("catalog_id and control_id are required"), nil
+	}
+
+	return g.editArtifact(consts.Layer2, catalogID, fmt.Sprintf("remove_control %s", controlID), func(doc *yamldoc.Document) error {
+		controls, err := decodeList(doc, "/controls")
+		if err != nil {
+			return err
+		}
+		index := indexOf(controls, "id", controlID)
+		if index < 0 {
+			return fmt.Errorf("control '%s' not found", controlID)
+		}
+		return doc.Remove(fmt.Sprintf("/controls/%d", index))
+	})
+}
+
+// handleAddGuidelineMapping maps a control in a stored catalog to a guideline
+func (g *GemaraAuthoringTools) handleAddGuidelineMapping(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	catalogID := request.GetString("catalog_id", "")
+	controlID := request.GetString("control_id", "")
+	referenceID := request.GetString("reference_id", "")
+	entryID := request.GetString("entry_id", "")
+	strength := request.GetInt("strength", 0)
+	remarks := request.GetString("remarks", "")
+	if catalogID == "" || controlID == "" || referenceID == "" || entryID == "" {
+		return mcp.NewToolResultError("catalog_id, control_id, reference_id, and entry_id are required"), nil
+	}
+	// Strength is optional, but a strength that is given must be in range
+	if _, hasStrength := request.GetArguments()["strength"]; hasStrength && (strength < 1 || strength > 10) {
+		return mcp.NewToolResultErrorf("strength must be between 1 and 10, got %d", strength), nil
+	}
+
+	entry := yaml.MapSlice{{Key: "reference-id", Value: entryID}}
+	if strength > 0 {
+		entry = append(entry, yaml.MapItem{Key: "strength", Value: strength})
+	}
+	if remarks != "" {
+		entry = append(entry, yaml.MapItem{Key: "remarks", Value: remarks})
+	}
+
+	var note string
+	result, err := g.editArtifact(consts.Layer2, catalogID, fmt.Sprintf("add_guideline_mapping %s → %s/%s", controlID, referenceID, entryID), func(doc *yamldoc.Document) error {
+		controls, err := decodeList(doc, "/controls")
+		if err != nil {
+			return err
+		}
+		index := indexOf(controls, "id", controlID)
+		if index < 0 {
+			return fmt.Errorf("control '%s' not found", controlID)
+		}
+
+		references, _ := decodeList(doc, "/metadata/mapping-references")
+		if indexOf(references, "id", referenceID) < 0 {
+			note = fmt.Sprintf("Note: '%s' is not declared in metadata.mapping-references of catalog '%s'.\n", referenceID, catalogID)
+		}
+
+		mappingsPointer := fmt.Sprintf("/controls/%d/guideline-mappings", index)
+		mappings, err := decodeList(doc, mappingsPointer)
+		if err != nil {
+			return err
+		}
+		mappingIndex := indexOf(mappings, "reference-id", referenceID)
+		if mappingIndex < 0 {
+			mapping := yaml.MapSlice{{Key: "reference-id", Value: referenceID}, {Key: "entries", Value: []interface{}{entry}}}
+			if !doc.Has(mappingsPointer) {
+				return doc.Add(mappingsPointer, []interface{}{mapping})
+			}
+			return doc.Add(mappingsPointer+"/-", mapping)
+		}
+
+		entriesPointer := fmt.Sprintf("%s/%d/entries", mappingsPointer, mappingIndex)
+		entries, err := decodeList(doc, entriesPointer)
+		if err != nil {
+			return err
+		}
+		if indexOf(entries, "reference-id", entryID) >= 0 {
+			return fmt.Errorf("control '%s' already maps to %s/%s", controlID, referenceID, entryID)
+		}
+		if !doc.Has(entriesPointer) {
+			return doc.Add(entriesPointer, []interface{}{entry})
+		}
+		return doc.Add(entriesPointer+"/-", entry)
+	})
+	if err == nil && note != "" && !result.IsError {
+		result.Content = append(result.Content, mcp.NewTextContent(note))
+	}
+	return result, err
+}
+
+// handleAddPolicyModification adds an assessment requirement modification to a catalog import of a stored policy
+func (g *GemaraAuthoringTools) handleAddPolicyModification(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	policyID := request.GetString("policy_id", "")
+	catalogID := request.GetString("catalog_id", "")
+	targetID := request.GetString("target_id", "")
+	modificationType := request.GetString("modification_type", "")
+	rationale := request.GetString("rationale", "")
+	modificationID := request.GetString("modification_id", "")
+	if policyID == "" || catalogID == "" || targetID == "" || modificationType == "" || rationale == "" {
+		return mcp.NewToolResultError("policy_id, catalog_id, target_id, modification_type, and rationale are required"), nil
+	}
+	switch modificationType {
+	case "add", "modify", "remove", "replace", "override":
+	default:
+		return mcp.NewToolResultErrorf("invalid modification_type '%s': must be add, modify, remove, replace, or override", modificationType), nil
+	}
+
+	return g.editArtifact(consts.Layer3, policyID, fmt.Sprintf("add_policy_modification %s on %s", modificationType, targetID), func(doc *yamldoc.Document) error {
+		imports, err := decodeList(doc, "/imports/catalogs")
+		if err != nil {
+			return err
+		}
+		index := indexOf(imports, "reference-id", catalogID)
+		if index < 0 {
+			return fmt.Errorf("policy '%s' does not import catalog '%s'", policyID, catalogID)
+		}
+
+		modificationsPointer := fmt.Sprintf("/imports/catalogs/%d/assessment-requirement-modifications", index)
+		modifications, err := decodeList(doc, modificationsPointer)
+		if err != nil {
+			return err
+		}
+		if modificationID == "" {
+			used := idAllocator{}
+			for _, existing := range modifications {
+				used.reserve(fmt.Sprint(existing["id"]))
+			}
+			modificationID = used.next(fmt.Sprintf("%s-%s-%s", policyID, modificationType, targetID))
+		} else if indexOf(modifications, "id", modificationID) >= 0 {
+			return fmt.Errorf("modification '%s' already exists", modificationID)
+		}
+
+		modification := yaml.MapSlice{
+			{Key: "id", Value: modificationID},
+			{Key: "target-id", Value: targetID},
+			{Key: "modification-type", Value: modificationType},
+			{Key: "modification-rationale", Value: rationale},
+		}
+		if text := request.GetString("text", ""); text != "" {
+			modification = append(modification, yaml.MapItem{Key: "text", Value: text})
+		}
+		if applicability := g.extractStringArray(request, "applicability"); len(applicability) > 0 {
+			modification = append(modification, yaml.MapItem{Key: "applicability", Value: applicability})
+		}
+		if recommendation := request.GetString("recommendation", ""); recommendation != "" {
+			modification = append(modification, yaml.MapItem{Key: "recommendation", Value: recommendation})
+		}
+
+		if !doc.Has(modificationsPointer) {
+			return doc.Add(modificationsPointer, []interface{}{modification})
+		}
+		return doc.Add(modificationsPointer+"/-", modification)
+	})
+}
+
+// editArtifact applies an edit to the stored YAML of an artifact, validates the result, and stores it.
+// Nothing is written unless the edited artifact passes CUE validation. The result reports the semantic diff.
+func (g *GemaraAuthoringTools) editArtifact(layer int, artifactID, action string, edit func(doc *yamldoc.Document) error) (*mcp.CallToolResult, error) {
+	g.editMu.Lock()
+	defer g.editMu.Unlock()
+
+	if g.storage == nil {
+		return mcp.NewToolResultError("storage not available"), nil
+	}
+	original, err := g.storedArtifactYAML(layer, artifactID)
+	if err != nil {
+		return mcp.NewToolResultErrorf("Layer %d artifact with ID '%s' not found: %v", layer, artifactID, err), nil
+	}
+
+	doc, err := yamldoc.Parse([]byte(original))
+	if err != nil {
+		return mcp.NewToolResultErrorf("Failed to parse stored artifact '%s': %v", artifactID, err), nil
+	}
+	if err := edit(doc); err != nil {
+		return mcp.NewToolResultErrorf("Failed to apply %s: %v", action, err), nil
+	}
+
+	updated := doc.String()
+	if updated == original {
+		return mcp.NewToolResultText(fmt.Sprintf("No changes: applying %s left artifact '%s' unchanged.\n", action, artifactID)), nil
+	}
+	var updatedID string
+	if err := doc.Decode("/metadata/id", &updatedID); err != nil || updatedID != artifactID {
+		return mcp.NewToolResultErrorf("Edits must not change metadata.id of '%s'", artifactID), nil
+	}
+
+	before, err := g.loadArtifactForDiff(layer, "", original)
+	if err != nil {
+		return mcp.NewToolResultErrorf("Failed to diff edited artifact: %v", err), nil
+	}
+	after, err := g.loadArtifactForDiff(layer, "", updated)
+	if err != nil {
+		return mcp.NewToolResultErrorf("Failed to diff edited artifact: %v", err), nil
+	}
+	diff, err := diffArtifacts(before, after)
+	if err != nil {
+		return mcp.NewToolResultErrorf("Failed to diff edited artifact: %v", err), nil
+	}
+
+	warning := g.impactWarning(layer, updated)
+	if _, err := g.StoreValidatedYAML(layer, updated); err != nil {
+		return mcp.NewToolResultErrorf("Edited artifact was not stored: %v", err), nil
+	}
+	g.refreshCachedArtifact(layer, artifactID)
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Successfully applied %s to Layer %d artifact '%s':\n", action, layer, artifactID))
+	result.WriteString("- CUE Validation: ✅ PASSED\n")
+	result.WriteString("- Comments and key order of the stored YAML were preserved\n\n")
+	result.WriteString(diff.toMarkdown())
+	result.WriteString(warning)
+
+	return mcp.NewToolResultText(result.String()), nil
+}
+
+// refreshCachedArtifact reloads a stored artifact into the in-memory cache
+func (g *GemaraAuthoringTools) refreshCachedArtifact(layer int, artifactID string) {
+	retrieved, err := g.storage.Retrieve(layer, artifactID)
+	if err != nil {
+		return
+	}
+	switch artifact := retrieved.(type) {
+	case *gemara.GuidanceDocument:
+		g.layer1Guidance[artifactID] = artifact
+	case *gemara.Catalog:
+		g.layer2Catalogs[artifactID] = artifact
+	case *gemara.Policy:
+		g.layer3Policies[artifactID] = artifact
+	}
+}
+
+// rawArgument returns a tool argument as bytes, accepting either a JSON/YAML string or structured JSON
+func rawArgument(request mcp.CallToolRequest, key string) ([]byte, error) {
+	args, _ := request.GetRawArguments().(map[string]interface{})
+	value, ok := args[key]
+	if !ok || value == nil {
+		return nil, fmt.Errorf("%s is required", key)
+	}
+	if text, ok := value.(string); ok {
+		return []byte(text), nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// orderedValue decodes YAML or JSON into generic data, keeping the key order of mappings
+func orderedValue(data []byte) (interface{}, error) {
+	var value interface{}
+	if err := yaml.UnmarshalWithOptions(data, &value, yaml.UseOrderedMap()); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// decodeList decodes the list of mappings at pointer, returning nil if it does not exist
+func decodeList(doc *yamldoc.Document, pointer string) ([]map[string]interface{}, error) {
+	if !doc.Has(pointer) {
+		return nil, nil
+	}
+	var items []map[string]interface{}
+	if err := doc.Decode(pointer, &items); err != nil {
+		return nil, fmt.Errorf("%s is not a list of mappings: %w", pointer, err)
+	}
+	return items, nil
+}
+
+// indexOf returns the index of the first item whose key equals value, or -1
+func indexOf(items []map[string]interface{}, key, value string) int {
+	for i, item := range items {
+		if fmt.Sprint(item[key]) == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// escapePointer escapes a key for use as a JSON Pointer reference token
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"context"
+	"testing"
+
+	"github.com/complytime/gemara-mcp-server/storage"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validCatalogYAML is a Layer 2 catalog that is valid against the schemas in schematest
+const validCatalogYAML = `metadata:
+  id: edit-catalog
+  description: A catalog to edit
+  author:
+    id: test
+    name: TEST
+    type: Human
+  mapping-references:
+    - id: NIST-800-53
+      title: NIST SP 800-53
+      version: "5"
+title: Edit Catalog
+families:
+  - id: DATA
+    title: Data
+    description: Data protection
+controls:
+  - id: DATA-1
+    title: Test Control
+    objective: Test control objective
+    family: DATA
+    assessment-requirements:
+      - id: DATA-1.1
+        text: Test requirement
+        applicability:
+          - all
+`
+
+func TestAddGuidelineMappingStrength(t *testing.T) {
+	tests := []struct {
+		name      string
+		strength  any
+		wantError string
+		wantEntry string
+	}{
+		{name: "no strength", wantEntry: "- reference-id: CA-7\n"},
+		{name: "lowest strength", strength: 1, wantEntry: "strength: 1\n"},
+		{name: "highest strength", strength: 10, wantEntry: "strength: 10\n"},
+		{name: "zero strength", strength: 0, wantError: "strength must be between 1 and 10, got 0"},
+		{name: "negative strength", strength: -1, wantError: "strength must be between 1 and 10, got -1"},
+		{name: "strength above range", strength: 11, wantError: "strength must be between 1 and 10, got 11"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := storage.NewArtifactStorage(t.TempDir())
+			require.NoError(t, err)
+			_, err = store.StoreRawYAML(2, validCatalogYAML)
+			require.NoError(t, err)
+			g, err := NewGemaraAuthoringToolsWithInfo(store, localInfoTools(t))
+			require.NoError(t, err)
+
+			arguments := map[string]any{
+				"catalog_id":   "edit-catalog",
+				"control_id":   "DATA-1",
+				"reference_id": "NIST-800-53",
+				"entry_id":     "CA-7",
+			}
+			if tt.strength != nil {
+				arguments["strength"] = tt.strength
+			}
+			request := mcp.CallToolRequest{}
+			request.Params.Arguments = arguments
+			result, err := g.handleAddGuidelineMapping(context.Background(), request)
+			require.NoError(t, err)
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				assert.True(t, result.IsError)
+				assert.Equal(t, tt.wantError, text)
+				return
+			}
+			require.False(t, result.IsError, text)
+			stored, err := store.RetrieveRaw(2, "edit-catalog")
+			require.NoError(t, err)
+			assert.Contains(t, stored, tt.wantEntry)
+		})
+	}
+}
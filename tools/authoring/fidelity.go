@@ -0,0 +1,286 @@
+package authoring
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/complytime/gemara-mcp-server/storage"
+	"github.com/goccy/go-yaml"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Problems reported by the fidelity check
+const (
+	fidelityDropped = "dropped"
+	fidelityChanged = "changed"
+)
+
+// fidelityIssue is a field of the raw YAML that does not survive decoding into the gemara types.
+// Issues are grouped by path pattern, with sequence indices shown as '*'.
+type fidelityIssue struct {
+	Path        string `json:"path"`
+	Problem     string `json:"problem"`
+	Occurrences int    `json:"occurrences"`
+	Example     string `json:"example"`
+	Raw         string `json:"raw,omitempty"`
+	Typed       string `json:"typed,omitempty"`
+}
+
+// fidelityReport lists the data lost when an artifact is read through the gemara types
+type fidelityReport struct {
+	Layer      int             `json:"layer"`
+	ArtifactID string          `json:"artifact_id,omitempty"`
+	Issues     []fidelityIssue `json:"issues"`
+}
+
+// handleCheckArtifactFidelity reports fields of stored or proposed artifacts that the gemara types drop or change
+func (g *GemaraAuthoringTools) handleCheckArtifactFidelity(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	layer := request.GetInt("layer", 0)
+	artifactID := request.GetString("artifact_id", "")
+	yamlContent := request.GetString("yaml_content", "")
+	outputFormat := request.GetString("output_format", "markdown")
+
+	if layer < consts.Layer1 || layer > consts.Layer3 {
+		return mcp.NewToolResultErrorf("layer must be between %d and %d, got %d", consts.Layer1, consts.Layer3, layer), nil
+	}
+	if artifactID != "" && yamlContent != "" {
+		return mcp.NewToolResultError("provide at most one of artifact_id or yaml_content"), nil
+	}
+
+	var reports []*fidelityReport
+	switch {
+	case yamlContent != "":
+		report, err := g.checkFidelity(layer, yamlContent)
+		if err != nil {
+			return mcp.NewToolResultErrorf("Failed to check fidelity: %v", err), nil
+		}
+		reports = append(reports, report)
+	case g.storage == nil:
+		return mcp.NewToolResultError("storage not available"), nil
+	default:
+		ids := []string{artifactID}
+		if artifactID == "" {
+			ids = nil
+			for _, entry := range g.storage.List(layer) {
+				ids = append(ids, entry.ID)
+			}
+			sort.Strings(ids)
+		}
+		for _, id := range ids {
+			raw, err := g.retrieveRaw(layer, id)
+			if err != nil {
+				return mcp.NewToolResultErrorf("Layer %d artifact with ID '%s' not found", layer, id), nil
+			}
+			report, err := g.checkFidelity(layer, raw)
+			if err != nil {
+				return mcp.NewToolResultErrorf("Failed to check fidelity of %s: %v", id, err), nil
+			}
+			report.ArtifactID = id
+			reports = append(reports, report)
+		}
+	}
+
+	if outputFormat == "json" {
+		output, err := marshalOutput(reports, "json")
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal JSON: %v", err), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}
+
+	if len(reports) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No Layer %d artifacts are stored.\n", layer)), nil
+	}
+	var result strings.Builder
+	for _, report := range reports {
+		result.WriteString(report.toMarkdown())
+	}
+	return mcp.NewToolResultText(result.String()), nil
+}
+
+// checkFidelity compares the YAML tree of an artifact with the same artifact decoded into its gemara
+// type and marshalled again, reporting fields that are dropped or whose values change
+func (g *GemaraAuthoringTools) checkFidelity(layer int, yamlContent string) (*fidelityReport, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal([]byte(yamlContent), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	artifact, err := g.loadArtifactForDiff(layer, "", yamlContent)
+	if err != nil {
+		return nil, err
+	}
+	typedYAML, err := storage.MarshalArtifact(artifact)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal typed artifact: %w", err)
+	}
+	var typed interface{}
+	if err := yaml.Unmarshal(typedYAML, &typed); err != nil {
+		return nil, fmt.Errorf("failed to decode typed artifact: %w", err)
+	}
+
+	report := &fidelityReport{Layer: layer}
+	report.compare("", "", raw, typed, make(map[string]int))
+	return report, nil
+}
+
+// compare walks the raw tree alongside the typed tree and records what the typed tree lacks. The
+// pattern is the pointer with sequence indices replaced by '*'. Empty values are not reported,
+// since the gemara types omit them without losing data.
+func (r *fidelityReport) compare(pointer, pattern string, raw, typed interface{}, seen map[string]int) {
+	if isEmptyValue(raw) {
+		return
+	}
+	switch rawValue := raw.(type) {
+	case map[string]interface{}:
+		typedValue, ok := typed.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(rawValue))
+		for key := range rawValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			token := escapePointer(key)
+			value, ok := typedValue[key]
+			if !ok {
+				if !isEmptyValue(rawValue[key]) {
+					r.record(pointer+"/"+token, pattern+"/"+token, fidelityDropped, rawValue[key], nil, seen)
+				}
+				continue
+			}
+			r.compare(pointer+"/"+token, pattern+"/"+token, rawValue[key], value, seen)
+		}
+		return
+	case []interface{}:
+		typedValue, ok := typed.([]interface{})
+		if !ok {
+			break
+		}
+		for i, item := range rawValue {
+			itemPointer := fmt.Sprintf("%s/%d", pointer, i)
+			if i >= len(typedValue) {
+				r.record(itemPointer, pattern+"/*", fidelityDropped, item, nil, seen)
+				continue
+			}
+			r.compare(itemPointer, pattern+"/*", item, typedValue[i], seen)
+		}
+		return
+	}
+	if !reflect.DeepEqual(raw, typed) {
+		r.record(pointer, pattern, fidelityChanged, raw, typed, seen)
+	}
+}
+
+// record adds an occurrence to the issue for pattern and problem, keeping the first occurrence as the example
+func (r *fidelityReport) record(pointer, pattern, problem string, raw, typed interface{}, seen map[string]int) {
+	key := problem + " " + pattern
+	if i, ok := seen[key]; ok {
+		r.Issues[i].Occurrences++
+		return
+	}
+	issue := fidelityIssue{
+		Path:        pattern,
+		Problem:     problem,
+		Occurrences: 1,
+		Example:     pointer,
+		Raw:         fidelitySummary(raw),
+	}
+	if problem == fidelityChanged {
+		issue.Typed = fidelitySummary(typed)
+	}
+	seen[key] = len(r.Issues)
+	r.Issues = append(r.Issues, issue)
+}
+
+// fidelitySummary renders a value on one line, shortening collections and long text
+func fidelitySummary(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return fmt.Sprintf("mapping with %d key(s)", len(v))
+	case []interface{}:
+		return fmt.Sprintf("sequence of %d item(s)", len(v))
+	}
+	text := strings.Join(strings.Fields(fmt.Sprint(value)), " ")
+	if len(text) > 60 {
+		text = text[:57] + "..."
+	}
+	return fmt.Sprintf("%s (%T)", text, value)
+}
+
+// isEmptyValue reports whether a generic YAML value holds no data. Zero numbers and false count as
+// empty, since omitempty drops them as well.
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return v.IsZero()
+	}
+	return false
+}
+
+// toMarkdown renders the fidelity report
+func (r *fidelityReport) toMarkdown() string {
+	var result strings.Builder
+	if r.ArtifactID != "" {
+		result.WriteString(fmt.Sprintf("## Fidelity Check: Layer %d `%s`\n\n", r.Layer, r.ArtifactID))
+	} else {
+		result.WriteString(fmt.Sprintf("## Fidelity Check: Layer %d YAML\n\n", r.Layer))
+	}
+	if len(r.Issues) == 0 {
+		result.WriteString("✅ Every field survives decoding into the gemara types.\n\n")
+		return result.String()
+	}
+
+	result.WriteString(fmt.Sprintf("⚠️ %d field pattern(s) are lost or altered when the artifact is read through the gemara types. Search, get, and analysis tools will not see them.\n\n", len(r.Issues)))
+	result.WriteString("| Path | Problem | Occurrences | Example | Raw | Typed |\n")
+	result.WriteString("|------|---------|-------------|---------|-----|-------|\n")
+	for _, issue := range r.Issues {
+		result.WriteString(fmt.Sprintf("| `%s` | %s | %d | `%s` | %s | %s |\n",
+			issue.Path, issue.Problem, issue.Occurrences, issue.Example,
+			escapeTableCell(issue.Raw), escapeTableCell(issue.Typed)))
+	}
+	result.WriteString("\n")
+	return result.String()
+}
+
+// fidelityWarning returns a short note listing fields the gemara types drop from yamlContent, or "" if none
+func (g *GemaraAuthoringTools) fidelityWarning(layer int, yamlContent string) string {
+	report, err := g.checkFidelity(layer, yamlContent)
+	if err != nil || len(report.Issues) == 0 {
+		return ""
+	}
+	var result strings.Builder
+	result.WriteString("\n⚠️ Fidelity warning: the file is stored as written, but these fields are lost or altered when it is read through the gemara types and will not appear in search or get results:\n")
+	for _, issue := range report.Issues {
+		result.WriteString(fmt.Sprintf("- `%s` %s (%d occurrence(s))\n", issue.Path, issue.Problem, issue.Occurrences))
+	}
+	result.WriteString("Use check_artifact_fidelity for details.\n")
+	return result.String()
+}
+
+// escapeTableCell makes text safe to place in a markdown table cell
+func escapeTableCell(text string) string {
+	return strings.ReplaceAll(text, "|", "\\|")
+}
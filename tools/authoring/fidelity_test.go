@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckFidelity(t *testing.T) {
+	g := &GemaraAuthoringTools{}
+
+	clean := `title: Test Catalog
+metadata:
+  id: test-catalog
+  description: A catalog
+  author:
+    id: author
+    name: Author
+    type: Human
+controls:
+  - id: CTL-1
+    title: First Control
+    objective: Do the thing
+    family: general
+    assessment-requirements:
+      - id: CTL-1.1
+        text: Requirement one
+        applicability: []
+`
+	report, err := g.checkFidelity(2, clean)
+	require.NoError(t, err)
+	assert.Empty(t, report.Issues)
+
+	lossy := strings.Replace(clean, "metadata:\n", "metadata:\n  title: Metadata Title\n", 1) + `control-families:
+  - id: general
+    controls:
+      - id: CTL-2
+      - id: CTL-3
+`
+	report, err = g.checkFidelity(2, lossy)
+	require.NoError(t, err)
+	require.Len(t, report.Issues, 2)
+	assert.Equal(t, fidelityIssue{
+		Path:        "/control-families",
+		Problem:     fidelityDropped,
+		Occurrences: 1,
+		Example:     "/control-families",
+		Raw:         "sequence of 1 item(s)",
+	}, report.Issues[0])
+	assert.Equal(t, "/metadata/title", report.Issues[1].Path)
+	assert.Contains(t, g.fidelityWarning(2, lossy), "`/control-families` dropped")
+	assert.Empty(t, g.fidelityWarning(2, clean))
+
+	// Zero numbers and false are omitted by the gemara types without losing data
+	zero := strings.Replace(clean, "metadata:\n", "metadata:\n  draft: false\n", 1) + `    guideline-mappings:
+      - reference-id: GUIDE
+        entries:
+          - reference-id: G-1
+            strength: 0
+`
+	report, err = g.checkFidelity(2, zero)
+	require.NoError(t, err)
+	assert.Empty(t, report.Issues)
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/complytime/gemara-mcp-server/storage"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoredFormatPreserved(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewArtifactStorage(dir)
+	require.NoError(t, err)
+	g := &GemaraAuthoringTools{
+		storage:        store,
+		layer1Guidance: map[string]*gemara.GuidanceDocument{},
+		layer2Catalogs: map[string]*gemara.Catalog{},
+		layer3Policies: map[string]*gemara.Policy{},
+	}
+
+	jsonContent := "{\n  \"title\": \"JSON Guidance\",\n  \"metadata\": {\"id\": \"G-JSON\"},\n  \"document-type\": \"Framework\"\n}\n"
+	assert.Equal(t, storage.FormatJSON, storage.DetectFormat([]byte(jsonContent)))
+	assert.Equal(t, storage.FormatYAML, storage.DetectFormat([]byte("title: {not json}\n")))
+
+	id, err := store.StoreRawYAML(1, jsonContent)
+	require.NoError(t, err)
+	assert.Equal(t, "G-JSON", id)
+	entries := store.List(1)
+	require.Len(t, entries, 1)
+	assert.Equal(t, filepath.Join(dir, "layer1", "G-JSON.json"), entries[0].FilePath)
+	assert.Equal(t, storage.FormatJSON, entries[0].Format)
+
+	getOriginal := func() *mcp.CallToolResult {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = map[string]any{"guidance_id": "G-JSON", "output_format": outputOriginal}
+		result, err := g.handleGetLayer1Guidance(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+	result := getOriginal()
+	require.False(t, result.IsError)
+	assert.Equal(t, jsonContent, result.Content[0].(mcp.TextContent).Text)
+
+	// Edits work on YAML, and storing YAML over a JSON artifact keeps the file and its format
+	yamlContent, err := g.storedArtifactYAML(1, "G-JSON")
+	require.NoError(t, err)
+	assert.Equal(t, "title: JSON Guidance\nmetadata:\n  id: G-JSON\ndocument-type: Framework\n", yamlContent)
+	_, err = store.StoreRawYAML(1, "title: Renamed\nmetadata:\n  id: G-JSON\ndocument-type: Framework\n")
+	require.NoError(t, err)
+	raw, err := store.RetrieveRaw(1, "G-JSON")
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"title\": \"Renamed\",\n  \"metadata\": {\n    \"id\": \"G-JSON\"\n  },\n  \"document-type\": \"Framework\"\n}\n", raw)
+	require.Len(t, store.List(1), 1)
+
+	// YAML artifacts stay YAML
+	_, err = store.StoreRawYAML(1, "title: YAML Guidance\nmetadata:\n  id: G-YAML\n")
+	require.NoError(t, err)
+	raw, err = store.RetrieveRaw(1, "G-YAML")
+	require.NoError(t, err)
+	assert.Equal(t, "title: YAML Guidance\nmetadata:\n  id: G-YAML\n", raw)
+
+	// Storage without raw files cannot return the original, and edits start from the decoded artifact
+	g.storage = plainStorage{store}
+	assert.True(t, getOriginal().IsError)
+	yamlContent, err = g.storedArtifactYAML(1, "G-YAML")
+	require.NoError(t, err)
+	assert.Contains(t, yamlContent, "id: G-YAML")
+
+	g.storage = nil
+	assert.True(t, getOriginal().IsError)
+}
+
+// plainStorage exposes only the methods of storage.Storage, hiding the optional interfaces of the storage it wraps
+type plainStorage struct {
+	storage.Storage
+}
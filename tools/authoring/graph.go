@@ -0,0 +1,446 @@
+package authoring
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+)
+
+// Graph node kinds
+const (
+	nodeGuidance      = "guidance"
+	nodeGuideline     = "guideline"
+	nodeGuidelinePart = "guideline-part"
+	nodeCatalog       = "catalog"
+	nodeControl       = "control"
+	nodePolicy        = "policy"
+	nodeReference     = "reference"
+	nodeEntry         = "reference-entry"
+)
+
+// Graph edge relations. Edges point from the dependent element towards its source,
+// so following outgoing edges walks "upstream" towards regulations and guidance.
+const (
+	relPartOf      = "part-of"
+	relMapsTo      = "maps-to"
+	relImports     = "imports"
+	relConstrains  = "constrains"
+	relModifies    = "modifies"
+	relPrincipleOf = "principle-of"
+)
+
+// Traversal directions
+const (
+	directionUpstream   = "upstream"
+	directionDownstream = "downstream"
+	directionBoth       = "both"
+)
+
+// graphNode is an element of the artifact graph
+type graphNode struct {
+	Key        string `json:"key"`
+	Kind       string `json:"kind"`
+	Layer      int    `json:"layer,omitempty"`
+	ArtifactID string `json:"artifact_id"`
+	ElementID  string `json:"element_id"`
+	Label      string `json:"label"`
+}
+
+// graphEdge is a directed relationship between two nodes of the artifact graph
+type graphEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"`
+	Strength int64  `json:"strength,omitempty"`
+}
+
+// artifactGraph holds the relationships between Layer 1-3 artifacts and external references
+type artifactGraph struct {
+	nodes map[string]*graphNode
+	out   map[string][]graphEdge
+	in    map[string][]graphEdge
+	// folded maps lower-cased node keys to the first node added with that key
+	folded map[string]string
+}
+
+// graphView is a subgraph extracted from the artifact graph
+type graphView struct {
+	Roots []string     `json:"roots"`
+	Nodes []*graphNode `json:"nodes"`
+	Edges []graphEdge  `json:"edges"`
+}
+
+// handleGetTraceabilityGraph returns the subgraph around a node of the artifact graph
+func (g *GemaraAuthoringTools) handleGetTraceabilityGraph(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	nodeID := request.GetString("node_id", "")
+	depth := request.GetInt("depth", 2)
+	direction := request.GetString("direction", directionBoth)
+	outputFormat := request.GetString("output_format", "json")
+
+	if nodeID == "" {
+		return mcp.NewToolResultError("node_id is required"), nil
+	}
+	if depth < 0 {
+		return mcp.NewToolResultErrorf("depth must be zero or greater, got %d", depth), nil
+	}
+	if direction != directionUpstream && direction != directionDownstream && direction != directionBoth {
+		return mcp.NewToolResultErrorf("direction must be '%s', '%s', or '%s', got '%s'", directionUpstream, directionDownstream, directionBoth, direction), nil
+	}
+
+	graph := g.buildArtifactGraph()
+	roots := graph.resolve(nodeID)
+	if len(roots) == 0 {
+		return mcp.NewToolResultErrorf("Node '%s' not found in the artifact graph. Use an artifact ID, guideline ID, control ID, or a node key such as 'control:CATALOG/CONTROL-ID'.", nodeID), nil
+	}
+
+	view := graph.subgraph(roots, depth, direction)
+
+	switch outputFormat {
+	case "mermaid":
+		return mcp.NewToolResultText(view.toMermaid()), nil
+	case "dot":
+		return mcp.NewToolResultText(view.toDOT()), nil
+	default:
+		output, err := marshalOutput(view, "json")
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal JSON: %v", err), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}
+}
+
+// buildArtifactGraph builds the artifact graph from all stored Layer 1-3 artifacts
+func (g *GemaraAuthoringTools) buildArtifactGraph() *artifactGraph {
+	graph := &artifactGraph{
+		nodes:  make(map[string]*graphNode),
+		out:    make(map[string][]graphEdge),
+		in:     make(map[string][]graphEdge),
+		folded: make(map[string]string),
+	}
+
+	guidanceDocs := make(map[string]*gemara.GuidanceDocument)
+	for _, entry := range g.getLayerEntries(consts.Layer1) {
+		if guidance := g.loadLayer1Guidance(entry.ID); guidance != nil {
+			guidanceDocs[entry.ID] = guidance
+		}
+	}
+	catalogs := make(map[string]*gemara.Catalog)
+	for _, entry := range g.getLayerEntries(consts.Layer2) {
+		if catalog := g.loadLayer2Catalog(entry.ID); catalog != nil {
+			catalogs[entry.ID] = catalog
+		}
+	}
+	policies := make(map[string]*gemara.Policy)
+	for _, entry := range g.getLayerEntries(consts.Layer3) {
+		if policy := g.loadLayer3Policy(entry.ID); policy != nil {
+			policies[entry.ID] = policy
+		}
+	}
+
+	// Artifacts are added in ID order so that the first of several spellings of a mapped ID is stable
+	guidanceIDs := sortedMapKeys(guidanceDocs)
+	catalogIDs := sortedMapKeys(catalogs)
+
+	// Layer 1: guidance documents, guidelines and guideline parts
+	for _, guidanceID := range guidanceIDs {
+		guidance := guidanceDocs[guidanceID]
+		docKey := graph.addNode(nodeGuidance, consts.Layer1, guidanceID, guidanceID, guidance.Title)
+		for _, guideline := range guidance.Guidelines {
+			guidelineKey := graph.addNode(nodeGuideline, consts.Layer1, guidanceID, guideline.Id, guideline.Title)
+			graph.addEdge(guidelineKey, docKey, relPartOf, 0)
+			for _, part := range guideline.Statements {
+				partKey := graph.addNode(nodeGuidelinePart, consts.Layer1, guidanceID, part.Id, part.Title)
+				graph.addEdge(partKey, guidelineKey, relPartOf, 0)
+			}
+		}
+	}
+	for _, guidanceID := range guidanceIDs {
+		for _, guideline := range guidanceDocs[guidanceID].Guidelines {
+			guidelineKey := nodeKey(nodeGuideline, guidanceID, guideline.Id)
+			graph.addMappingEdges(guidelineKey, guideline.GuidelineMappings, relMapsTo)
+			graph.addMappingEdges(guidelineKey, guideline.PrincipleMappings, relPrincipleOf)
+		}
+	}
+
+	// Layer 2: catalogs and controls
+	for _, catalogID := range catalogIDs {
+		catalog := catalogs[catalogID]
+		catalogKey := graph.addNode(nodeCatalog, consts.Layer2, catalogID, catalogID, catalog.Title)
+		for _, control := range catalog.Controls {
+			controlKey := graph.addNode(nodeControl, consts.Layer2, catalogID, control.Id, control.Title)
+			graph.addEdge(controlKey, catalogKey, relPartOf, 0)
+			graph.addMappingEdges(controlKey, control.GuidelineMappings, relMapsTo)
+		}
+	}
+
+	// Layer 3: policies and their imports
+	for policyID, policy := range policies {
+		policyKey := graph.addNode(nodePolicy, consts.Layer3, policyID, policyID, policy.Title)
+		for _, imported := range policy.Imports.Guidance {
+			targetKey := nodeKey(nodeGuidance, imported.ReferenceId, imported.ReferenceId)
+			if _, ok := graph.nodes[targetKey]; !ok {
+				targetKey = graph.addNode(nodeReference, 0, imported.ReferenceId, imported.ReferenceId, imported.ReferenceId)
+			}
+			graph.addEdge(policyKey, targetKey, relImports, 0)
+			for _, constraint := range imported.Constraints {
+				if key := graph.findElement(imported.ReferenceId, constraint.TargetId, nodeGuideline, nodeGuidelinePart); key != "" {
+					graph.addEdge(policyKey, key, relConstrains, 0)
+				}
+			}
+		}
+		for _, imported := range policy.Imports.Catalogs {
+			targetKey := nodeKey(nodeCatalog, imported.ReferenceId, imported.ReferenceId)
+			if _, ok := graph.nodes[targetKey]; !ok {
+				targetKey = graph.addNode(nodeReference, 0, imported.ReferenceId, imported.ReferenceId, imported.ReferenceId)
+			}
+			graph.addEdge(policyKey, targetKey, relImports, 0)
+			for _, constraint := range imported.Constraints {
+				if key := graph.findElement(imported.ReferenceId, constraint.TargetId, nodeControl); key != "" {
+					graph.addEdge(policyKey, key, relConstrains, 0)
+				}
+			}
+			if catalog, ok := catalogs[imported.ReferenceId]; ok {
+				for _, modifier := range imported.AssessmentRequirementModifications {
+					if control := findControlByRequirement(catalog, modifier.TargetId); control != nil {
+						graph.addEdge(policyKey, nodeKey(nodeControl, imported.ReferenceId, control.Id), relModifies, 0)
+					}
+				}
+			}
+		}
+	}
+
+	return graph
+}
+
+// nodeKey builds the unique key of a graph node
+func nodeKey(kind, artifactID, elementID string) string {
+	switch kind {
+	case nodeGuidance, nodeCatalog, nodePolicy, nodeReference:
+		return fmt.Sprintf("%s:%s", kind, artifactID)
+	default:
+		return fmt.Sprintf("%s:%s/%s", kind, artifactID, elementID)
+	}
+}
+
+// addNode adds a node to the graph if it does not exist yet and returns its key
+func (a *artifactGraph) addNode(kind string, layer int, artifactID, elementID, label string) string {
+	key := nodeKey(kind, artifactID, elementID)
+	if _, exists := a.nodes[key]; !exists {
+		if _, exists := a.folded[strings.ToLower(key)]; !exists {
+			a.folded[strings.ToLower(key)] = key
+		}
+		a.nodes[key] = &graphNode{
+			Key:        key,
+			Kind:       kind,
+			Layer:      layer,
+			ArtifactID: artifactID,
+			ElementID:  elementID,
+			Label:      label,
+		}
+	}
+	return key
+}
+
+// addEdge adds a directed edge to the graph, ignoring duplicates
+func (a *artifactGraph) addEdge(from, to, relation string, strength int64) {
+	for _, existing := range a.out[from] {
+		if existing.To == to && existing.Relation == relation {
+			return
+		}
+	}
+	edge := graphEdge{From: from, To: to, Relation: relation, Strength: strength}
+	a.out[from] = append(a.out[from], edge)
+	a.in[to] = append(a.in[to], edge)
+}
+
+// addMappingEdges links a node to the targets of its mappings. Mappings that reference a stored
+// guidance document link to its guidelines; any other mapping links to an external reference entry.
+// Reference and entry IDs match regardless of case, like reverse mappings and coverage reports.
+func (a *artifactGraph) addMappingEdges(from string, mappings []gemara.MultiMapping, relation string) {
+	for _, mapping := range mappings {
+		for _, entry := range mapping.Entries {
+			target := a.findMappedElement(mapping.ReferenceId, entry.ReferenceId, nodeGuideline, nodeGuidelinePart, nodeEntry)
+			if target == "" {
+				refKey := a.findMappedElement(mapping.ReferenceId, mapping.ReferenceId, nodeReference)
+				if refKey == "" {
+					refKey = a.addNode(nodeReference, 0, mapping.ReferenceId, mapping.ReferenceId, mapping.ReferenceId)
+				}
+				referenceID := a.nodes[refKey].ArtifactID
+				target = a.addNode(nodeEntry, 0, referenceID, entry.ReferenceId, entry.ReferenceId)
+				a.addEdge(target, refKey, relPartOf, 0)
+			}
+			a.addEdge(from, target, relation, entry.Strength)
+		}
+	}
+}
+
+// findElement returns the key of the first existing node of the given kinds within an artifact
+func (a *artifactGraph) findElement(artifactID, elementID string, kinds ...string) string {
+	for _, kind := range kinds {
+		key := nodeKey(kind, artifactID, elementID)
+		if _, ok := a.nodes[key]; ok {
+			return key
+		}
+	}
+	return ""
+}
+
+// findMappedElement is findElement for mapping targets, falling back to a match that ignores case
+func (a *artifactGraph) findMappedElement(artifactID, elementID string, kinds ...string) string {
+	if key := a.findElement(artifactID, elementID, kinds...); key != "" {
+		return key
+	}
+	for _, kind := range kinds {
+		if key, ok := a.folded[strings.ToLower(nodeKey(kind, artifactID, elementID))]; ok {
+			return key
+		}
+	}
+	return ""
+}
+
+// resolve finds the nodes matching a user-supplied identifier. The identifier can be a full node key,
+// an artifact ID, or an element ID such as a guideline, control, or reference entry ID.
+func (a *artifactGraph) resolve(id string) []string {
+	if _, ok := a.nodes[id]; ok {
+		return []string{id}
+	}
+	var matches []string
+	for key, node := range a.nodes {
+		if node.ElementID == id {
+			matches = append(matches, key)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// subgraph collects all nodes within depth hops of the roots, following edges in the given direction
+func (a *artifactGraph) subgraph(roots []string, depth int, direction string) *graphView {
+	visited := make(map[string]bool)
+	edgeSet := make(map[graphEdge]bool)
+	frontier := roots
+	for _, root := range roots {
+		visited[root] = true
+	}
+
+	for level := 0; level < depth && len(frontier) > 0; level++ {
+		var next []string
+		for _, key := range frontier {
+			var edges []graphEdge
+			if direction == directionUpstream || direction == directionBoth {
+				edges = append(edges, a.out[key]...)
+			}
+			if direction == directionDownstream || direction == directionBoth {
+				edges = append(edges, a.in[key]...)
+			}
+			for _, edge := range edges {
+				edgeSet[edge] = true
+				neighbor := edge.To
+				if neighbor == key {
+					neighbor = edge.From
+				}
+				if !visited[neighbor] {
+					visited[neighbor] = true
+					next = append(next, neighbor)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	view := &graphView{Roots: roots}
+	for key := range visited {
+		view.Nodes = append(view.Nodes, a.nodes[key])
+	}
+	for edge := range edgeSet {
+		view.Edges = append(view.Edges, edge)
+	}
+	sort.Slice(view.Nodes, func(i, j int) bool { return view.Nodes[i].Key < view.Nodes[j].Key })
+	sort.Slice(view.Edges, func(i, j int) bool {
+		if view.Edges[i].From != view.Edges[j].From {
+			return view.Edges[i].From < view.Edges[j].From
+		}
+		if view.Edges[i].To != view.Edges[j].To {
+			return view.Edges[i].To < view.Edges[j].To
+		}
+		return view.Edges[i].Relation < view.Edges[j].Relation
+	})
+	return view
+}
+
+// toMermaid renders the subgraph as a Mermaid flowchart
+func (v *graphView) toMermaid() string {
+	ids := v.shortIDs()
+	var result strings.Builder
+	result.WriteString("graph LR\n")
+	for _, node := range v.Nodes {
+		label := strings.ReplaceAll(fmt.Sprintf("%s: %s", node.Kind, node.ElementID), `"`, "#quot;")
+		result.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", ids[node.Key], label))
+	}
+	for _, edge := range v.Edges {
+		label := edge.Relation
+		if edge.Strength > 0 {
+			label = fmt.Sprintf("%s (%d)", edge.Relation, edge.Strength)
+		}
+		result.WriteString(fmt.Sprintf("  %s -->|\"%s\"| %s\n", ids[edge.From], label, ids[edge.To]))
+	}
+	return result.String()
+}
+
+// toDOT renders the subgraph in Graphviz DOT format
+func (v *graphView) toDOT() string {
+	ids := v.shortIDs()
+	var result strings.Builder
+	result.WriteString("digraph traceability {\n")
+	result.WriteString("  rankdir=LR;\n")
+	result.WriteString("  node [shape=box];\n")
+	for _, node := range v.Nodes {
+		label := strings.ReplaceAll(fmt.Sprintf("%s\\n%s", node.Kind, node.ElementID), `"`, `\"`)
+		result.WriteString(fmt.Sprintf("  %s [label=\"%s\"];\n", ids[node.Key], label))
+	}
+	for _, edge := range v.Edges {
+		label := edge.Relation
+		if edge.Strength > 0 {
+			label = fmt.Sprintf("%s (%d)", edge.Relation, edge.Strength)
+		}
+		result.WriteString(fmt.Sprintf("  %s -> %s [label=\"%s\"];\n", ids[edge.From], ids[edge.To], label))
+	}
+	result.WriteString("}\n")
+	return result.String()
+}
+
+// shortIDs assigns diagram-safe identifiers to the nodes of the subgraph
+func (v *graphView) shortIDs() map[string]string {
+	ids := make(map[string]string, len(v.Nodes))
+	for i, node := range v.Nodes {
+		ids[node.Key] = fmt.Sprintf("n%d", i)
+	}
+	return ids
+}
+
+// sortedMapKeys returns the keys of a map in ascending order
+func sortedMapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// findControlByRequirement returns the control that owns the given assessment requirement ID
+func findControlByRequirement(catalog *gemara.Catalog, requirementID string) *gemara.Control {
+	for i := range catalog.Controls {
+		for _, req := range catalog.Controls[i].AssessmentRequirements {
+			if req.Id == requirementID {
+				return &catalog.Controls[i]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"testing"
+
+	"github.com/ossf/gemara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testGraph builds the artifact graph of the mapping fixture with a policy that imports a stored
+// and an unknown catalog
+func testGraph() *artifactGraph {
+	g := testMappingTools()
+	g.layer3Policies["POL"] = &gemara.Policy{
+		Title:    "Test Policy",
+		Metadata: gemara.Metadata{Id: "POL"},
+		Imports: gemara.Imports{Catalogs: []gemara.CatalogImport{
+			{ReferenceId: "CAT-A", Constraints: []gemara.Constraint{
+				{Id: "CON-1", TargetId: "CTL-1"},
+				{Id: "CON-2", TargetId: "CTL-9"},
+			}},
+			{ReferenceId: "EXTERNAL"},
+		}},
+	}
+	return g.buildArtifactGraph()
+}
+
+func TestArtifactGraphResolve(t *testing.T) {
+	graph := testGraph()
+
+	tests := []struct {
+		name string
+		id   string
+		want []string
+	}{
+		{name: "node key", id: "catalog:CAT-A", want: []string{"catalog:CAT-A"}},
+		{name: "artifact ID", id: "GUIDE", want: []string{"guidance:GUIDE", "reference:GUIDE"}},
+		{name: "colliding control IDs", id: "CTL-1", want: []string{"control:CAT-A/CTL-1", "control:CAT-B/CTL-1"}},
+		{name: "guideline part", id: "G-1.a", want: []string{"guideline-part:GUIDE/G-1.a"}},
+		{name: "unknown ID", id: "CTL-9"},
+		{name: "IDs are case sensitive", id: "ctl-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, graph.resolve(tt.id))
+		})
+	}
+}
+
+func TestArtifactGraphSubgraph(t *testing.T) {
+	graph := testGraph()
+
+	tests := []struct {
+		name      string
+		root      string
+		depth     int
+		direction string
+		nodes     []string
+		edges     []graphEdge
+	}{
+		{
+			name:      "depth zero is the root alone",
+			root:      "control:CAT-A/CTL-1",
+			depth:     0,
+			direction: directionBoth,
+			nodes:     []string{"control:CAT-A/CTL-1"},
+		},
+		{
+			name:      "upstream follows mappings regardless of case",
+			root:      "control:CAT-A/CTL-1",
+			depth:     1,
+			direction: directionUpstream,
+			nodes:     []string{"catalog:CAT-A", "control:CAT-A/CTL-1", "guideline-part:GUIDE/G-1.a", "guideline:GUIDE/G-1"},
+			edges: []graphEdge{
+				{From: "control:CAT-A/CTL-1", To: "catalog:CAT-A", Relation: relPartOf},
+				{From: "control:CAT-A/CTL-1", To: "guideline-part:GUIDE/G-1.a", Relation: relMapsTo, Strength: 7},
+				{From: "control:CAT-A/CTL-1", To: "guideline:GUIDE/G-1", Relation: relMapsTo, Strength: 4},
+			},
+		},
+		{
+			name:      "downstream finds constraining policies",
+			root:      "control:CAT-A/CTL-1",
+			depth:     1,
+			direction: directionDownstream,
+			nodes:     []string{"control:CAT-A/CTL-1", "policy:POL"},
+			edges:     []graphEdge{{From: "policy:POL", To: "control:CAT-A/CTL-1", Relation: relConstrains}},
+		},
+		{
+			name:      "entries spelled in another case share a node",
+			root:      "reference-entry:NIST/CA-7",
+			depth:     1,
+			direction: directionBoth,
+			nodes:     []string{"control:CAT-A/CTL-3", "guideline:GUIDE/G-1", "reference-entry:NIST/CA-7", "reference:NIST"},
+			edges: []graphEdge{
+				{From: "control:CAT-A/CTL-3", To: "reference-entry:NIST/CA-7", Relation: relMapsTo, Strength: 8},
+				{From: "guideline:GUIDE/G-1", To: "reference-entry:NIST/CA-7", Relation: relMapsTo, Strength: 6},
+				{From: "reference-entry:NIST/CA-7", To: "reference:NIST", Relation: relPartOf},
+			},
+		},
+		{
+			name:      "unknown imports become references",
+			root:      "policy:POL",
+			depth:     1,
+			direction: directionUpstream,
+			nodes:     []string{"catalog:CAT-A", "control:CAT-A/CTL-1", "policy:POL", "reference:EXTERNAL"},
+			edges: []graphEdge{
+				{From: "policy:POL", To: "catalog:CAT-A", Relation: relImports},
+				{From: "policy:POL", To: "control:CAT-A/CTL-1", Relation: relConstrains},
+				{From: "policy:POL", To: "reference:EXTERNAL", Relation: relImports},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := graph.subgraph([]string{tt.root}, tt.depth, tt.direction)
+			var nodes []string
+			for _, node := range view.Nodes {
+				nodes = append(nodes, node.Key)
+			}
+			assert.Equal(t, tt.nodes, nodes)
+			assert.Equal(t, tt.edges, view.Edges)
+		})
+	}
+}
+
+func TestGraphViewRendering(t *testing.T) {
+	view := testGraph().subgraph([]string{"guideline-part:GUIDE/G-1.a"}, 1, directionBoth)
+	require.Len(t, view.Nodes, 3)
+
+	assert.Equal(t, `graph LR
+  n0["control: CTL-1"]
+  n1["guideline-part: G-1.a"]
+  n2["guideline: G-1"]
+  n0 -->|"maps-to (7)"| n1
+  n1 -->|"part-of"| n2
+`, view.toMermaid())
+
+	assert.Equal(t, `digraph traceability {
+  rankdir=LR;
+  node [shape=box];
+  n0 [label="control\nCTL-1"];
+  n1 [label="guideline-part\nG-1.a"];
+  n2 [label="guideline\nG-1"];
+  n0 -> n1 [label="maps-to (7)"];
+  n1 -> n2 [label="part-of"];
+}
+`, view.toDOT())
+}
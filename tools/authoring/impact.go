@@ -0,0 +1,255 @@
+package authoring
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/goccy/go-yaml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+)
+
+// impactedDependent is an element that depends on a changed element
+type impactedDependent struct {
+	ChangedID  string `json:"changed_id"`
+	Kind       string `json:"kind"`
+	Layer      int    `json:"layer"`
+	ArtifactID string `json:"artifact_id"`
+	ElementID  string `json:"element_id"`
+	Title      string `json:"title,omitempty"`
+	Relation   string `json:"relation"`
+	Strength   int64  `json:"strength,omitempty"`
+	Detail     string `json:"detail,omitempty"`
+}
+
+// impactReport lists the changed elements of an incoming artifact and everything that depends on them
+type impactReport struct {
+	Layer      int                 `json:"layer"`
+	ArtifactID string              `json:"artifact_id"`
+	IsNew      bool                `json:"is_new"`
+	Changes    []elementDiff       `json:"changes"`
+	Dependents []impactedDependent `json:"dependents"`
+}
+
+// handleAnalyzeImpact diffs incoming YAML against the stored version and lists affected downstream elements
+func (g *GemaraAuthoringTools) handleAnalyzeImpact(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	yamlContent := request.GetString("yaml_content", "")
+	layer := request.GetInt("layer", 0)
+	outputFormat := request.GetString("output_format", "markdown")
+
+	if yamlContent == "" {
+		return mcp.NewToolResultError("yaml_content is required"), nil
+	}
+	if layer != consts.Layer1 && layer != consts.Layer2 {
+		return mcp.NewToolResultErrorf("layer must be %d or %d, got %d", consts.Layer1, consts.Layer2, layer), nil
+	}
+
+	report, err := g.analyzeImpact(layer, yamlContent)
+	if err != nil {
+		return mcp.NewToolResultErrorf("Failed to analyze impact: %v", err), nil
+	}
+
+	if outputFormat == "json" {
+		output, err := marshalOutput(report, "json")
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal JSON: %v", err), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}
+
+	return mcp.NewToolResultText(report.toMarkdown()), nil
+}
+
+// analyzeImpact compares incoming Layer 1 or Layer 2 YAML with the stored artifact of the same ID
+// and collects every control, guideline, and policy that depends on a changed or removed element
+func (g *GemaraAuthoringTools) analyzeImpact(layer int, yamlContent string) (*impactReport, error) {
+	report := &impactReport{Layer: layer}
+
+	switch layer {
+	case consts.Layer1:
+		incoming := &gemara.GuidanceDocument{}
+		if err := yaml.Unmarshal([]byte(yamlContent), incoming); err != nil {
+			return nil, fmt.Errorf("failed to parse Layer 1 YAML: %w", err)
+		}
+		if incoming.Metadata.Id == "" {
+			return nil, fmt.Errorf("metadata.id is required in YAML content")
+		}
+		report.ArtifactID = incoming.Metadata.Id
+		stored := g.loadLayer1Guidance(report.ArtifactID)
+		if stored == nil {
+			report.IsNew = true
+			return report, nil
+		}
+		diff, err := diffGuidance(stored, incoming)
+		if err != nil {
+			return nil, err
+		}
+		report.Changes = diff.Elements
+	case consts.Layer2:
+		incoming := &gemara.Catalog{}
+		if err := yaml.Unmarshal([]byte(yamlContent), incoming); err != nil {
+			return nil, fmt.Errorf("failed to parse Layer 2 YAML: %w", err)
+		}
+		if incoming.Metadata.Id == "" {
+			return nil, fmt.Errorf("metadata.id is required in YAML content")
+		}
+		report.ArtifactID = incoming.Metadata.Id
+		stored := g.loadLayer2Catalog(report.ArtifactID)
+		if stored == nil {
+			report.IsNew = true
+			return report, nil
+		}
+		diff, err := diffCatalogs(stored, incoming)
+		if err != nil {
+			return nil, err
+		}
+		report.Changes = diff.Elements
+	default:
+		return nil, fmt.Errorf("impact analysis is not supported for layer %d", layer)
+	}
+
+	graph := g.buildArtifactGraph()
+	for _, change := range report.Changes {
+		if change.Change == changeAdded {
+			continue
+		}
+		if change.Kind == elementAssessmentRequirement {
+			report.Dependents = append(report.Dependents, g.requirementDependents(report.ArtifactID, change.ID)...)
+			continue
+		}
+		key := nodeKey(change.Kind, report.ArtifactID, change.ID)
+		for _, edge := range graph.in[key] {
+			// Policy modifications are reported per assessment requirement above
+			if edge.Relation == relPartOf || edge.Relation == relModifies {
+				continue
+			}
+			node := graph.nodes[edge.From]
+			report.Dependents = append(report.Dependents, impactedDependent{
+				ChangedID:  change.ID,
+				Kind:       node.Kind,
+				Layer:      node.Layer,
+				ArtifactID: node.ArtifactID,
+				ElementID:  node.ElementID,
+				Title:      node.Label,
+				Relation:   edge.Relation,
+				Strength:   edge.Strength,
+			})
+		}
+	}
+
+	return report, nil
+}
+
+// requirementDependents lists the policy modifications targeting an assessment requirement of a catalog
+func (g *GemaraAuthoringTools) requirementDependents(catalogID, requirementID string) []impactedDependent {
+	var dependents []impactedDependent
+	for _, entry := range g.getLayerEntries(consts.Layer3) {
+		policy := g.loadLayer3Policy(entry.ID)
+		if policy == nil {
+			continue
+		}
+		for _, imported := range policy.Imports.Catalogs {
+			if imported.ReferenceId != catalogID {
+				continue
+			}
+			for _, modifier := range imported.AssessmentRequirementModifications {
+				if modifier.TargetId != requirementID {
+					continue
+				}
+				dependents = append(dependents, impactedDependent{
+					ChangedID:  requirementID,
+					Kind:       nodePolicy,
+					Layer:      consts.Layer3,
+					ArtifactID: entry.ID,
+					ElementID:  modifier.Id,
+					Title:      policy.Title,
+					Relation:   relModifies,
+					Detail:     fmt.Sprintf("%s (target-id: %s)", modifier.ModificationType, modifier.TargetId),
+				})
+			}
+		}
+	}
+	return dependents
+}
+
+// toMarkdown renders the impact report as markdown
+func (r *impactReport) toMarkdown() string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("# Impact Analysis: Layer %d `%s`\n\n", r.Layer, r.ArtifactID))
+
+	if r.IsNew {
+		result.WriteString("No stored version of this artifact exists, so nothing downstream depends on it yet.\n")
+		return result.String()
+	}
+
+	var changed, removed, added []elementDiff
+	for _, c := range r.Changes {
+		switch c.Change {
+		case changeModified:
+			changed = append(changed, c)
+		case changeRemoved:
+			removed = append(removed, c)
+		case changeAdded:
+			added = append(added, c)
+		}
+	}
+
+	result.WriteString("## Changes\n\n")
+	result.WriteString(fmt.Sprintf("- **Modified**: %d\n", len(changed)))
+	result.WriteString(fmt.Sprintf("- **Removed**: %d\n", len(removed)))
+	result.WriteString(fmt.Sprintf("- **Added**: %d\n\n", len(added)))
+	for _, group := range []struct {
+		title   string
+		changes []elementDiff
+	}{{"Modified", changed}, {"Removed", removed}} {
+		if len(group.changes) == 0 {
+			continue
+		}
+		result.WriteString(fmt.Sprintf("### %s\n\n", group.title))
+		for _, c := range group.changes {
+			result.WriteString(fmt.Sprintf("- `%s` (%s)\n", c.ID, c.Kind))
+		}
+		result.WriteString("\n")
+	}
+
+	result.WriteString("## Affected Dependents\n\n")
+	if len(r.Dependents) == 0 {
+		result.WriteString("No stored guidelines, controls, or policies depend on the modified or removed elements.\n")
+		return result.String()
+	}
+	result.WriteString(r.dependentsMarkdown())
+
+	return result.String()
+}
+
+// dependentsMarkdown renders the affected dependents as a markdown list
+func (r *impactReport) dependentsMarkdown() string {
+	var result strings.Builder
+	for _, d := range r.Dependents {
+		result.WriteString(fmt.Sprintf("- `%s` ← %s `%s` in `%s` (%s", d.ChangedID, d.Kind, d.ElementID, d.ArtifactID, d.Relation))
+		if d.Strength > 0 {
+			result.WriteString(fmt.Sprintf(", strength: %d", d.Strength))
+		}
+		if d.Detail != "" {
+			result.WriteString(fmt.Sprintf(", %s", d.Detail))
+		}
+		result.WriteString(")\n")
+	}
+	return result.String()
+}
+
+// impactWarning returns a non-blocking warning for the store tools when an update affects stored dependents.
+// It must be called before the new version replaces the stored one.
+func (g *GemaraAuthoringTools) impactWarning(layer int, yamlContent string) string {
+	report, err := g.analyzeImpact(layer, yamlContent)
+	if err != nil || len(report.Dependents) == 0 {
+		return ""
+	}
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("\n⚠️ Impact warning: this update modifies or removes elements that %d stored dependent(s) rely on:\n", len(report.Dependents)))
+	result.WriteString(report.dependentsMarkdown())
+	result.WriteString("Use analyze_impact to review the full change report.\n")
+	return result.String()
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ossf/gemara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testImpactTools returns the mapping fixture with a requirement on CAT-A/CTL-1 and a policy that
+// constrains that control and modifies its requirement
+func testImpactTools() *GemaraAuthoringTools {
+	g := testMappingTools()
+	g.layer2Catalogs["CAT-A"].Controls[0].AssessmentRequirements = []gemara.AssessmentRequirement{{Id: "CTL-1.1", Text: "Monitor"}}
+	g.layer3Policies["POL"] = &gemara.Policy{
+		Title:    "Test Policy",
+		Metadata: gemara.Metadata{Id: "POL"},
+		Imports: gemara.Imports{Catalogs: []gemara.CatalogImport{{
+			ReferenceId:                        "CAT-A",
+			Constraints:                        []gemara.Constraint{{Id: "CON-1", TargetId: "CTL-1"}},
+			AssessmentRequirementModifications: []gemara.AssessmentRequirementModifier{{Id: "MOD-1", TargetId: "CTL-1.1", ModificationType: "modify"}},
+		}}},
+	}
+	return g
+}
+
+func TestAnalyzeImpact(t *testing.T) {
+	// Artifacts are written as JSON, which is also YAML, because only encoding/json marshals
+	// the author type of a struct value through its pointer method
+	mustJSON := func(v any) string {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		return string(data)
+	}
+	catalog := func(modify func(c *gemara.Catalog)) string {
+		c := *testImpactTools().layer2Catalogs["CAT-A"]
+		c.Controls = append([]gemara.Control{}, c.Controls...)
+		modify(&c)
+		return mustJSON(&c)
+	}
+	guidance := func(modify func(d *gemara.GuidanceDocument)) string {
+		d := *testImpactTools().layer1Guidance["GUIDE"]
+		d.Guidelines = append([]gemara.Guideline{}, d.Guidelines...)
+		modify(&d)
+		return mustJSON(&d)
+	}
+
+	tests := []struct {
+		name       string
+		layer      int
+		yaml       string
+		isNew      bool
+		dependents []impactedDependent
+	}{
+		{
+			name:  "new artifact",
+			layer: 2,
+			yaml:  "metadata:\n  id: CAT-NEW\ntitle: New\n",
+			isNew: true,
+		},
+		{
+			name:  "removed control affects policies of its catalog only",
+			layer: 2,
+			yaml: catalog(func(c *gemara.Catalog) {
+				c.Controls = c.Controls[1:]
+			}),
+			dependents: []impactedDependent{
+				{ChangedID: "CTL-1", Kind: nodePolicy, Layer: 3, ArtifactID: "POL", ElementID: "POL", Title: "Test Policy", Relation: relConstrains},
+				{ChangedID: "CTL-1.1", Kind: nodePolicy, Layer: 3, ArtifactID: "POL", ElementID: "MOD-1", Title: "Test Policy", Relation: relModifies, Detail: "modify (target-id: CTL-1.1)"},
+			},
+		},
+		{
+			name:  "modified requirement affects policy modifications",
+			layer: 2,
+			yaml: catalog(func(c *gemara.Catalog) {
+				c.Controls[0].AssessmentRequirements = []gemara.AssessmentRequirement{{Id: "CTL-1.1", Text: "Monitor continuously"}}
+			}),
+			dependents: []impactedDependent{
+				{ChangedID: "CTL-1.1", Kind: nodePolicy, Layer: 3, ArtifactID: "POL", ElementID: "MOD-1", Title: "Test Policy", Relation: relModifies, Detail: "modify (target-id: CTL-1.1)"},
+			},
+		},
+		{
+			name:  "added control has no dependents",
+			layer: 2,
+			yaml: catalog(func(c *gemara.Catalog) {
+				c.Controls = append(c.Controls, gemara.Control{Id: "CTL-4", Title: "New"})
+			}),
+		},
+		{
+			name:  "modified guideline affects controls mapped in any case",
+			layer: 1,
+			yaml: guidance(func(d *gemara.GuidanceDocument) {
+				d.Guidelines[0].Title = "Renamed Guideline"
+			}),
+			dependents: []impactedDependent{
+				{ChangedID: "G-1", Kind: nodeControl, Layer: 2, ArtifactID: "CAT-A", ElementID: "CTL-1", Title: "Monitoring", Relation: relMapsTo, Strength: 4},
+				{ChangedID: "G-1", Kind: nodeControl, Layer: 2, ArtifactID: "CAT-B", ElementID: "CTL-1", Title: "Logging", Relation: relMapsTo, Strength: 2},
+			},
+		},
+		{
+			name:  "unmapped guideline removed",
+			layer: 1,
+			yaml: guidance(func(d *gemara.GuidanceDocument) {
+				d.Guidelines = d.Guidelines[:2]
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := testImpactTools()
+			report, err := g.analyzeImpact(tt.layer, tt.yaml)
+			require.NoError(t, err)
+			assert.Equal(t, tt.isNew, report.IsNew)
+			assert.Equal(t, tt.dependents, report.Dependents)
+
+			warning := g.impactWarning(tt.layer, tt.yaml)
+			if len(tt.dependents) == 0 {
+				assert.Empty(t, warning)
+			} else {
+				assert.Contains(t, warning, "stored dependent(s) rely on")
+			}
+		})
+	}
+
+	_, err := testImpactTools().analyzeImpact(2, "title: No ID\n")
+	assert.ErrorContains(t, err, "metadata.id is required")
+}
@@ -7,13 +7,16 @@ import (
 	"log/slog"
 	"strings"
 
+	"github.com/complytime/gemara-mcp-server/internal/consts"
 	"github.com/complytime/gemara-mcp-server/storage"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/ossf/gemara"
 )
 
 // handleListLayer1Guidance lists all available Layer 1 Guidance documents
-func (g *GemaraAuthoringTools) handleListLayer1Guidance(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func (g *GemaraAuthoringTools) handleListLayer1Guidance(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	outputFormat := request.GetString("output_format", "yaml")
+
 	// Rescan storage to discover new artifacts
 	if g.storage != nil {
 		if err := g.storage.Rescan(); err != nil {
@@ -38,27 +41,59 @@ func (g *GemaraAuthoringTools) handleListLayer1Guidance(_ context.Context, _ mcp
 
 	totalCount := len(entries)
 	if totalCount == 0 {
-		return mcp.NewToolResultText("No Layer 1 Guidance documents available.\n\nUse store_layer1_yaml to store guidance documents."), nil
+		if outputFormat == "json" {
+			return mcp.NewToolResultText("[]"), nil
+		}
+		return mcp.NewToolResultText("No Layer 1 Guidance documents available.\n\nUse store_layer1_yaml to store guidance documents.\n" + g.quarantineNote(1)), nil
 	}
 
-	result := fmt.Sprintf("# Available Layer 1 Guidance Documents\n\n")
-	result += fmt.Sprintf("Total: %d guidance document(s)\n\n", totalCount)
-
+	guidanceByID := make(map[string]*gemara.GuidanceDocument, len(entries))
 	for _, entry := range entries {
 		// Try to get full details from cache or storage
-		var guidance *gemara.GuidanceDocument
 		if gd, exists := g.layer1Guidance[entry.ID]; exists {
-			guidance = gd
+			guidanceByID[entry.ID] = gd
 		} else if g.storage != nil {
 			if retrieved, err := g.storage.Retrieve(1, entry.ID); err == nil {
 				if gd, ok := retrieved.(*gemara.GuidanceDocument); ok {
-					guidance = gd
+					guidanceByID[entry.ID] = gd
 					// Update cache
-					g.layer1Guidance[entry.ID] = guidance
+					g.layer1Guidance[entry.ID] = gd
+				}
+			}
+		}
+	}
+
+	if outputFormat == "json" {
+		guidanceJSON := make([]map[string]interface{}, len(entries))
+		for i, entry := range entries {
+			guidanceJSON[i] = map[string]interface{}{
+				"guidance_id": entry.ID,
+				"title":       entry.Title,
+			}
+			if guidance := guidanceByID[entry.ID]; guidance != nil {
+				if guidance.Metadata.Description != "" {
+					guidanceJSON[i]["description"] = guidance.Metadata.Description
+				}
+				if guidance.Metadata.Author.Name != "" {
+					guidanceJSON[i]["author"] = guidance.Metadata.Author.Name
+				}
+				if guidance.Metadata.Version != "" {
+					guidanceJSON[i]["version"] = guidance.Metadata.Version
 				}
 			}
 		}
+		output, err := marshalOutput(guidanceJSON, outputFormat)
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal JSON: %v", err), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}
 
+	result := fmt.Sprintf("# Available Layer 1 Guidance Documents\n\n")
+	result += fmt.Sprintf("Total: %d guidance document(s)\n\n", totalCount)
+
+	for _, entry := range entries {
+		guidance := guidanceByID[entry.ID]
 		result += fmt.Sprintf("## %s\n", entry.Title)
 		result += fmt.Sprintf("- **ID**: `%s`\n", entry.ID)
 		if guidance != nil {
@@ -77,6 +112,7 @@ func (g *GemaraAuthoringTools) handleListLayer1Guidance(_ context.Context, _ mcp
 
 	result += "\nUse `get_layer1_guidance` with a guidance_id to get full details.\n"
 	result += "Use these guidance IDs in `guideline_mappings` when creating Layer 2 controls.\n"
+	result += g.quarantineNote(1)
 
 	return mcp.NewToolResultText(result), nil
 }
@@ -109,6 +145,9 @@ func (g *GemaraAuthoringTools) handleGetLayer1Guidance(_ context.Context, reques
 	}
 
 	outputFormat := request.GetString("output_format", "yaml")
+	if outputFormat == outputOriginal {
+		return g.originalArtifact(1, guidanceID), nil
+	}
 	output, err := marshalOutput(guidance, outputFormat)
 	if err != nil {
 		return mcp.NewToolResultErrorf("failed to marshal: %v", err), nil
@@ -120,11 +159,14 @@ func (g *GemaraAuthoringTools) handleGetLayer1Guidance(_ context.Context, reques
 // handleStoreLayer1YAML stores raw YAML content with CUE validation
 // This is the preferred method for storing Layer 1 artifacts as it preserves all YAML content without data loss
 func (g *GemaraAuthoringTools) handleStoreLayer1YAML(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	yamlContent := request.GetString("yaml_content", "")
-	if yamlContent == "" {
-		return mcp.NewToolResultError("yaml_content is required"), nil
+	yamlContent, err := artifactContent(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
+	// Check downstream impact before the stored version is replaced
+	warning := g.impactWarning(1, yamlContent)
+
 	// Store with validation (ensures CUE validation always happens)
 	storedID, err := g.StoreValidatedYAML(1, yamlContent)
 	if err != nil {
@@ -143,6 +185,8 @@ func (g *GemaraAuthoringTools) handleStoreLayer1YAML(_ context.Context, request
 	result += fmt.Sprintf("- CUE Validation: ✅ PASSED\n")
 	result += fmt.Sprintf("\nUse get_layer1_guidance with ID '%s' to retrieve full details.\n", storedID)
 	result += fmt.Sprintf("Use list_layer1_guidance to see all available guidance documents.\n")
+	result += warning
+	result += g.fidelityWarning(1, yamlContent)
 
 	return mcp.NewToolResultText(result), nil
 }
@@ -283,6 +327,9 @@ func (g *GemaraAuthoringTools) handleSearchLayer1Guidance(_ context.Context, req
 			filterParts = append(filterParts, fmt.Sprintf("providers %v", providers))
 		}
 		filterMsg := strings.Join(filterParts, ", ")
+		if outputFormat == "json" {
+			return mcp.NewToolResultText("[]"), nil
+		}
 		return mcp.NewToolResultText(fmt.Sprintf("No Layer 1 Guidance documents found matching %s.\n\nUse list_layer1_guidance to see all available guidance documents.", filterMsg)), nil
 	}
 
@@ -333,3 +380,219 @@ func (g *GemaraAuthoringTools) handleSearchLayer1Guidance(_ context.Context, req
 
 	return mcp.NewToolResultText(result), nil
 }
+
+// guidelinePartView presents a single guideline part together with the context
+// inherited from its parent guideline, so it can be cited on its own
+type guidelinePartView struct {
+	Id                string                `json:"id" yaml:"id"`
+	Title             string                `json:"title,omitempty" yaml:"title,omitempty"`
+	Text              string                `json:"text" yaml:"text"`
+	Recommendations   []string              `json:"recommendations,omitempty" yaml:"recommendations,omitempty"`
+	GuidelineId       string                `json:"guideline_id" yaml:"guideline_id"`
+	GuidelineTitle    string                `json:"guideline_title" yaml:"guideline_title"`
+	Objective         string                `json:"objective,omitempty" yaml:"objective,omitempty"`
+	Rationale         *gemara.Rationale     `json:"rationale,omitempty" yaml:"rationale,omitempty"`
+	SeeAlso           []string              `json:"see-also,omitempty" yaml:"see-also,omitempty"`
+	GuidelineMappings []gemara.MultiMapping `json:"guideline-mappings,omitempty" yaml:"guideline-mappings,omitempty"`
+	PrincipleMappings []gemara.MultiMapping `json:"principle-mappings,omitempty" yaml:"principle-mappings,omitempty"`
+}
+
+// handleListLayer1Guidelines lists individual guidelines and their parts across Layer 1 Guidance documents
+func (g *GemaraAuthoringTools) handleListLayer1Guidelines(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	guidanceID := request.GetString("guidance_id", "")
+	familyID := request.GetString("family", "")
+	outputFormat := request.GetString("output_format", "yaml")
+
+	// Rescan storage to discover new artifacts
+	if g.storage != nil {
+		if err := g.storage.Rescan(); err != nil {
+			slog.Warn("Failed to rescan storage for new artifacts", "error", err)
+		}
+	}
+
+	type guidelineInfo struct {
+		guidanceID string
+		guideline  gemara.Guideline
+	}
+	var allGuidelines []guidelineInfo
+
+	for _, entry := range g.getLayerEntries(consts.Layer1) {
+		if guidanceID != "" && entry.ID != guidanceID {
+			continue
+		}
+		guidance := g.loadLayer1Guidance(entry.ID)
+		if guidance == nil {
+			continue
+		}
+		for _, guideline := range guidance.Guidelines {
+			if familyID != "" && guideline.Family != familyID {
+				continue
+			}
+			allGuidelines = append(allGuidelines, guidelineInfo{
+				guidanceID: entry.ID,
+				guideline:  guideline,
+			})
+		}
+	}
+
+	if len(allGuidelines) == 0 {
+		var filterParts []string
+		if guidanceID != "" {
+			filterParts = append(filterParts, fmt.Sprintf("guidance '%s'", guidanceID))
+		}
+		if familyID != "" {
+			filterParts = append(filterParts, fmt.Sprintf("family '%s'", familyID))
+		}
+		filterMsg := ""
+		if len(filterParts) > 0 {
+			filterMsg = " for " + strings.Join(filterParts, ", ")
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("No Layer 1 guidelines found%s.\n\nUse list_layer1_guidance to see all available guidance documents.", filterMsg)), nil
+	}
+
+	if outputFormat == "json" {
+		guidelinesJSON := make([]map[string]interface{}, len(allGuidelines))
+		for i, gi := range allGuidelines {
+			partIDs := make([]string, len(gi.guideline.Statements))
+			for j, part := range gi.guideline.Statements {
+				partIDs[j] = part.Id
+			}
+			guidelinesJSON[i] = map[string]interface{}{
+				"guideline_id": gi.guideline.Id,
+				"title":        gi.guideline.Title,
+				"objective":    gi.guideline.Objective,
+				"guidance_id":  gi.guidanceID,
+				"family_id":    gi.guideline.Family,
+				"part_ids":     partIDs,
+			}
+		}
+		output, err := marshalOutput(guidelinesJSON, outputFormat)
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal JSON: %v", err), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}
+
+	result := "# Available Layer 1 Guidelines\n\n"
+	result += fmt.Sprintf("Total: %d guideline(s)", len(allGuidelines))
+	if familyID != "" {
+		result += fmt.Sprintf(" (filtered by family: %s)", familyID)
+	}
+	result += "\n\n"
+
+	// Group by guidance document, preserving document order
+	var guidanceOrder []string
+	guidanceMap := make(map[string][]guidelineInfo)
+	for _, gi := range allGuidelines {
+		if _, seen := guidanceMap[gi.guidanceID]; !seen {
+			guidanceOrder = append(guidanceOrder, gi.guidanceID)
+		}
+		guidanceMap[gi.guidanceID] = append(guidanceMap[gi.guidanceID], gi)
+	}
+
+	for _, gid := range guidanceOrder {
+		guidance := g.layer1Guidance[gid]
+		result += fmt.Sprintf("## Guidance: %s\n", guidance.Title)
+		result += fmt.Sprintf("- **Guidance ID**: `%s`\n", gid)
+		result += fmt.Sprintf("- **Guidelines**: %d\n\n", len(guidanceMap[gid]))
+
+		for _, gi := range guidanceMap[gid] {
+			result += fmt.Sprintf("### %s (`%s`)\n", gi.guideline.Title, gi.guideline.Id)
+			if gi.guideline.Family != "" {
+				result += fmt.Sprintf("- **Family**: %s\n", gi.guideline.Family)
+			}
+			if gi.guideline.Objective != "" {
+				result += fmt.Sprintf("- **Objective**: %s\n", gi.guideline.Objective)
+			}
+			if len(gi.guideline.Statements) > 0 {
+				result += "- **Parts**: "
+				for i, part := range gi.guideline.Statements {
+					if i > 0 {
+						result += ", "
+					}
+					result += fmt.Sprintf("`%s`", part.Id)
+				}
+				result += "\n"
+			}
+			result += "\n"
+		}
+	}
+
+	result += "\nUse `get_layer1_guideline` with a guideline_id (or part ID) to get full details.\n"
+
+	return mcp.NewToolResultText(result), nil
+}
+
+// handleGetLayer1Guideline gets a single guideline or guideline part by its ID
+func (g *GemaraAuthoringTools) handleGetLayer1Guideline(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	guidelineID := request.GetString("guideline_id", "")
+	guidanceID := request.GetString("guidance_id", "")
+	outputFormat := request.GetString("output_format", "yaml")
+
+	if guidelineID == "" {
+		return mcp.NewToolResultError("guideline_id is required"), nil
+	}
+
+	foundGuidanceID, guideline, part := g.findGuideline(guidanceID, guidelineID)
+	if guideline == nil {
+		return mcp.NewToolResultErrorf("Guideline with ID '%s' not found. Use list_layer1_guidelines to see available guidelines.", guidelineID), nil
+	}
+
+	header := fmt.Sprintf("Guidance: %s\nFamily: %s\n", foundGuidanceID, guideline.Family)
+
+	if part == nil {
+		guidelineOutput, err := marshalOutput(guideline, outputFormat)
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal: %v", err), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("%s\n%s", header, guidelineOutput)), nil
+	}
+
+	view := guidelinePartView{
+		Id:                part.Id,
+		Title:             part.Title,
+		Text:              part.Text,
+		Recommendations:   part.Recommendations,
+		GuidelineId:       guideline.Id,
+		GuidelineTitle:    guideline.Title,
+		Objective:         guideline.Objective,
+		Rationale:         guideline.Rationale,
+		SeeAlso:           guideline.SeeAlso,
+		GuidelineMappings: guideline.GuidelineMappings,
+		PrincipleMappings: guideline.PrincipleMappings,
+	}
+	partOutput, err := marshalOutput(view, outputFormat)
+	if err != nil {
+		return mcp.NewToolResultErrorf("failed to marshal: %v", err), nil
+	}
+	header += fmt.Sprintf("Guideline: %s\n", guideline.Id)
+
+	return mcp.NewToolResultText(fmt.Sprintf("%s\n%s", header, partOutput)), nil
+}
+
+// findGuideline locates a guideline or guideline part by ID across Layer 1 Guidance documents.
+// If guidanceID is set, only that document is searched. When the ID refers to a guideline part,
+// the parent guideline is returned along with the part.
+func (g *GemaraAuthoringTools) findGuideline(guidanceID, guidelineID string) (string, *gemara.Guideline, *gemara.Statement) {
+	for _, entry := range g.getLayerEntries(consts.Layer1) {
+		if guidanceID != "" && entry.ID != guidanceID {
+			continue
+		}
+		guidance := g.loadLayer1Guidance(entry.ID)
+		if guidance == nil {
+			continue
+		}
+		for i := range guidance.Guidelines {
+			guideline := &guidance.Guidelines[i]
+			if guideline.Id == guidelineID {
+				return entry.ID, guideline, nil
+			}
+			for j := range guideline.Statements {
+				if guideline.Statements[j].Id == guidelineID {
+					return entry.ID, guideline, &guideline.Statements[j]
+				}
+			}
+		}
+	}
+	return "", nil, nil
+}
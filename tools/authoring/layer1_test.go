@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testMappingTools returns tools with a guidance document and two catalogs that map to it. The
+// catalogs reuse control IDs and spell reference and entry IDs in a different case than the guidance.
+func testMappingTools() *GemaraAuthoringTools {
+	guidance := &gemara.GuidanceDocument{
+		Title:    "Test Guidance",
+		Metadata: gemara.Metadata{Id: "GUIDE"},
+		Guidelines: []gemara.Guideline{
+			{
+				Id:         "G-1",
+				Title:      "First Guideline",
+				Statements: []gemara.Statement{{Id: "G-1.a", Title: "First Part"}},
+				GuidelineMappings: []gemara.MultiMapping{
+					{ReferenceId: "NIST", Entries: []gemara.MappingEntry{{ReferenceId: "CA-7", Strength: 6}}},
+				},
+			},
+			{Id: "G-2", Title: "Second Guideline"},
+			{Id: "G-3", Title: "Third Guideline"},
+		},
+	}
+	mapping := func(referenceID string, entries ...gemara.MappingEntry) []gemara.MultiMapping {
+		return []gemara.MultiMapping{{ReferenceId: referenceID, Entries: entries, Remarks: "mapping remarks"}}
+	}
+	first := &gemara.Catalog{
+		Title:    "First Catalog",
+		Metadata: gemara.Metadata{Id: "CAT-A"},
+		Controls: []gemara.Control{
+			{Id: "CTL-1", Title: "Monitoring", GuidelineMappings: mapping("guide",
+				gemara.MappingEntry{ReferenceId: "g-1", Strength: 4},
+				gemara.MappingEntry{ReferenceId: "G-1.A", Strength: 7, Remarks: "entry remarks"},
+			)},
+			{Id: "CTL-2", Title: "Unknown Entry", GuidelineMappings: mapping("GUIDE",
+				gemara.MappingEntry{ReferenceId: "G-9", Strength: 9},
+			)},
+			{Id: "CTL-3", Title: "Other Reference", GuidelineMappings: mapping("NIST",
+				gemara.MappingEntry{ReferenceId: "ca-7", Strength: 8},
+			)},
+		},
+	}
+	second := &gemara.Catalog{
+		Title:    "Second Catalog",
+		Metadata: gemara.Metadata{Id: "CAT-B"},
+		Controls: []gemara.Control{
+			{Id: "CTL-1", Title: "Logging", GuidelineMappings: mapping("GUIDE",
+				gemara.MappingEntry{ReferenceId: "G-1", Strength: 2},
+			)},
+		},
+	}
+	return &GemaraAuthoringTools{
+		layer1Guidance: map[string]*gemara.GuidanceDocument{"GUIDE": guidance},
+		layer2Catalogs: map[string]*gemara.Catalog{"CAT-A": first, "CAT-B": second},
+		layer3Policies: map[string]*gemara.Policy{},
+	}
+}
+
+func TestFindGuideline(t *testing.T) {
+	g := testMappingTools()
+	// A second document reuses the guideline ID G-2
+	g.layer1Guidance["OTHER"] = &gemara.GuidanceDocument{
+		Title:      "Other Guidance",
+		Metadata:   gemara.Metadata{Id: "OTHER"},
+		Guidelines: []gemara.Guideline{{Id: "G-2", Title: "Other Second Guideline"}},
+	}
+
+	tests := []struct {
+		name        string
+		guidanceID  string
+		guidelineID string
+		wantGuide   string
+		wantTitle   string
+		wantPart    string
+	}{
+		{name: "guideline", guidanceID: "GUIDE", guidelineID: "G-1", wantGuide: "GUIDE", wantTitle: "First Guideline"},
+		{name: "part returns its guideline", guidelineID: "G-1.a", wantGuide: "GUIDE", wantTitle: "First Guideline", wantPart: "G-1.a"},
+		{name: "colliding ID in the named document", guidanceID: "OTHER", guidelineID: "G-2", wantGuide: "OTHER", wantTitle: "Other Second Guideline"},
+		{name: "colliding ID in the other document", guidanceID: "GUIDE", guidelineID: "G-2", wantGuide: "GUIDE", wantTitle: "Second Guideline"},
+		{name: "guideline outside the named document", guidanceID: "OTHER", guidelineID: "G-1"},
+		{name: "unknown ID", guidelineID: "G-9"},
+		{name: "IDs are case sensitive", guidelineID: "g-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guidanceID, guideline, part := g.findGuideline(tt.guidanceID, tt.guidelineID)
+			assert.Equal(t, tt.wantGuide, guidanceID)
+			if tt.wantTitle == "" {
+				assert.Nil(t, guideline)
+				return
+			}
+			require.NotNil(t, guideline)
+			assert.Equal(t, tt.wantTitle, guideline.Title)
+			if tt.wantPart == "" {
+				assert.Nil(t, part)
+			} else {
+				require.NotNil(t, part)
+				assert.Equal(t, tt.wantPart, part.Id)
+			}
+		})
+	}
+}
+
+func TestListLayer1GuidelinesJSON(t *testing.T) {
+	g := testMappingTools()
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"guidance_id": "GUIDE", "output_format": "json"}
+	result, err := g.handleListLayer1Guidelines(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var guidelines []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &guidelines))
+	require.Len(t, guidelines, 3)
+	assert.Equal(t, map[string]any{
+		"guideline_id": "G-1",
+		"title":        "First Guideline",
+		"objective":    "",
+		"guidance_id":  "GUIDE",
+		"family_id":    "",
+		"part_ids":     []any{"G-1.a"},
+	}, guidelines[0])
+}
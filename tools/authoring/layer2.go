@@ -16,6 +16,7 @@ import (
 func (g *GemaraAuthoringTools) handleListLayer2Controls(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	_ = request.GetString("technology", "") // Technology filtering not yet implemented for Gemara types
 	layer1Ref := request.GetString("layer1_reference", "")
+	applicability := request.GetString("applicability", "")
 	outputFormat := request.GetString("output_format", "yaml")
 
 	// Rescan storage to discover new artifacts
@@ -41,7 +42,10 @@ func (g *GemaraAuthoringTools) handleListLayer2Controls(ctx context.Context, req
 	}
 
 	if len(catalogEntries) == 0 {
-		return mcp.NewToolResultText("No Layer 2 Controls available.\n\nUse store_layer2_yaml to store controls."), nil
+		if outputFormat == "json" {
+			return mcp.NewToolResultText("[]"), nil
+		}
+		return mcp.NewToolResultText("No Layer 2 Controls available.\n\nUse store_layer2_yaml to store controls.\n" + g.quarantineNote(2)), nil
 	}
 
 	// Collect all controls from catalogs with filtering
@@ -85,6 +89,10 @@ func (g *GemaraAuthoringTools) handleListLayer2Controls(ctx context.Context, req
 					continue
 				}
 			}
+			// Filter by applicability category if specified
+			if applicability != "" && len(g.applicableRequirements(control, catalog.Metadata.ApplicabilityCategories, applicability)) == 0 {
+				continue
+			}
 			allControls = append(allControls, controlInfo{
 				catalogID: catalogEntry.ID,
 				familyID:  control.Family,
@@ -94,11 +102,17 @@ func (g *GemaraAuthoringTools) handleListLayer2Controls(ctx context.Context, req
 	}
 
 	if len(allControls) == 0 {
+		if outputFormat == "json" {
+			return mcp.NewToolResultText("[]"), nil
+		}
 		filterMsg := ""
 		if layer1Ref != "" {
 			filterMsg += fmt.Sprintf(" referencing Layer 1 guidance '%s'", layer1Ref)
 		}
-		return mcp.NewToolResultText(fmt.Sprintf("No Layer 2 Controls found%s.\n\nTry removing filters or use store_layer2_yaml to store new controls.", filterMsg)), nil
+		if applicability != "" {
+			filterMsg += fmt.Sprintf(" applicable to '%s'", applicability)
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("No Layer 2 Controls found%s.\n\nTry removing filters or use store_layer2_yaml to store new controls.\n%s", filterMsg, g.quarantineNote(2))), nil
 	}
 
 	var output string
@@ -125,6 +139,9 @@ func (g *GemaraAuthoringTools) handleListLayer2Controls(ctx context.Context, req
 		if layer1Ref != "" {
 			result += fmt.Sprintf(" (filtered by Layer 1 reference: %s)", layer1Ref)
 		}
+		if applicability != "" {
+			result += fmt.Sprintf(" (filtered by applicability: %s)", applicability)
+		}
 		result += "\n\n"
 
 		// Group by catalog
@@ -161,6 +178,7 @@ func (g *GemaraAuthoringTools) handleListLayer2Controls(ctx context.Context, req
 
 		result += "\nUse `get_layer2_control` with a control_id to get full details.\n"
 		result += "Use these control IDs in `layer2_controls` when creating Layer 3 policies.\n"
+		result += g.quarantineNote(2)
 		output = result
 	}
 
@@ -197,6 +215,9 @@ func (g *GemaraAuthoringTools) handleGetLayer2Control(ctx context.Context, reque
 		return mcp.NewToolResultErrorf("Control with ID '%s' not found. Use list_layer2_controls to see available controls.", controlID), nil
 	}
 
+	if outputFormat == outputOriginal {
+		return g.originalArtifact(2, catalogID), nil
+	}
 	controlOutput, err := marshalOutput(foundControl, outputFormat)
 	if err != nil {
 		return mcp.NewToolResultErrorf("failed to marshal: %v", err), nil
@@ -216,11 +237,12 @@ func (g *GemaraAuthoringTools) handleSearchLayer2Controls(ctx context.Context, r
 	boundaries := g.extractStringArray(request, "boundaries")
 	technologies := g.extractStringArray(request, "technologies")
 	providers := g.extractStringArray(request, "providers")
+	applicability := request.GetString("applicability", "")
 	outputFormat := request.GetString("output_format", "yaml")
 
 	// Allow empty search_term if other filters are provided
-	if searchTerm == "" && technology == "" && layer1Ref == "" && len(boundaries) == 0 && len(technologies) == 0 && len(providers) == 0 {
-		return mcp.NewToolResultError("search_term is required, or provide at least one filter (technology, layer1_reference, boundaries, technologies, providers)"), nil
+	if searchTerm == "" && technology == "" && layer1Ref == "" && applicability == "" && len(boundaries) == 0 && len(technologies) == 0 && len(providers) == 0 {
+		return mcp.NewToolResultError("search_term is required, or provide at least one filter (technology, layer1_reference, applicability, boundaries, technologies, providers)"), nil
 	}
 
 	searchTermLower := strings.ToLower(searchTerm)
@@ -287,9 +309,14 @@ func (g *GemaraAuthoringTools) handleSearchLayer2Controls(ctx context.Context, r
 				// For now, we'll include all controls if technology filter is not precise
 			}
 
+			// Filter by applicability category if specified
+			if applicability != "" && len(g.applicableRequirements(control, catalog.Metadata.ApplicabilityCategories, applicability)) == 0 {
+				continue
+			}
+
 			// Apply scoping filters if provided
 			if len(boundaries) > 0 || len(technologies) > 0 || len(providers) > 0 {
-				if !g.matchesLayer2Applicability(control, technologies, boundaries, providers) {
+				if !g.matchesLayer2Applicability(control, catalog.Metadata.ApplicabilityCategories, technologies, boundaries, providers) {
 					continue
 				}
 			}
@@ -329,6 +356,9 @@ func (g *GemaraAuthoringTools) handleSearchLayer2Controls(ctx context.Context, r
 		if technology != "" {
 			filterParts = append(filterParts, fmt.Sprintf("technology '%s'", technology))
 		}
+		if applicability != "" {
+			filterParts = append(filterParts, fmt.Sprintf("applicability '%s'", applicability))
+		}
 		if len(boundaries) > 0 {
 			filterParts = append(filterParts, fmt.Sprintf("boundaries %v", boundaries))
 		}
@@ -339,6 +369,9 @@ func (g *GemaraAuthoringTools) handleSearchLayer2Controls(ctx context.Context, r
 			filterParts = append(filterParts, fmt.Sprintf("providers %v", providers))
 		}
 		filterMsg := strings.Join(filterParts, ", ")
+		if outputFormat == "json" {
+			return mcp.NewToolResultText("[]"), nil
+		}
 		return mcp.NewToolResultText(fmt.Sprintf("No controls found matching %s.\n\nTry different filters or use list_layer2_controls to see all available controls.", filterMsg)), nil
 	}
 
@@ -373,6 +406,9 @@ func (g *GemaraAuthoringTools) handleSearchLayer2Controls(ctx context.Context, r
 		if technology != "" {
 			filterParts = append(filterParts, fmt.Sprintf("technology: %s", technology))
 		}
+		if applicability != "" {
+			filterParts = append(filterParts, fmt.Sprintf("applicability: %s", applicability))
+		}
 		if len(boundaries) > 0 {
 			filterParts = append(filterParts, fmt.Sprintf("boundaries: %v", boundaries))
 		}
@@ -413,11 +449,14 @@ func (g *GemaraAuthoringTools) handleSearchLayer2Controls(ctx context.Context, r
 // handleStoreLayer2YAML stores raw YAML content with CUE validation
 // This is the preferred method for storing Layer 2 artifacts as it preserves all YAML content without data loss
 func (g *GemaraAuthoringTools) handleStoreLayer2YAML(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	yamlContent := request.GetString("yaml_content", "")
-	if yamlContent == "" {
-		return mcp.NewToolResultError("yaml_content is required"), nil
+	yamlContent, err := artifactContent(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
+	// Check downstream impact before the stored version is replaced
+	warning := g.impactWarning(2, yamlContent)
+
 	// Store with validation (ensures CUE validation always happens)
 	storedID, err := g.StoreValidatedYAML(2, yamlContent)
 	if err != nil {
@@ -436,6 +475,8 @@ func (g *GemaraAuthoringTools) handleStoreLayer2YAML(ctx context.Context, reques
 	result += fmt.Sprintf("- CUE Validation: ✅ PASSED\n")
 	result += fmt.Sprintf("\nUse get_layer2_control with catalog ID '%s' to retrieve full details.\n", storedID)
 	result += fmt.Sprintf("Use list_layer2_controls to see all available controls.\n")
+	result += warning
+	result += g.fidelityWarning(2, yamlContent)
 
 	return mcp.NewToolResultText(result), nil
 }
@@ -40,7 +40,10 @@ func (g *GemaraAuthoringTools) handleListLayer3Policies(ctx context.Context, req
 
 	totalCount := len(entries)
 	if totalCount == 0 {
-		return mcp.NewToolResultText("No Layer 3 Policy documents available.\n\nUse store_layer3_yaml to store policies."), nil
+		if outputFormat == "json" {
+			return mcp.NewToolResultText("[]"), nil
+		}
+		return mcp.NewToolResultText("No Layer 3 Policy documents available.\n\nUse store_layer3_yaml to store policies.\n" + g.quarantineNote(3)), nil
 	}
 
 	if outputFormat == "json" {
@@ -105,6 +108,7 @@ func (g *GemaraAuthoringTools) handleListLayer3Policies(ctx context.Context, req
 	}
 
 	result += "\nUse `get_layer3_policy` with a policy_id to get full details.\n"
+	result += g.quarantineNote(3)
 
 	return mcp.NewToolResultText(result), nil
 }
@@ -136,6 +140,9 @@ func (g *GemaraAuthoringTools) handleGetLayer3Policy(ctx context.Context, reques
 		}
 	}
 
+	if outputFormat == outputOriginal {
+		return g.originalArtifact(3, policyID), nil
+	}
 	output, err := marshalOutput(policy, outputFormat)
 	if err != nil {
 		return mcp.NewToolResultErrorf("failed to marshal: %v", err), nil
@@ -201,6 +208,9 @@ func (g *GemaraAuthoringTools) handleSearchLayer3Policies(ctx context.Context, r
 	}
 
 	if len(matches) == 0 {
+		if outputFormat == "json" {
+			return mcp.NewToolResultText("[]"), nil
+		}
 		return mcp.NewToolResultText(fmt.Sprintf("No Layer 3 Policy documents found matching '%s'.\n\nUse list_layer3_policies to see all available policies.", searchTerm)), nil
 	}
 
@@ -234,9 +244,9 @@ func (g *GemaraAuthoringTools) handleSearchLayer3Policies(ctx context.Context, r
 // handleStoreLayer3YAML stores raw YAML content with CUE validation
 // This is the preferred method for storing Layer 3 artifacts as it preserves all YAML content without data loss
 func (g *GemaraAuthoringTools) handleStoreLayer3YAML(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	yamlContent := request.GetString("yaml_content", "")
-	if yamlContent == "" {
-		return mcp.NewToolResultError("yaml_content is required"), nil
+	yamlContent, err := artifactContent(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	// Store with validation (ensures CUE validation always happens)
@@ -257,6 +267,7 @@ func (g *GemaraAuthoringTools) handleStoreLayer3YAML(ctx context.Context, reques
 	result += fmt.Sprintf("- CUE Validation: ✅ PASSED\n")
 	result += fmt.Sprintf("\nUse get_layer3_policy with ID '%s' to retrieve full details.\n", storedID)
 	result += fmt.Sprintf("Use list_layer3_policies to see all available policies.\n")
+	result += g.fidelityWarning(3, yamlContent)
 
 	return mcp.NewToolResultText(result), nil
 }
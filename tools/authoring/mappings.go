@@ -0,0 +1,189 @@
+package authoring
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+)
+
+// mappingMatch describes a Layer 1 guideline or Layer 2 control that maps to an external reference entry
+type mappingMatch struct {
+	Layer        int    `json:"layer"`
+	ArtifactID   string `json:"artifact_id"`
+	ElementID    string `json:"element_id"`
+	ElementTitle string `json:"element_title"`
+	ReferenceID  string `json:"reference_id"`
+	EntryID      string `json:"entry_id"`
+	Strength     int64  `json:"strength,omitempty"`
+	Remarks      string `json:"remarks,omitempty"`
+}
+
+// handleGetReverseMappings lists every Layer 1 guideline and Layer 2 control that maps to a given reference entry
+func (g *GemaraAuthoringTools) handleGetReverseMappings(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	referenceID := request.GetString("reference_id", "")
+	entryID := request.GetString("entry_id", "")
+	outputFormat := request.GetString("output_format", "yaml")
+
+	if referenceID == "" {
+		return mcp.NewToolResultError("reference_id is required"), nil
+	}
+
+	matches := g.collectReverseMappings(referenceID, entryID)
+
+	target := fmt.Sprintf("'%s'", referenceID)
+	if entryID != "" {
+		target = fmt.Sprintf("'%s' / '%s'", referenceID, entryID)
+	}
+
+	if len(matches) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No Layer 1 guidelines or Layer 2 controls map to %s.\n\nUse get_layer2_guideline_mappings to see the references of a specific control.", target)), nil
+	}
+
+	if outputFormat == "json" {
+		result := map[string]interface{}{
+			"reference_id": referenceID,
+			"entry_id":     entryID,
+			"mappings":     matches,
+		}
+		output, err := marshalOutput(result, "json")
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal JSON: %v", err), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("# Reverse Mappings for %s\n\n", target))
+	result.WriteString(fmt.Sprintf("Found %d mapping(s).\n\n", len(matches)))
+
+	// Group by reference entry so each entry reads as "what satisfies X?"
+	var entryOrder []string
+	entryMap := make(map[string][]mappingMatch)
+	for _, m := range matches {
+		if _, seen := entryMap[m.EntryID]; !seen {
+			entryOrder = append(entryOrder, m.EntryID)
+		}
+		entryMap[m.EntryID] = append(entryMap[m.EntryID], m)
+	}
+
+	for _, entry := range entryOrder {
+		result.WriteString(fmt.Sprintf("## %s `%s`\n\n", referenceID, entry))
+		for _, layer := range []int{consts.Layer1, consts.Layer2} {
+			var layerMatches []mappingMatch
+			for _, m := range entryMap[entry] {
+				if m.Layer == layer {
+					layerMatches = append(layerMatches, m)
+				}
+			}
+			if len(layerMatches) == 0 {
+				continue
+			}
+			if layer == consts.Layer1 {
+				result.WriteString("### Layer 1 Guidelines\n\n")
+			} else {
+				result.WriteString("### Layer 2 Controls\n\n")
+			}
+			for _, m := range layerMatches {
+				result.WriteString(fmt.Sprintf("- **%s** (`%s`) - %s", m.ElementTitle, m.ElementID, m.ArtifactID))
+				if m.Strength > 0 {
+					result.WriteString(fmt.Sprintf(" (strength: %d)", m.Strength))
+				}
+				if m.Remarks != "" {
+					result.WriteString(fmt.Sprintf(" - %s", m.Remarks))
+				}
+				result.WriteString("\n")
+			}
+			result.WriteString("\n")
+		}
+	}
+
+	result.WriteString("Use `get_layer1_guideline` or `get_layer2_control` to see full details of a mapped element.\n")
+
+	return mcp.NewToolResultText(result.String()), nil
+}
+
+// collectReverseMappings finds all Layer 1 guidelines and Layer 2 controls whose guideline mappings
+// point at the given reference ID. If entryID is empty, all entries of the reference are returned.
+// Results are sorted by entry, layer, element ID, and artifact ID.
+func (g *GemaraAuthoringTools) collectReverseMappings(referenceID, entryID string) []mappingMatch {
+	var matches []mappingMatch
+
+	for _, entry := range g.getLayerEntries(consts.Layer1) {
+		guidance := g.loadLayer1Guidance(entry.ID)
+		if guidance == nil {
+			continue
+		}
+		for _, guideline := range guidance.Guidelines {
+			for _, m := range matchMappings(guideline.GuidelineMappings, referenceID, entryID) {
+				m.Layer = consts.Layer1
+				m.ArtifactID = entry.ID
+				m.ElementID = guideline.Id
+				m.ElementTitle = guideline.Title
+				matches = append(matches, m)
+			}
+		}
+	}
+
+	for _, entry := range g.getLayerEntries(consts.Layer2) {
+		catalog := g.loadLayer2Catalog(entry.ID)
+		if catalog == nil {
+			continue
+		}
+		for _, control := range catalog.Controls {
+			for _, m := range matchMappings(control.GuidelineMappings, referenceID, entryID) {
+				m.Layer = consts.Layer2
+				m.ArtifactID = entry.ID
+				m.ElementID = control.Id
+				m.ElementTitle = control.Title
+				matches = append(matches, m)
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].EntryID != matches[j].EntryID {
+			return matches[i].EntryID < matches[j].EntryID
+		}
+		if matches[i].Layer != matches[j].Layer {
+			return matches[i].Layer < matches[j].Layer
+		}
+		if matches[i].ElementID != matches[j].ElementID {
+			return matches[i].ElementID < matches[j].ElementID
+		}
+		return matches[i].ArtifactID < matches[j].ArtifactID
+	})
+
+	return matches
+}
+
+// matchMappings returns the mapping entries that target the given reference and, optionally, entry ID.
+// Entry remarks take precedence over the remarks of the enclosing mapping.
+func matchMappings(mappings []gemara.MultiMapping, referenceID, entryID string) []mappingMatch {
+	var matches []mappingMatch
+	for _, mapping := range mappings {
+		if !strings.EqualFold(mapping.ReferenceId, referenceID) {
+			continue
+		}
+		for _, entry := range mapping.Entries {
+			if entryID != "" && !strings.EqualFold(entry.ReferenceId, entryID) {
+				continue
+			}
+			remarks := entry.Remarks
+			if remarks == "" {
+				remarks = mapping.Remarks
+			}
+			matches = append(matches, mappingMatch{
+				ReferenceID: mapping.ReferenceId,
+				EntryID:     entry.ReferenceId,
+				Strength:    entry.Strength,
+				Remarks:     remarks,
+			})
+		}
+	}
+	return matches
+}
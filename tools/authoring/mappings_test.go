@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"testing"
+
+	"github.com/ossf/gemara"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectReverseMappings(t *testing.T) {
+	g := testMappingTools()
+	// A third catalog reuses control ID CTL-1 and spells entry g-1 exactly like CAT-A
+	g.layer2Catalogs["CAT-0"] = &gemara.Catalog{
+		Title:    "Zeroth Catalog",
+		Metadata: gemara.Metadata{Id: "CAT-0"},
+		Controls: []gemara.Control{{Id: "CTL-1", Title: "Auditing", GuidelineMappings: []gemara.MultiMapping{
+			{ReferenceId: "GUIDE", Entries: []gemara.MappingEntry{{ReferenceId: "g-1"}}},
+		}}},
+	}
+
+	tests := []struct {
+		name        string
+		referenceID string
+		entryID     string
+		want        []mappingMatch
+	}{
+		{
+			name:        "reference and entry match regardless of case",
+			referenceID: "nist",
+			entryID:     "CA-7",
+			want: []mappingMatch{
+				{Layer: 1, ArtifactID: "GUIDE", ElementID: "G-1", ElementTitle: "First Guideline", ReferenceID: "NIST", EntryID: "CA-7", Strength: 6},
+				{Layer: 2, ArtifactID: "CAT-A", ElementID: "CTL-3", ElementTitle: "Other Reference", ReferenceID: "NIST", EntryID: "ca-7", Strength: 8, Remarks: "mapping remarks"},
+			},
+		},
+		{
+			name:        "colliding control IDs are ordered by catalog",
+			referenceID: "GUIDE",
+			entryID:     "G-1",
+			want: []mappingMatch{
+				{Layer: 2, ArtifactID: "CAT-B", ElementID: "CTL-1", ElementTitle: "Logging", ReferenceID: "GUIDE", EntryID: "G-1", Strength: 2, Remarks: "mapping remarks"},
+				{Layer: 2, ArtifactID: "CAT-0", ElementID: "CTL-1", ElementTitle: "Auditing", ReferenceID: "GUIDE", EntryID: "g-1"},
+				{Layer: 2, ArtifactID: "CAT-A", ElementID: "CTL-1", ElementTitle: "Monitoring", ReferenceID: "guide", EntryID: "g-1", Strength: 4, Remarks: "mapping remarks"},
+			},
+		},
+		{
+			name:        "entry remarks take precedence",
+			referenceID: "GUIDE",
+			entryID:     "g-1.a",
+			want: []mappingMatch{
+				{Layer: 2, ArtifactID: "CAT-A", ElementID: "CTL-1", ElementTitle: "Monitoring", ReferenceID: "guide", EntryID: "G-1.A", Strength: 7, Remarks: "entry remarks"},
+			},
+		},
+		{
+			name:        "entries unknown to the guidance are still reported",
+			referenceID: "GUIDE",
+			entryID:     "G-9",
+			want: []mappingMatch{
+				{Layer: 2, ArtifactID: "CAT-A", ElementID: "CTL-2", ElementTitle: "Unknown Entry", ReferenceID: "GUIDE", EntryID: "G-9", Strength: 9, Remarks: "mapping remarks"},
+			},
+		},
+		{
+			name:        "unknown entry",
+			referenceID: "NIST",
+			entryID:     "AC-2",
+		},
+		{
+			name:        "unknown reference",
+			referenceID: "ISO",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, g.collectReverseMappings(tt.referenceID, tt.entryID))
+		})
+	}
+
+	// Without an entry ID every entry of the reference is returned
+	assert.Len(t, g.collectReverseMappings("GUIDE", ""), 5)
+}
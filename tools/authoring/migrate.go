@@ -0,0 +1,317 @@
+package authoring
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/complytime/gemara-mcp-server/internal/migrate"
+	"github.com/complytime/gemara-mcp-server/storage"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// migrationOutcome is the result of migrating one artifact
+type migrationOutcome struct {
+	Layer      int              `json:"layer"`
+	ArtifactID string           `json:"artifact_id,omitempty"`
+	File       string           `json:"file,omitempty"`
+	From       string           `json:"from"`
+	To         string           `json:"to"`
+	Changes    []migrate.Change `json:"changes"`
+	Valid      bool             `json:"valid"`
+	Errors     []string         `json:"errors,omitempty"`
+	Stored     bool             `json:"stored"`
+	YAML       string           `json:"yaml,omitempty"`
+}
+
+// handleMigrateArtifact upgrades artifacts written against an older Gemara schema version
+func (g *GemaraAuthoringTools) handleMigrateArtifact(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	layer := request.GetInt("layer", 0)
+	artifactID := request.GetString("artifact_id", "")
+	yamlContent := request.GetString("yaml_content", "")
+	all := request.GetBool("all", false)
+	fromVersion := request.GetString("from_version", "")
+	toVersion := request.GetString("to_version", migrate.Current)
+	store := request.GetBool("store", false)
+	outputFormat := request.GetString("output_format", "markdown")
+
+	sources := 0
+	for _, set := range []bool{artifactID != "", yamlContent != "", all} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return mcp.NewToolResultError("provide exactly one of artifact_id, yaml_content, or all=true"), nil
+	}
+	if all && layer != 0 && (layer < consts.Layer1 || layer > consts.Layer3) {
+		return mcp.NewToolResultErrorf("layer must be between %d and %d, or omitted to migrate every layer, got %d", consts.Layer1, consts.Layer3, layer), nil
+	}
+	if !all && (layer < consts.Layer1 || layer > consts.Layer3) {
+		return mcp.NewToolResultErrorf("layer must be between %d and %d, got %d", consts.Layer1, consts.Layer3, layer), nil
+	}
+	if yamlContent != "" && store {
+		return mcp.NewToolResultError("store applies to stored artifacts; use store_layerN_yaml to store migrated content"), nil
+	}
+	if (artifactID != "" || all) && g.storage == nil {
+		return mcp.NewToolResultError("storage not available"), nil
+	}
+
+	if yamlContent != "" {
+		outcome, err := g.migrateContent(layer, yamlContent, fromVersion, toVersion)
+		if err != nil {
+			return mcp.NewToolResultErrorf("Failed to migrate artifact: %v", err), nil
+		}
+		return g.migrationResult([]*migrationOutcome{outcome}, outputFormat, false)
+	}
+
+	g.editMu.Lock()
+	defer g.editMu.Unlock()
+
+	if artifactID != "" {
+		outcome, err := g.migrateStored(layer, artifactID, fromVersion, toVersion, store)
+		if err != nil {
+			return mcp.NewToolResultErrorf("Failed to migrate artifact '%s': %v", artifactID, err), nil
+		}
+		return g.migrationResult([]*migrationOutcome{outcome}, outputFormat, false)
+	}
+
+	layers := []int{consts.Layer1, consts.Layer2, consts.Layer3}
+	if layer != 0 {
+		layers = []int{layer}
+	}
+	var outcomes []*migrationOutcome
+	for _, l := range layers {
+		entries := g.storage.List(l)
+		sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+		for _, entry := range entries {
+			outcome, err := g.migrateStored(l, entry.ID, fromVersion, toVersion, store)
+			if err != nil {
+				outcome = &migrationOutcome{Layer: l, ArtifactID: entry.ID, Errors: []string{err.Error()}}
+			}
+			outcomes = append(outcomes, outcome)
+		}
+		// Files in an older layout often fail to decode and are quarantined instead of indexed
+		for _, file := range g.storageProblems(l) {
+			outcomes = append(outcomes, g.migrateQuarantined(file, fromVersion, toVersion, store))
+		}
+	}
+	return g.migrationResult(outcomes, outputFormat, true)
+}
+
+// migrateContent migrates YAML content and validates the result against the target schema
+func (g *GemaraAuthoringTools) migrateContent(layer int, yamlContent, fromVersion, toVersion string) (*migrationOutcome, error) {
+	result, err := migrate.Migrate(layer, []byte(yamlContent), fromVersion, toVersion)
+	if err != nil {
+		return nil, err
+	}
+	outcome := &migrationOutcome{
+		Layer:   layer,
+		From:    result.From,
+		To:      result.To,
+		Changes: result.Changes,
+		YAML:    result.YAML,
+	}
+	validation := g.infoTools.PerformCUEValidation(result.YAML, layer)
+	outcome.Valid = validation.Valid
+	if validation.Error != "" {
+		outcome.Errors = append(outcome.Errors, validation.Error)
+	}
+	outcome.Errors = append(outcome.Errors, validation.Errors...)
+	return outcome, nil
+}
+
+// migrateStored migrates a stored artifact and, when store is set and the result is valid, writes it back
+// to the file it was loaded from. The caller must hold editMu.
+func (g *GemaraAuthoringTools) migrateStored(layer int, artifactID, fromVersion, toVersion string, store bool) (*migrationOutcome, error) {
+	original, err := g.storedArtifactYAML(layer, artifactID)
+	if err != nil {
+		return nil, err
+	}
+	outcome, err := g.migrateContent(layer, original, fromVersion, toVersion)
+	if err != nil {
+		return nil, err
+	}
+	outcome.ArtifactID = artifactID
+
+	if !store || !outcome.Valid || outcome.YAML == original {
+		return outcome, nil
+	}
+	if migratedID := yamlArtifactID(outcome.YAML); migratedID != artifactID {
+		return nil, fmt.Errorf("migration changed metadata.id from '%s' to '%s'", artifactID, migratedID)
+	}
+	if _, err := g.StoreValidatedYAML(layer, outcome.YAML); err != nil {
+		outcome.Errors = append(outcome.Errors, err.Error())
+		return outcome, nil
+	}
+	g.refreshCachedArtifact(layer, artifactID)
+	outcome.Stored = true
+	return outcome, nil
+}
+
+// migrateQuarantined migrates a file that could not be indexed and, when store is set and the result
+// is valid, writes it back to the same file so that it is indexed. The caller must hold editMu.
+func (g *GemaraAuthoringTools) migrateQuarantined(file *storage.QuarantinedFile, fromVersion, toVersion string, store bool) *migrationOutcome {
+	failed := func(err error) *migrationOutcome {
+		return &migrationOutcome{Layer: file.Layer, File: file.FilePath, Errors: []string{err.Error()}}
+	}
+	quarantine, ok := g.storage.(storage.QuarantineStore)
+	if !ok {
+		return failed(fmt.Errorf("storage does not support repairing files that could not be indexed"))
+	}
+	content, err := quarantine.RetrieveQuarantined(file.Layer, file.FileName)
+	if err != nil {
+		return failed(err)
+	}
+	original, err := storage.ConvertFormat([]byte(content), storage.FormatYAML)
+	if err != nil {
+		return failed(fmt.Errorf("%s: %s", file.Error, err))
+	}
+	outcome, err := g.migrateContent(file.Layer, string(original), fromVersion, toVersion)
+	if err != nil {
+		return failed(fmt.Errorf("%s: %s", file.Error, err))
+	}
+	outcome.File = file.FilePath
+	outcome.ArtifactID = yamlArtifactID(outcome.YAML)
+
+	if !store || !outcome.Valid || outcome.YAML == string(original) {
+		return outcome
+	}
+	artifactID, err := quarantine.RepairQuarantined(file.Layer, file.FileName, outcome.YAML)
+	if err != nil {
+		outcome.Errors = append(outcome.Errors, err.Error())
+		return outcome
+	}
+	g.refreshCachedArtifact(file.Layer, artifactID)
+	outcome.Stored = true
+	return outcome
+}
+
+// migrationResult renders migration outcomes. Bulk results leave out the migrated YAML.
+func (g *GemaraAuthoringTools) migrationResult(outcomes []*migrationOutcome, outputFormat string, bulk bool) (*mcp.CallToolResult, error) {
+	if bulk {
+		for _, outcome := range outcomes {
+			outcome.YAML = ""
+		}
+	}
+	if outputFormat == "json" {
+		output, err := marshalOutput(outcomes, "json")
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal JSON: %v", err), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}
+
+	var result strings.Builder
+	if bulk {
+		result.WriteString(migrationSummaryMarkdown(outcomes))
+	}
+	for _, outcome := range outcomes {
+		if bulk && len(outcome.Changes) == 0 && len(outcome.Errors) == 0 {
+			continue
+		}
+		result.WriteString(outcome.toMarkdown())
+	}
+	return mcp.NewToolResultText(result.String()), nil
+}
+
+// migrationSummaryMarkdown renders one row per artifact of a bulk migration
+func migrationSummaryMarkdown(outcomes []*migrationOutcome) string {
+	var result strings.Builder
+	result.WriteString("## Bulk Migration\n\n")
+	if len(outcomes) == 0 {
+		result.WriteString("No stored artifacts found.\n")
+		return result.String()
+	}
+	result.WriteString("| Layer | Artifact | From | To | Transformations | CUE Validation | Stored |\n")
+	result.WriteString("|-------|----------|------|----|-----------------|----------------|--------|\n")
+	for _, outcome := range outcomes {
+		result.WriteString(fmt.Sprintf("| %d | %s | %s | %s | %d | %s | %s |\n",
+			outcome.Layer, outcome.name(), outcome.From, outcome.To, len(outcome.Changes),
+			validationMark(outcome), storedMark(outcome.Stored)))
+	}
+	result.WriteString("\n")
+	return result.String()
+}
+
+// toMarkdown renders the transformations applied to one artifact and, if present, the migrated YAML
+func (o *migrationOutcome) toMarkdown() string {
+	var result strings.Builder
+	name := "YAML"
+	if o.ArtifactID != "" || o.File != "" {
+		name = fmt.Sprintf("`%s`", o.name())
+	}
+	if o.From == "" {
+		result.WriteString(fmt.Sprintf("## Migration: Layer %d %s\n\n", o.Layer, name))
+		result.WriteString(fmt.Sprintf("❌ %s\n\n", strings.Join(o.Errors, "; ")))
+		return result.String()
+	}
+	result.WriteString(fmt.Sprintf("## Migration: Layer %d %s (%s → %s)\n\n", o.Layer, name, o.From, o.To))
+	result.WriteString(fmt.Sprintf("- Transformations: %d\n", len(o.Changes)))
+	result.WriteString(fmt.Sprintf("- CUE Validation: %s\n", validationMark(o)))
+	switch {
+	case o.Stored:
+		result.WriteString("- Stored: ✅ written back to the artifact's file\n")
+	case (o.ArtifactID != "" || o.File != "") && len(o.Changes) > 0:
+		result.WriteString("- Stored: no (preview); call again with store=true to write the migrated artifact\n")
+	}
+	result.WriteString("\n")
+
+	for _, err := range o.Errors {
+		result.WriteString(fmt.Sprintf("- ❌ %s\n", err))
+	}
+	if len(o.Errors) > 0 {
+		result.WriteString("\n")
+	}
+
+	if len(o.Changes) == 0 {
+		result.WriteString(fmt.Sprintf("The artifact already uses the %s layout; nothing to migrate.\n\n", o.To))
+		return result.String()
+	}
+	result.WriteString("### Transformations\n\n")
+	result.WriteString("| Step | Path | Change |\n")
+	result.WriteString("|------|------|--------|\n")
+	for _, change := range o.Changes {
+		result.WriteString(fmt.Sprintf("| %s | `%s` | %s |\n", change.Step, change.Path, escapeTableCell(change.Detail)))
+	}
+	result.WriteString("\n")
+
+	if o.YAML != "" {
+		result.WriteString("### Migrated YAML\n\n```yaml\n")
+		result.WriteString(o.YAML)
+		result.WriteString("```\n\n")
+	}
+	return result.String()
+}
+
+// name identifies the migrated artifact by its ID, and files that could not be indexed by their file name
+func (o *migrationOutcome) name() string {
+	if o.File != "" {
+		if o.ArtifactID != "" {
+			return fmt.Sprintf("%s (%s)", o.ArtifactID, filepath.Base(o.File))
+		}
+		return filepath.Base(o.File)
+	}
+	return o.ArtifactID
+}
+
+func validationMark(o *migrationOutcome) string {
+	switch {
+	case o.From == "":
+		return "❌ NOT RUN"
+	case o.Valid:
+		return "✅ PASSED"
+	default:
+		return "❌ FAILED"
+	}
+}
+
+func storedMark(stored bool) string {
+	if stored {
+		return "yes"
+	}
+	return "no"
+}
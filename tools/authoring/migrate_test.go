@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/complytime/gemara-mcp-server/internal/schematest"
+	"github.com/complytime/gemara-mcp-server/storage"
+	"github.com/complytime/gemara-mcp-server/tools/info"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const legacyMigrationCatalog = `metadata:
+  id: LEGACY
+  title: Legacy Catalog
+  description: d
+  author: Jane Doe
+control-families:
+  - title: Data Protection
+    description: Protect data
+    controls:
+      - id: C-1
+        title: One
+        objective: o
+        assessment-requirements:
+          - id: C-1.1
+            text: First
+            applicability: [all]
+`
+
+func TestMigrateQuarantinedArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	legacyPath := filepath.Join(dir, "layer2", "legacy.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(legacyPath), 0755))
+	require.NoError(t, os.WriteFile(legacyPath, []byte(legacyMigrationCatalog), 0644))
+	store, err := storage.NewArtifactStorage(dir)
+	require.NoError(t, err)
+	require.Len(t, store.ListProblems(2), 1, "the legacy catalog cannot be decoded and is quarantined")
+
+	g, err := NewGemaraAuthoringToolsWithInfo(store, localInfoTools(t))
+	require.NoError(t, err)
+	migrate := func(arguments map[string]any) []migrationOutcome {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = arguments
+		result, err := g.handleMigrateArtifact(context.Background(), request)
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content[0].(mcp.TextContent).Text)
+		var outcomes []migrationOutcome
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &outcomes))
+		return outcomes
+	}
+
+	// A preview reports the quarantined file without writing it
+	outcomes := migrate(map[string]any{"all": true, "layer": 2, "output_format": "json"})
+	require.Len(t, outcomes, 1)
+	assert.Equal(t, legacyPath, outcomes[0].File)
+	assert.Equal(t, "LEGACY", outcomes[0].ArtifactID)
+	assert.True(t, outcomes[0].Valid, outcomes[0].Errors)
+	assert.False(t, outcomes[0].Stored)
+	assert.Empty(t, store.List(2))
+
+	// Storing writes the migrated catalog back to the same file, which is then indexed
+	outcomes = migrate(map[string]any{"all": true, "layer": 2, "store": true, "output_format": "json"})
+	require.Len(t, outcomes, 1)
+	assert.True(t, outcomes[0].Stored, outcomes[0].Errors)
+	assert.Empty(t, store.ListProblems(2))
+	entries := store.List(2)
+	require.Len(t, entries, 1)
+	assert.Equal(t, legacyPath, entries[0].FilePath)
+	assert.Equal(t, "LEGACY", entries[0].ID)
+	assert.NotNil(t, g.layer2Catalogs["LEGACY"])
+}
+
+// localInfoTools returns info tools that validate against the schemas in schematest, without network access
+func localInfoTools(t *testing.T) *info.GemaraInfoTools {
+	infoTools, err := info.NewGemaraInfoToolsWithClient("main", schematest.Client())
+	require.NoError(t, err)
+	return infoTools
+}
@@ -0,0 +1,906 @@
+package authoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/complytime/gemara-mcp-server/internal/oscal"
+	"github.com/goccy/go-yaml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+)
+
+// gemaraNamespace qualifies the props export_oscal adds for Gemara fields that OSCAL does not define
+const gemaraNamespace = "https://github.com/ossf/gemara/ns/oscal"
+
+// OSCAL models export_oscal produces
+const (
+	oscalModelCatalog             = "catalog"
+	oscalModelProfile             = "profile"
+	oscalModelComponentDefinition = "component-definition"
+	oscalModelAssessmentResults   = "assessment-results"
+)
+
+// OSCALExportOptions selects the artifact to export and how the OSCAL document is written
+type OSCALExportOptions struct {
+	// Layer is the layer of the artifact: 2 (Catalog), 3 (Policy), or 4 (EvaluationLog)
+	Layer int
+	// ArtifactID is the ID of a stored artifact
+	ArtifactID string
+	// Content is the artifact YAML or JSON, used instead of a stored artifact
+	Content []byte
+	// Model is the OSCAL model for Layer 3: profile (default) or component-definition
+	Model string
+	// Format is the serialization of the document: json (default) or yaml
+	Format string
+	// OutputPath is a file the document is written to; when empty it is only returned
+	OutputPath string
+}
+
+// OSCALExportResult is an OSCAL document produced from a Gemara artifact
+type OSCALExportResult struct {
+	Model      string              `json:"model"`
+	Layer      int                 `json:"layer"`
+	ArtifactID string              `json:"artifact_id"`
+	Format     string              `json:"format"`
+	Path       string              `json:"path,omitempty"`
+	Counts     []oscalCount        `json:"counts"`
+	Notes      []string            `json:"notes,omitempty"`
+	Mappings   []oscalFieldMapping `json:"mappings"`
+	Document   string              `json:"document"`
+}
+
+// oscalFieldMapping documents where a Gemara field ends up in an OSCAL model
+type oscalFieldMapping struct {
+	Gemara   string `json:"gemara"`
+	OSCAL    string `json:"oscal"`
+	Fidelity string `json:"fidelity"`
+	Notes    string `json:"notes,omitempty"`
+}
+
+// Fidelity of an OSCAL field mapping
+const (
+	mappingExact       = "exact"
+	mappingLossy       = "lossy"
+	mappingNotExported = "not exported"
+)
+
+// oscalExportMappings is the field mapping table of each exported model
+var oscalExportMappings = map[string][]oscalFieldMapping{
+	oscalModelCatalog: {
+		{"title", "metadata.title", mappingExact, ""},
+		{"metadata.id", "metadata.props[gemara-id]", mappingExact, ""},
+		{"metadata.version / date / description", "metadata.version / last-modified / remarks", mappingExact, "A missing version is written as 1.0"},
+		{"metadata.author", "metadata.parties + responsible-parties[creator]", mappingLossy, "Only name, type, and email are kept"},
+		{"metadata.mapping-references", "back-matter.resources", mappingLossy, "Version becomes a prop; the reference ID is the resource's id prop"},
+		{"metadata.applicability-categories", "-", mappingNotExported, "Category IDs remain in assessment requirement applicability props"},
+		{"families", "groups (class family)", mappingExact, "The description becomes an overview part"},
+		{"controls", "groups[].controls", mappingExact, "Controls whose family is not defined are written at the catalog level"},
+		{"controls[].objective", "controls[].parts[statement]", mappingExact, ""},
+		{"controls[].assessment-requirements", "controls[].controls (class assessment-requirement)", mappingExact, "Text is the statement part, recommendation the guidance part"},
+		{"assessment-requirements[].applicability", "props[applicability]", mappingExact, "Comma-separated"},
+		{"controls[].guideline-mappings", "controls[].links (rel reference)", mappingLossy, "Strength and remarks are dropped"},
+		{"controls[].threat-mappings", "controls[].links (rel threat)", mappingLossy, "Strength and remarks are dropped"},
+		{"threats, capabilities", "-", mappingNotExported, "OSCAL catalogs do not model threats"},
+		{"imported-controls, imported-threats, imported-capabilities", "-", mappingNotExported, ""},
+	},
+	oscalModelProfile: {
+		{"title", "metadata.title", mappingExact, ""},
+		{"metadata", "metadata", mappingLossy, "As for catalogs"},
+		{"contacts", "metadata.parties + responsible-parties", mappingLossy, "RACI roles become role IDs; affiliation and social are dropped"},
+		{"imports.catalogs / imports.guidance", "imports (href to a back-matter resource)", mappingExact, "The resource's id prop is the reference ID"},
+		{"imports[].exclusions", "imports[].exclude-controls", mappingExact, ""},
+		{"imports[].constraints", "modify.alters[].adds[].parts (name constraint)", mappingExact, "Constraints on assessment requirements are added to their control"},
+		{"assessment-requirement-modifications (remove)", "modify.alters[].removes[].by-id", mappingExact, "Requires the imported catalog to be stored"},
+		{"assessment-requirement-modifications (other)", "modify.alters[].adds[].parts (name modification)", mappingLossy, "Applicability and recommendation changes are dropped"},
+		{"imports.policies", "-", mappingNotExported, "Profiles cannot import Gemara policies"},
+		{"scope, implementation-plan, risks, adherence", "-", mappingNotExported, "OSCAL profiles do not describe them"},
+	},
+	oscalModelComponentDefinition: {
+		{"title / metadata.description", "components[].title / description", mappingExact, "One component of type policy"},
+		{"metadata", "metadata", mappingLossy, "As for catalogs"},
+		{"imports.catalogs", "components[].control-implementations[].source", mappingExact, "Only stored catalogs can be resolved"},
+		{"effective controls", "control-implementations[].implemented-requirements", mappingExact, "Exclusions, scope, and modifications are applied first"},
+		{"constraints", "implemented-requirements[].description", mappingExact, "The objective is used when a control has no constraints"},
+		{"effective assessment requirements", "implemented-requirements[].statements", mappingLossy, "Applicability and recommendation are dropped"},
+		{"imports.guidance, imports.policies", "-", mappingNotExported, ""},
+		{"contacts, scope, implementation-plan, risks, adherence", "-", mappingNotExported, ""},
+	},
+	oscalModelAssessmentResults: {
+		{"metadata", "metadata", mappingLossy, "As for catalogs; the evaluation log has no title"},
+		{"evaluations[]", "results[0].findings (target objective-id <control>)", mappingExact, "One finding per control evaluation"},
+		{"evaluations[].result", "findings[].target.status", mappingLossy, "Passed is satisfied; any other result is not-satisfied, and the Gemara result is kept as a prop"},
+		{"evaluations[].control", "results[0].reviewed-controls", mappingLossy, "The control's reference ID is dropped"},
+		{"assessment-logs[]", "results[0].observations (method TEST)", mappingExact, "Linked to their finding as related observations"},
+		{"assessment-logs[].result / message / recommendation", "observations[].props[result] / description / remarks", mappingExact, ""},
+		{"assessment-logs[].applicability / confidence-level / steps-executed", "observations[].props", mappingExact, ""},
+		{"assessment-logs[].start / end", "observations[].collected, results[0].start / end", mappingExact, ""},
+		{"assessment-logs[].steps", "-", mappingNotExported, "Only the number of steps is kept"},
+		{"assessment-logs[].plan", "import-ap (back-matter resource)", mappingLossy, "Only the first plan reference is kept"},
+	},
+}
+
+// handleExportOSCAL converts a stored or supplied Gemara artifact into an OSCAL document
+func (g *GemaraAuthoringTools) handleExportOSCAL(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	options := OSCALExportOptions{
+		Layer:      request.GetInt("layer", 0),
+		ArtifactID: request.GetString("artifact_id", ""),
+		Model:      request.GetString("oscal_model", ""),
+		Format:     request.GetString("format", "json"),
+		OutputPath: request.GetString("output_path", ""),
+	}
+	if content, err := rawArgument(request, "artifact_content"); err == nil {
+		options.Content = content
+	}
+	outputFormat := request.GetString("output_format", "")
+	if outputFormat == "" {
+		outputFormat = "document"
+		if options.OutputPath != "" {
+			outputFormat = "markdown"
+		}
+	}
+
+	result, err := g.ExportOSCAL(options)
+	if err != nil {
+		return mcp.NewToolResultErrorf("Failed to export OSCAL: %v", err), nil
+	}
+
+	switch outputFormat {
+	case "json":
+		output, err := marshalOutput(result, "json")
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal JSON: %v", err), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	case "markdown":
+		return mcp.NewToolResultText(result.ToMarkdown()), nil
+	}
+	return mcp.NewToolResultText(result.Document), nil
+}
+
+// ExportOSCAL converts a Layer 2 Catalog into an OSCAL catalog, a Layer 3 Policy into an OSCAL
+// profile or component definition, or a Layer 4 EvaluationLog into OSCAL assessment results.
+// The document is written to options.OutputPath when it is set.
+func (g *GemaraAuthoringTools) ExportOSCAL(options OSCALExportOptions) (*OSCALExportResult, error) {
+	if options.ArtifactID == "" && len(options.Content) == 0 {
+		return nil, fmt.Errorf("either an artifact ID or artifact content is required")
+	}
+	if options.Format == "" {
+		options.Format = "json"
+	}
+	if options.Format != "json" && options.Format != "yaml" {
+		return nil, fmt.Errorf("invalid format '%s': must be json or yaml", options.Format)
+	}
+
+	exporter := &oscalExporter{g: g, now: time.Now().UTC()}
+	var doc oscal.Document
+	var err error
+	switch options.Layer {
+	case consts.Layer2:
+		if options.Model != "" && options.Model != oscalModelCatalog {
+			return nil, fmt.Errorf("Layer 2 catalogs export as an OSCAL catalog, not %s", options.Model)
+		}
+		var artifact interface{}
+		if artifact, err = g.loadArtifactForDiff(consts.Layer2, options.ArtifactID, string(options.Content)); err != nil {
+			return nil, err
+		}
+		doc.Catalog = exporter.catalog(artifact.(*gemara.Catalog))
+	case consts.Layer3:
+		var artifact interface{}
+		if artifact, err = g.loadArtifactForDiff(consts.Layer3, options.ArtifactID, string(options.Content)); err != nil {
+			return nil, err
+		}
+		switch options.Model {
+		case "", oscalModelProfile:
+			doc.Profile = exporter.profile(artifact.(*gemara.Policy))
+		case oscalModelComponentDefinition:
+			doc.ComponentDefinition = exporter.componentDefinition(artifact.(*gemara.Policy))
+		default:
+			return nil, fmt.Errorf("invalid oscal_model '%s' for Layer 3: must be profile or component-definition", options.Model)
+		}
+	case consts.Layer4:
+		if options.Model != "" && options.Model != oscalModelAssessmentResults {
+			return nil, fmt.Errorf("Layer 4 evaluation logs export as OSCAL assessment-results, not %s", options.Model)
+		}
+		content := string(options.Content)
+		if options.ArtifactID != "" {
+			if g.storage == nil {
+				return nil, fmt.Errorf("Layer 4 artifact '%s' cannot be loaded without storage; pass artifact_content", options.ArtifactID)
+			}
+			if content, err = g.retrieveRaw(consts.Layer4, options.ArtifactID); err != nil {
+				return nil, err
+			}
+		}
+		var log evaluationLogRecord
+		if err := yaml.Unmarshal([]byte(content), &log); err != nil {
+			return nil, fmt.Errorf("failed to parse Layer 4 YAML: %w", err)
+		}
+		doc.AssessmentResults = exporter.assessmentResults(&log)
+	default:
+		return nil, fmt.Errorf("OSCAL export supports layers 2, 3, and 4, got layer %d", options.Layer)
+	}
+
+	result := &OSCALExportResult{
+		Model:      doc.Model(),
+		Layer:      options.Layer,
+		ArtifactID: exporter.id,
+		Format:     options.Format,
+		Counts:     exporter.counts,
+		Notes:      exporter.notes,
+		Mappings:   oscalExportMappings[doc.Model()],
+	}
+	var data []byte
+	if options.Format == "yaml" {
+		data, err = yaml.Marshal(doc)
+	} else {
+		data, err = json.MarshalIndent(doc, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal OSCAL %s: %w", result.Model, err)
+	}
+	result.Document = string(data)
+
+	if options.OutputPath != "" {
+		path, err := filepath.Abs(options.OutputPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid output path: %w", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create output directory: %w", err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write OSCAL document: %w", err)
+		}
+		result.Path = path
+	}
+	return result, nil
+}
+
+// ToMarkdown renders the export summary with the field mapping table, and the document when it was not written to a file
+func (r *OSCALExportResult) ToMarkdown() string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## OSCAL Export: Layer %d `%s` → %s\n\n", r.Layer, r.ArtifactID, r.Model))
+	for _, count := range r.Counts {
+		result.WriteString(fmt.Sprintf("- **%s**: %d\n", count.Name, count.Count))
+	}
+	if r.Path != "" {
+		result.WriteString(fmt.Sprintf("- **Written to**: %s\n", r.Path))
+	}
+	result.WriteString("\n")
+
+	if len(r.Notes) > 0 {
+		result.WriteString("### Conversion Notes\n\n")
+		for _, note := range r.Notes {
+			result.WriteString(fmt.Sprintf("- %s\n", note))
+		}
+		result.WriteString("\n")
+	}
+
+	result.WriteString("### Field Mapping\n\n")
+	result.WriteString("| Gemara | OSCAL | Fidelity | Notes |\n")
+	result.WriteString("|--------|-------|----------|-------|\n")
+	for _, mapping := range r.Mappings {
+		result.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", escapeTableCell(mapping.Gemara), escapeTableCell(mapping.OSCAL), mapping.Fidelity, escapeTableCell(mapping.Notes)))
+	}
+	result.WriteString("\n")
+
+	if r.Path == "" {
+		result.WriteString(fmt.Sprintf("```%s\n%s```\n", r.Format, r.Document))
+	}
+	return result.String()
+}
+
+// evaluationLogRecord is a Layer 4 evaluation log as it is stored. gemara.Result can only be
+// marshalled and assessment steps are functions, so results and steps are read as text.
+type evaluationLogRecord struct {
+	Metadata    gemara.Metadata           `yaml:"metadata"`
+	Evaluations []controlEvaluationRecord `yaml:"evaluations"`
+}
+
+// controlEvaluationRecord is a control evaluation of an evaluationLogRecord
+type controlEvaluationRecord struct {
+	Name           string                `yaml:"name"`
+	Result         string                `yaml:"result"`
+	Message        string                `yaml:"message"`
+	Control        gemara.SingleMapping  `yaml:"control"`
+	AssessmentLogs []assessmentLogRecord `yaml:"assessment-logs"`
+}
+
+// assessmentLogRecord is an assessment log of a controlEvaluationRecord
+type assessmentLogRecord struct {
+	Requirement     gemara.SingleMapping  `yaml:"requirement"`
+	Plan            *gemara.SingleMapping `yaml:"plan"`
+	Description     string                `yaml:"description"`
+	Result          string                `yaml:"result"`
+	Message         string                `yaml:"message"`
+	Applicability   []string              `yaml:"applicability"`
+	Steps           []string              `yaml:"steps"`
+	StepsExecuted   int64                 `yaml:"steps-executed"`
+	Start           string                `yaml:"start"`
+	End             string                `yaml:"end"`
+	Recommendation  string                `yaml:"recommendation"`
+	ConfidenceLevel string                `yaml:"confidence-level"`
+}
+
+// oscalExporter builds one OSCAL document from a Gemara artifact and collects notes on what could not be carried over
+type oscalExporter struct {
+	g      *GemaraAuthoringTools
+	now    time.Time
+	id     string
+	counts []oscalCount
+	notes  []string
+	// resources are the back-matter resources by reference ID, in the order they were added
+	resources     []oscal.Resource
+	resourceIndex map[string]int
+}
+
+// catalog converts a Layer 2 Catalog into an OSCAL catalog. Families become groups, and assessment
+// requirements become child controls of class assessment-requirement, which import_oscal reads back.
+func (e *oscalExporter) catalog(catalog *gemara.Catalog) *oscal.Catalog {
+	e.id = catalog.Metadata.Id
+	result := &oscal.Catalog{
+		UUID:     oscal.UUID(e.id, oscalModelCatalog),
+		Metadata: e.metadata(catalog.Title, catalog.Metadata),
+	}
+	e.mappingReferences(catalog.Metadata.MappingReferences)
+
+	groups := make(map[string]int)
+	for _, family := range catalog.Families {
+		groups[family.Id] = len(result.Groups)
+		group := oscal.Group{ID: family.Id, Class: "family", Title: family.Title}
+		if family.Description != "" {
+			group.Parts = []oscal.Part{{ID: family.Id + "_ovw", Name: "overview", Prose: family.Description}}
+		}
+		result.Groups = append(result.Groups, group)
+	}
+
+	requirements := 0
+	ungrouped := 0
+	droppedStrength := 0
+	for _, control := range catalog.Controls {
+		exported := oscal.Control{ID: control.Id, Title: control.Title}
+		if control.Objective != "" {
+			exported.Parts = []oscal.Part{{ID: statementPartID(control), Name: "statement", Prose: control.Objective}}
+		}
+		for _, requirement := range control.AssessmentRequirements {
+			exported.Controls = append(exported.Controls, requirementControl(requirement))
+		}
+		requirements += len(control.AssessmentRequirements)
+		for _, mappings := range []struct {
+			rel     string
+			entries []gemara.MultiMapping
+		}{{"reference", control.GuidelineMappings}, {"threat", control.ThreatMappings}} {
+			for _, mapping := range mappings.entries {
+				href := "#" + e.resource(mapping.ReferenceId).UUID
+				for _, entry := range mapping.Entries {
+					exported.Links = append(exported.Links, oscal.Link{Href: href, Rel: mappings.rel, Text: entry.ReferenceId})
+					if entry.Strength != 0 || entry.Remarks != "" {
+						droppedStrength++
+					}
+				}
+			}
+		}
+
+		if index, ok := groups[control.Family]; ok {
+			result.Groups[index].Controls = append(result.Groups[index].Controls, exported)
+			continue
+		}
+		ungrouped++
+		result.Controls = append(result.Controls, exported)
+	}
+	result.BackMatter = e.backMatter()
+
+	e.count("Groups", len(result.Groups))
+	e.count("Controls", len(catalog.Controls))
+	e.count("Assessment Requirements", requirements)
+	e.count("Back-matter Resources", len(e.resources))
+	if ungrouped > 0 {
+		e.note("%d control(s) reference a family that is not defined and were written at the catalog level.", ungrouped)
+	}
+	if droppedStrength > 0 {
+		e.note("Strength and remarks of %d mapping entries were dropped; OSCAL links only carry the target.", droppedStrength)
+	}
+	if len(catalog.Threats) > 0 || len(catalog.Capabilities) > 0 {
+		e.note("%d threat(s) and %d capability(ies) were not exported; OSCAL catalogs do not model them.", len(catalog.Threats), len(catalog.Capabilities))
+	}
+	if imported := len(catalog.ImportedControls) + len(catalog.ImportedThreats) + len(catalog.ImportedCapabilities); imported > 0 {
+		e.note("%d imported control, threat, or capability mapping(s) were not exported.", imported)
+	}
+	if len(catalog.Metadata.ApplicabilityCategories) > 0 {
+		e.note("%d applicability categories were not exported; requirements keep their category IDs in applicability props.", len(catalog.Metadata.ApplicabilityCategories))
+	}
+	return result
+}
+
+// statementPartID returns the ID of a control's statement part. Catalogs imported from OSCAL may
+// already use <control>_smt for an assessment requirement, and OSCAL IDs must be unique.
+func statementPartID(control gemara.Control) string {
+	id := control.Id + "_smt"
+	if containsRequirement(control.AssessmentRequirements, id) {
+		return control.Id + "_stmt"
+	}
+	return id
+}
+
+// requirementControl converts an assessment requirement into a child control
+func requirementControl(requirement gemara.AssessmentRequirement) oscal.Control {
+	control := oscal.Control{
+		ID:    requirement.Id,
+		Class: assessmentRequirementClass,
+		Title: requirement.Id,
+		Parts: []oscal.Part{{ID: requirement.Id + "_smt", Name: "statement", Prose: requirement.Text}},
+	}
+	if len(requirement.Applicability) > 0 {
+		control.Props = []oscal.Property{{Name: "applicability", Value: strings.Join(requirement.Applicability, ","), NS: gemaraNamespace}}
+	}
+	if requirement.Recommendation != "" {
+		control.Parts = append(control.Parts, oscal.Part{ID: requirement.Id + "_gdn", Name: "guidance", Prose: requirement.Recommendation})
+	}
+	return control
+}
+
+// profile converts a Layer 3 Policy into an OSCAL profile. Each catalog and guidance import becomes
+// a profile import with its exclusions, and constraints and modifications become alterations.
+func (e *oscalExporter) profile(policy *gemara.Policy) *oscal.Profile {
+	e.id = policy.Metadata.Id
+	result := &oscal.Profile{
+		UUID:     oscal.UUID(e.id, oscalModelProfile),
+		Metadata: e.metadata(policy.Title, policy.Metadata),
+		Imports:  []oscal.ProfileImport{},
+	}
+	e.contacts(&result.Metadata, policy.Contacts)
+	e.mappingReferences(policy.Metadata.MappingReferences)
+
+	alters := make(map[string]*oscal.Alteration)
+	var alterOrder []string
+	alter := func(controlID string) *oscal.Alteration {
+		if _, ok := alters[controlID]; !ok {
+			alters[controlID] = &oscal.Alteration{ControlID: controlID}
+			alterOrder = append(alterOrder, controlID)
+		}
+		return alters[controlID]
+	}
+
+	addImport := func(referenceID string, exclusions []string, constraints []gemara.Constraint) *gemara.Catalog {
+		imported := oscal.ProfileImport{Href: "#" + e.resource(referenceID).UUID, IncludeAll: &struct{}{}}
+		if len(exclusions) > 0 {
+			imported.ExcludeControls = []oscal.SelectControlByID{{WithIDs: exclusions}}
+		}
+		result.Imports = append(result.Imports, imported)
+
+		catalog := e.g.loadLayer2Catalog(referenceID)
+		for _, constraint := range constraints {
+			controlID := controlOf(catalog, constraint.TargetId)
+			target := alter(controlID)
+			target.Adds = append(target.Adds, oscal.Addition{
+				Position: "ending",
+				Parts:    []oscal.Part{{ID: constraint.Id, Name: "constraint", NS: gemaraNamespace, Prose: constraint.Text}},
+			})
+		}
+		return catalog
+	}
+
+	constraints := 0
+	modifications := 0
+	for _, imported := range policy.Imports.Catalogs {
+		catalog := addImport(imported.ReferenceId, imported.Exclusions, imported.Constraints)
+		constraints += len(imported.Constraints)
+		for _, modifier := range imported.AssessmentRequirementModifications {
+			controlID := controlOf(catalog, modifier.TargetId)
+			if isRemoveModification(modifier) {
+				if catalog == nil || controlID == modifier.TargetId {
+					e.note("Modification '%s' removes '%s', which is not an assessment requirement of a stored catalog; it was not exported.", modifier.Id, modifier.TargetId)
+					continue
+				}
+				alter(controlID).Removes = append(alter(controlID).Removes, oscal.Removal{ByID: modifier.TargetId})
+				modifications++
+				continue
+			}
+			text := modifier.Text
+			if text == "" {
+				text = modifier.ModificationRationale
+			}
+			alter(controlID).Adds = append(alter(controlID).Adds, oscal.Addition{
+				Position: "ending",
+				Parts: []oscal.Part{{
+					ID:    modifier.Id,
+					Name:  "modification",
+					NS:    gemaraNamespace,
+					Title: fmt.Sprintf("%s %s", modifier.ModificationType, modifier.TargetId),
+					Props: []oscal.Property{{Name: "modification-type", Value: string(modifier.ModificationType), NS: gemaraNamespace}},
+					Prose: text,
+				}},
+			})
+			modifications++
+		}
+	}
+	for _, imported := range policy.Imports.Guidance {
+		addImport(imported.ReferenceId, imported.Exclusions, imported.Constraints)
+		constraints += len(imported.Constraints)
+	}
+	if len(alterOrder) > 0 {
+		result.Modify = &oscal.Modify{}
+		for _, controlID := range alterOrder {
+			result.Modify.Alters = append(result.Modify.Alters, *alters[controlID])
+		}
+	}
+	result.BackMatter = e.backMatter()
+
+	e.count("Imports", len(result.Imports))
+	e.count("Constraints", constraints)
+	e.count("Modifications", modifications)
+	if len(policy.Imports.Policies) > 0 {
+		e.note("Imported policies (%s) were not exported; OSCAL profiles can only import catalogs and profiles.", strings.Join(policy.Imports.Policies, ", "))
+	}
+	e.note("Scope, implementation plan, risks, and adherence were not exported; OSCAL profiles do not describe them.")
+	return result
+}
+
+// componentDefinition converts a Layer 3 Policy into an OSCAL component definition with one
+// component that implements the policy's effective controls
+func (e *oscalExporter) componentDefinition(policy *gemara.Policy) *oscal.ComponentDefinition {
+	e.id = policy.Metadata.Id
+	result := &oscal.ComponentDefinition{
+		UUID:     oscal.UUID(e.id, oscalModelComponentDefinition),
+		Metadata: e.metadata(policy.Title, policy.Metadata),
+	}
+	e.contacts(&result.Metadata, policy.Contacts)
+	e.mappingReferences(policy.Metadata.MappingReferences)
+
+	description := policy.Metadata.Description
+	if description == "" {
+		description = policy.Title
+	}
+	component := oscal.Component{
+		UUID:        oscal.UUID(e.id, "component"),
+		Type:        "policy",
+		Title:       policy.Title,
+		Description: description,
+	}
+
+	resolved := e.g.resolvePolicy(e.id, policy)
+	implementations := make(map[string]int)
+	requirements := 0
+	for _, control := range resolved.Controls {
+		index, ok := implementations[control.CatalogID]
+		if !ok {
+			index = len(component.ControlImplementations)
+			implementations[control.CatalogID] = index
+			component.ControlImplementations = append(component.ControlImplementations, oscal.ControlImplementation{
+				UUID:        oscal.UUID(e.id, "control-implementation", control.CatalogID),
+				Source:      "#" + e.resource(control.CatalogID).UUID,
+				Description: fmt.Sprintf("Controls of %s in effect under %s.", control.CatalogID, policy.Title),
+			})
+		}
+
+		implemented := oscal.ImplementedRequirement{
+			UUID:        oscal.UUID(e.id, "implemented-requirement", control.CatalogID, control.ControlID),
+			ControlID:   control.ControlID,
+			Description: control.Objective,
+		}
+		if len(control.Constraints) > 0 {
+			var texts []string
+			for _, constraint := range control.Constraints {
+				texts = append(texts, constraint.Text)
+			}
+			implemented.Description = strings.Join(texts, "\n\n")
+		}
+		if implemented.Description == "" {
+			implemented.Description = control.Title
+		}
+		for _, requirement := range control.Requirements {
+			implemented.Statements = append(implemented.Statements, oscal.Statement{
+				StatementID: requirement.ID + "_smt",
+				UUID:        oscal.UUID(e.id, "statement", control.CatalogID, requirement.ID),
+				Description: requirement.Text,
+			})
+		}
+		requirements += len(control.Requirements)
+		implementation := &component.ControlImplementations[index]
+		implementation.ImplementedRequirements = append(implementation.ImplementedRequirements, implemented)
+	}
+	result.Components = []oscal.Component{component}
+	result.BackMatter = e.backMatter()
+
+	e.count("Control Implementations", len(component.ControlImplementations))
+	e.count("Implemented Requirements", len(resolved.Controls))
+	e.count("Statements", requirements)
+	e.count("Excluded Elements", len(resolved.Excluded))
+	for _, warning := range resolved.Warnings {
+		e.note("%s", warning)
+	}
+	if len(policy.Imports.Guidance) > 0 {
+		e.note("%d guidance import(s) were not exported; component definitions implement catalog controls.", len(policy.Imports.Guidance))
+	}
+	return result
+}
+
+// assessmentResults converts a Layer 4 evaluation log into OSCAL assessment results with one
+// result: each control evaluation becomes a finding and each assessment log an observation
+func (e *oscalExporter) assessmentResults(log *evaluationLogRecord) *oscal.AssessmentResults {
+	e.id = log.Metadata.Id
+	title := fmt.Sprintf("Evaluation log %s", e.id)
+	result := &oscal.AssessmentResults{
+		UUID:     oscal.UUID(e.id, oscalModelAssessmentResults),
+		Metadata: e.metadata(title, log.Metadata),
+	}
+	e.mappingReferences(log.Metadata.MappingReferences)
+
+	run := oscal.Result{
+		UUID:        oscal.UUID(e.id, "result"),
+		Title:       title,
+		Description: log.Metadata.Description,
+	}
+	if run.Description == "" {
+		run.Description = title
+	}
+
+	var starts, ends []string
+	var reviewed []oscal.AssessedControl
+	plan := ""
+	counts := make(map[string]int)
+	for i, evaluation := range log.Evaluations {
+		finding := oscal.Finding{
+			UUID:        oscal.UUID(e.id, "finding", strconv.Itoa(i)),
+			Title:       evaluation.Name,
+			Description: evaluation.Message,
+			Props:       []oscal.Property{{Name: "result", Value: evaluation.Result, NS: gemaraNamespace}},
+			Target: oscal.FindingTarget{
+				Type:     "objective-id",
+				TargetID: evaluation.Control.EntryId,
+				Status:   findingStatus(evaluation.Result),
+			},
+		}
+		if finding.Title == "" {
+			finding.Title = evaluation.Control.EntryId
+		}
+		if finding.Description == "" {
+			finding.Description = fmt.Sprintf("%s: %s", evaluation.Control.EntryId, evaluation.Result)
+		}
+		if finding.Target.Status.State != "satisfied" {
+			finding.Target.Remarks = fmt.Sprintf("Gemara result: %s", evaluation.Result)
+		}
+		counts[evaluation.Result]++
+		if evaluation.Control.EntryId != "" && !containsAssessedControl(reviewed, evaluation.Control.EntryId) {
+			reviewed = append(reviewed, oscal.AssessedControl{ControlID: evaluation.Control.EntryId})
+		}
+
+		for j, assessment := range evaluation.AssessmentLogs {
+			observation := oscal.Observation{
+				UUID:        oscal.UUID(e.id, "observation", strconv.Itoa(i), strconv.Itoa(j)),
+				Title:       assessment.Requirement.EntryId,
+				Description: assessment.Description,
+				Methods:     []string{"TEST"},
+				Collected:   assessment.Start,
+				Props:       []oscal.Property{{Name: "result", Value: assessment.Result, NS: gemaraNamespace}},
+				Remarks:     strings.TrimSpace(strings.Join([]string{assessment.Message, assessment.Recommendation}, "\n\n")),
+			}
+			if observation.Description == "" {
+				observation.Description = assessment.Requirement.EntryId
+			}
+			if len(assessment.Applicability) > 0 {
+				observation.Props = append(observation.Props, oscal.Property{Name: "applicability", Value: strings.Join(assessment.Applicability, ","), NS: gemaraNamespace})
+			}
+			if assessment.ConfidenceLevel != "" {
+				observation.Props = append(observation.Props, oscal.Property{Name: "confidence-level", Value: assessment.ConfidenceLevel, NS: gemaraNamespace})
+			}
+			if assessment.StepsExecuted > 0 || len(assessment.Steps) > 0 {
+				observation.Props = append(observation.Props, oscal.Property{Name: "steps-executed", Value: fmt.Sprintf("%d/%d", assessment.StepsExecuted, len(assessment.Steps)), NS: gemaraNamespace})
+			}
+			if observation.Collected == "" {
+				observation.Collected = oscalTimestamp(log.Metadata.Date, e.now)
+			} else {
+				starts = append(starts, assessment.Start)
+			}
+			if assessment.End != "" {
+				ends = append(ends, assessment.End)
+			}
+			if plan == "" && assessment.Plan != nil {
+				plan = assessment.Plan.ReferenceId
+			}
+			run.Observations = append(run.Observations, observation)
+			finding.RelatedObservations = append(finding.RelatedObservations, oscal.RelatedObservation{ObservationUUID: observation.UUID})
+		}
+		run.Findings = append(run.Findings, finding)
+	}
+
+	sort.Strings(starts)
+	sort.Strings(ends)
+	run.Start = oscalTimestamp(log.Metadata.Date, e.now)
+	if len(starts) > 0 {
+		run.Start = starts[0]
+	}
+	if len(ends) > 0 {
+		run.End = ends[len(ends)-1]
+	}
+	run.ReviewedControls = oscal.ReviewedControls{ControlSelections: []oscal.ControlSelection{{IncludeControls: reviewed}}}
+	result.Results = []oscal.Result{run}
+
+	if plan == "" {
+		plan = e.id + "-assessment-plan"
+		e.note("The evaluation log names no assessment plan; import-ap references a placeholder resource '%s'.", plan)
+	}
+	result.ImportAP = oscal.ImportAP{Href: "#" + e.resource(plan).UUID}
+	result.BackMatter = e.backMatter()
+
+	e.count("Findings", len(run.Findings))
+	e.count("Observations", len(run.Observations))
+	results := make([]string, 0, len(counts))
+	for name := range counts {
+		results = append(results, name)
+	}
+	sort.Strings(results)
+	for _, name := range results {
+		e.count(fmt.Sprintf("Result %s", name), counts[name])
+	}
+	return result
+}
+
+// findingStatus maps a Gemara result onto an OSCAL objective status
+func findingStatus(result string) oscal.ObjectiveStatus {
+	switch result {
+	case "Passed":
+		return oscal.ObjectiveStatus{State: "satisfied"}
+	case "Failed":
+		return oscal.ObjectiveStatus{State: "not-satisfied", Reason: "fail"}
+	}
+	return oscal.ObjectiveStatus{State: "not-satisfied", Reason: "other"}
+}
+
+// containsAssessedControl reports whether the control is already listed
+func containsAssessedControl(controls []oscal.AssessedControl, id string) bool {
+	for _, control := range controls {
+		if control.ControlID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// controlOf returns the ID of the control in the catalog that has the target as an assessment
+// requirement, or the target itself
+func controlOf(catalog *gemara.Catalog, targetID string) string {
+	if catalog == nil {
+		return targetID
+	}
+	for _, control := range catalog.Controls {
+		if containsRequirement(control.AssessmentRequirements, targetID) {
+			return control.Id
+		}
+	}
+	return targetID
+}
+
+// metadata builds OSCAL metadata from the artifact's metadata, with the author as creator
+func (e *oscalExporter) metadata(title string, metadata gemara.Metadata) oscal.Metadata {
+	version := metadata.Version
+	if version == "" {
+		version = "1.0"
+	}
+	result := oscal.Metadata{
+		Title:        title,
+		LastModified: oscalTimestamp(metadata.Date, e.now),
+		Version:      version,
+		OscalVersion: oscal.Version,
+		Props:        []oscal.Property{{Name: "gemara-id", Value: metadata.Id, NS: gemaraNamespace}},
+		Remarks:      metadata.Description,
+	}
+	if metadata.Author.Name != "" {
+		e.party(&result, "creator", metadata.Author.Name, metadata.Author.Contact.Email, metadata.Author.Type != gemara.Human)
+	}
+	return result
+}
+
+// contacts adds the policy's RACI contacts as parties in the matching roles
+func (e *oscalExporter) contacts(metadata *oscal.Metadata, contacts gemara.Contacts) {
+	for _, role := range []struct {
+		id       string
+		contacts []gemara.Contact
+	}{
+		{"responsible", contacts.Responsible},
+		{"accountable", contacts.Accountable},
+		{"consulted", contacts.Consulted},
+		{"informed", contacts.Informed},
+	} {
+		for _, contact := range role.contacts {
+			e.party(metadata, role.id, contact.Name, contact.Email, false)
+		}
+	}
+}
+
+// party adds a party in a role, reusing the party when the same name was already added
+func (e *oscalExporter) party(metadata *oscal.Metadata, roleID, name string, email *gemara.Email, organization bool) {
+	if name == "" {
+		return
+	}
+	party := oscal.Party{UUID: oscal.UUID(e.id, "party", name), Type: "person", Name: name}
+	if organization {
+		party.Type = "organization"
+	}
+	if email != nil && *email != "" {
+		party.EmailAddresses = []string{string(*email)}
+	}
+	known := false
+	for _, existing := range metadata.Parties {
+		known = known || existing.UUID == party.UUID
+	}
+	if !known {
+		metadata.Parties = append(metadata.Parties, party)
+	}
+	for i := range metadata.ResponsibleParties {
+		if metadata.ResponsibleParties[i].RoleID == roleID {
+			metadata.ResponsibleParties[i].PartyUUIDs = append(metadata.ResponsibleParties[i].PartyUUIDs, party.UUID)
+			return
+		}
+	}
+	metadata.ResponsibleParties = append(metadata.ResponsibleParties, oscal.ResponsibleParty{RoleID: roleID, PartyUUIDs: []string{party.UUID}})
+}
+
+// mappingReferences adds the artifact's mapping references as back-matter resources
+func (e *oscalExporter) mappingReferences(references []gemara.MappingReference) {
+	for _, reference := range references {
+		resource := e.resource(reference.Id)
+		resource.Title = reference.Title
+		resource.Description = reference.Description
+		if reference.Version != "" {
+			resource.Props = append(resource.Props, oscal.Property{Name: "version", Value: reference.Version, NS: gemaraNamespace})
+		}
+		if reference.Url != "" {
+			resource.Rlinks = []oscal.RLink{{Href: reference.Url}}
+		}
+	}
+}
+
+// resource returns the back-matter resource for a reference ID, adding it when it is new. The
+// reference ID is kept in the resource's id prop, which import_oscal resolves imports by.
+func (e *oscalExporter) resource(referenceID string) *oscal.Resource {
+	if e.resourceIndex == nil {
+		e.resourceIndex = make(map[string]int)
+	}
+	if index, ok := e.resourceIndex[referenceID]; ok {
+		return &e.resources[index]
+	}
+	e.resourceIndex[referenceID] = len(e.resources)
+	e.resources = append(e.resources, oscal.Resource{
+		UUID:  oscal.UUID(e.id, "resource", referenceID),
+		Title: referenceID,
+		Props: []oscal.Property{{Name: "id", Value: referenceID, NS: gemaraNamespace}},
+	})
+	return &e.resources[len(e.resources)-1]
+}
+
+// backMatter returns the collected resources, or nil when there are none
+func (e *oscalExporter) backMatter() *oscal.BackMatter {
+	if len(e.resources) == 0 {
+		return nil
+	}
+	return &oscal.BackMatter{Resources: e.resources}
+}
+
+func (e *oscalExporter) count(name string, count int) {
+	e.counts = append(e.counts, oscalCount{Name: name, Count: count})
+}
+
+func (e *oscalExporter) note(format string, args ...interface{}) {
+	e.notes = append(e.notes, fmt.Sprintf(format, args...))
+}
+
+// oscalTimestamp converts a Gemara date into an OSCAL timestamp, falling back to now
+func oscalTimestamp(date gemara.Date, now time.Time) string {
+	if t, err := time.Parse("2006-01-02", string(date)); err == nil {
+		return t.Format(time.RFC3339)
+	}
+	if t, err := time.Parse(time.RFC3339, string(date)); err == nil {
+		return t.Format(time.RFC3339)
+	}
+	return now.Format(time.RFC3339)
+}
@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/complytime/gemara-mcp-server/internal/oscal"
+	"github.com/ossf/gemara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testExportCatalog() *gemara.Catalog {
+	return &gemara.Catalog{
+		Title: "Export Catalog",
+		Metadata: gemara.Metadata{
+			Id:          "export-catalog",
+			Version:     "1.0.0",
+			Date:        "2024-05-06",
+			Description: "Catalog for export tests",
+			Author:      gemara.Actor{Id: "sec", Name: "Security Team", Type: gemara.Human},
+			MappingReferences: []gemara.MappingReference{
+				{Id: "NIST", Title: "NIST SP 800-53", Version: "5", Url: "https://example.com/800-53"},
+			},
+		},
+		Families: []gemara.Family{{Id: "ac", Title: "Access Control", Description: "Control access."}},
+		Controls: []gemara.Control{
+			{
+				Id: "AC-01", Title: "MFA", Objective: "Require MFA.", Family: "ac",
+				AssessmentRequirements: []gemara.AssessmentRequirement{
+					{Id: "AC-01.01", Text: "MFA is enforced.", Applicability: []string{"prod", "dev"}, Recommendation: "Use hardware keys."},
+					{Id: "AC-01.02", Text: "MFA is logged.", Applicability: []string{}},
+				},
+				GuidelineMappings: []gemara.MultiMapping{
+					{ReferenceId: "NIST", Entries: []gemara.MappingEntry{{ReferenceId: "ia-2", Strength: 8}}},
+				},
+			},
+			{
+				Id: "AC-02", Title: "Sessions", Objective: "Expire sessions.", Family: "ac",
+				AssessmentRequirements: []gemara.AssessmentRequirement{{Id: "AC-02_smt", Text: "Expire sessions.", Applicability: []string{}}},
+			},
+		},
+	}
+}
+
+func TestOSCALCatalogExport(t *testing.T) {
+	g := &GemaraAuthoringTools{}
+	source := testExportCatalog()
+
+	exporter := &oscalExporter{g: g}
+	exported := exporter.catalog(source)
+	assert.Equal(t, "Export Catalog", exported.Metadata.Title)
+	assert.Equal(t, "2024-05-06T00:00:00Z", exported.Metadata.LastModified)
+	assert.Equal(t, oscal.Version, exported.Metadata.OscalVersion)
+	require.Len(t, exported.Metadata.Parties, 1)
+	assert.Equal(t, "Security Team", exported.Metadata.Parties[0].Name)
+
+	require.Len(t, exported.Groups, 1)
+	group := exported.Groups[0]
+	assert.Equal(t, "ac", group.ID)
+	require.Len(t, group.Controls, 2)
+	control := group.Controls[0]
+	require.Len(t, control.Controls, 2)
+	assert.Equal(t, assessmentRequirementClass, control.Controls[0].Class)
+	assert.Equal(t, "prod,dev", oscal.Prop(control.Controls[0].Props, "applicability"))
+	require.Len(t, control.Links, 1)
+	assert.Equal(t, "ia-2", control.Links[0].Text)
+	require.NotNil(t, exported.BackMatter)
+	require.Len(t, exported.BackMatter.Resources, 1)
+	assert.Equal(t, "#"+exported.BackMatter.Resources[0].UUID, control.Links[0].Href)
+	assert.Equal(t, "NIST", oscal.Prop(exported.BackMatter.Resources[0].Props, "id"))
+	assert.Contains(t, exporter.notes, "Strength and remarks of 1 mapping entries were dropped; OSCAL links only carry the target.")
+
+	// The statement part must not reuse an assessment requirement's ID
+	assert.Equal(t, "AC-02_stmt", group.Controls[1].Parts[0].ID)
+
+	// UUIDs are stable across exports
+	assert.Equal(t, exported.UUID, (&oscalExporter{g: g}).catalog(source).UUID)
+
+	// import_oscal reads the exported catalog back into the same controls
+	converter := &oscalConverter{g: g, options: OSCALImportOptions{ID: "export-catalog"}}
+	imported, err := converter.catalog(exported)
+	require.NoError(t, err)
+	assert.Equal(t, source.Families, imported.Families)
+	require.Len(t, imported.Controls, 2)
+	for i, control := range source.Controls {
+		assert.Equal(t, control.Id, imported.Controls[i].Id)
+		assert.Equal(t, control.Objective, imported.Controls[i].Objective)
+		assert.Equal(t, control.AssessmentRequirements, imported.Controls[i].AssessmentRequirements)
+	}
+}
+
+func TestOSCALProfileExport(t *testing.T) {
+	catalog := testExportCatalog()
+	g := &GemaraAuthoringTools{
+		layer2Catalogs: map[string]*gemara.Catalog{catalog.Metadata.Id: catalog},
+		layer3Policies: map[string]*gemara.Policy{},
+	}
+	policy := &gemara.Policy{
+		Title: "Export Policy",
+		Metadata: gemara.Metadata{
+			Id:     "export-policy",
+			Author: gemara.Actor{Id: "sec", Name: "Security Team", Type: gemara.Human},
+		},
+		Contacts: gemara.Contacts{
+			Responsible: []gemara.Contact{{Name: "Platform Team"}},
+			Accountable: []gemara.Contact{{Name: "Security Team"}},
+		},
+		Imports: gemara.Imports{
+			Policies: []string{"base-policy"},
+			Catalogs: []gemara.CatalogImport{{
+				ReferenceId: "export-catalog",
+				Exclusions:  []string{"AC-02"},
+				Constraints: []gemara.Constraint{{Id: "C1", TargetId: "AC-01.01", Text: "Use FIDO2 keys."}},
+				AssessmentRequirementModifications: []gemara.AssessmentRequirementModifier{
+					{Id: "M1", TargetId: "AC-01.02", ModificationType: "remove", ModificationRationale: "Logged elsewhere."},
+				},
+			}},
+		},
+	}
+
+	exporter := &oscalExporter{g: g}
+	profile := exporter.profile(policy)
+	require.Len(t, profile.Imports, 1)
+	assert.NotNil(t, profile.Imports[0].IncludeAll)
+	assert.Equal(t, []oscal.SelectControlByID{{WithIDs: []string{"AC-02"}}}, profile.Imports[0].ExcludeControls)
+	resource := profile.BackMatter.Resource(profile.Imports[0].Href[1:])
+	require.NotNil(t, resource)
+	assert.Equal(t, "export-catalog", oscal.Prop(resource.Props, "id"))
+
+	require.NotNil(t, profile.Modify)
+	require.Len(t, profile.Modify.Alters, 1)
+	alter := profile.Modify.Alters[0]
+	assert.Equal(t, "AC-01", alter.ControlID)
+	assert.Equal(t, "Use FIDO2 keys.", alter.Adds[0].Parts[0].Prose)
+	assert.Equal(t, []oscal.Removal{{ByID: "AC-01.02"}}, alter.Removes)
+
+	// Security Team is both the author and the accountable contact, and is listed once
+	assert.Len(t, profile.Metadata.Parties, 2)
+	assert.Contains(t, exporter.notes, "Imported policies (base-policy) were not exported; OSCAL profiles can only import catalogs and profiles.")
+
+	components := (&oscalExporter{g: g}).componentDefinition(policy)
+	require.Len(t, components.Components, 1)
+	implementations := components.Components[0].ControlImplementations
+	require.Len(t, implementations, 1)
+	require.Len(t, implementations[0].ImplementedRequirements, 1)
+	requirement := implementations[0].ImplementedRequirements[0]
+	assert.Equal(t, "AC-01", requirement.ControlID)
+	assert.Equal(t, "Use FIDO2 keys.", requirement.Description)
+	require.Len(t, requirement.Statements, 1)
+	assert.Equal(t, "AC-01.01_smt", requirement.Statements[0].StatementID)
+}
+
+func TestOSCALAssessmentResultsExport(t *testing.T) {
+	g := &GemaraAuthoringTools{}
+	log := `metadata:
+  id: nightly
+  description: Nightly evaluation
+  author: {id: scanner, name: Scanner, type: Software}
+evaluations:
+  - name: MFA check
+    result: Failed
+    message: One account lacks MFA
+    control: {reference-id: export-catalog, entry-id: AC-01}
+    assessment-logs:
+      - requirement: {reference-id: export-catalog, entry-id: AC-01.01}
+        description: Check MFA enforcement
+        result: Failed
+        message: bob has no MFA
+        applicability: [prod]
+        steps: [checkMFA]
+        steps-executed: 1
+        start: "2025-01-02T01:00:00Z"
+        end: "2025-01-02T01:05:00Z"
+  - name: Session check
+    result: Passed
+    message: Sessions expire
+    control: {entry-id: AC-02}
+    assessment-logs: []
+`
+	outputPath := filepath.Join(t.TempDir(), "results", "nightly.json")
+	result, err := g.ExportOSCAL(OSCALExportOptions{Layer: 4, Content: []byte(log), OutputPath: outputPath})
+	require.NoError(t, err)
+	assert.Equal(t, oscalModelAssessmentResults, result.Model)
+	assert.Equal(t, outputPath, result.Path)
+
+	written, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, result.Document, string(written))
+
+	var doc oscal.Document
+	require.NoError(t, json.Unmarshal(written, &doc))
+	require.NotNil(t, doc.AssessmentResults)
+	require.Len(t, doc.AssessmentResults.Results, 1)
+	run := doc.AssessmentResults.Results[0]
+	assert.Equal(t, "2025-01-02T01:00:00Z", run.Start)
+	assert.Equal(t, []oscal.AssessedControl{{ControlID: "AC-01"}, {ControlID: "AC-02"}}, run.ReviewedControls.ControlSelections[0].IncludeControls)
+
+	require.Len(t, run.Findings, 2)
+	assert.Equal(t, oscal.ObjectiveStatus{State: "not-satisfied", Reason: "fail"}, run.Findings[0].Target.Status)
+	assert.Equal(t, oscal.ObjectiveStatus{State: "satisfied"}, run.Findings[1].Target.Status)
+	require.Len(t, run.Observations, 1)
+	assert.Equal(t, run.Observations[0].UUID, run.Findings[0].RelatedObservations[0].ObservationUUID)
+	assert.Equal(t, "1/1", oscal.Prop(run.Observations[0].Props, "steps-executed"))
+	assert.NotEmpty(t, result.Notes)
+
+	_, err = g.ExportOSCAL(OSCALExportOptions{Layer: 1, Content: []byte(log)})
+	assert.ErrorContains(t, err, "supports layers 2, 3, and 4")
+	_, err = g.ExportOSCAL(OSCALExportOptions{Layer: 3, Content: []byte(log), Model: "ssp"})
+	assert.ErrorContains(t, err, "invalid oscal_model")
+}
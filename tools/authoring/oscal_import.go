@@ -0,0 +1,939 @@
+package authoring
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/complytime/gemara-mcp-server/internal/oscal"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+)
+
+// assessmentRequirementClass marks child controls that hold a control's assessment requirements rather than enhancements
+const assessmentRequirementClass = "assessment-requirement"
+
+// OSCALImportOptions controls how an OSCAL document is converted into a Gemara artifact
+type OSCALImportOptions struct {
+	// Layer is the target layer for catalogs: 1 for a GuidanceDocument or 2 (default) for a Catalog.
+	// Profiles always become Layer 3 Policies.
+	Layer int
+	// ID overrides the artifact ID derived from the OSCAL title
+	ID string
+	// DocumentType is the Layer 1 document type (default Framework)
+	DocumentType string
+	// CatalogID is the ID of the stored artifact a profile imports, overriding resolution from its href
+	CatalogID string
+	// Store writes the artifact through StoreValidatedYAML; otherwise the result is only validated
+	Store bool
+	// Overwrite allows replacing a stored artifact with the same ID
+	Overwrite bool
+}
+
+// OSCALImportResult is the Gemara artifact produced from an OSCAL document
+type OSCALImportResult struct {
+	Model      string       `json:"model"`
+	Layer      int          `json:"layer"`
+	ArtifactID string       `json:"artifact_id"`
+	Title      string       `json:"title"`
+	Counts     []oscalCount `json:"counts"`
+	Notes      []string     `json:"notes,omitempty"`
+	Valid      bool         `json:"valid"`
+	Errors     []string     `json:"errors,omitempty"`
+	Stored     bool         `json:"stored"`
+	YAML       string       `json:"yaml,omitempty"`
+}
+
+// oscalCount is the number of elements of one kind in an imported artifact
+type oscalCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// handleImportOSCAL converts an OSCAL catalog or profile into a Gemara artifact and stores it
+func (g *GemaraAuthoringTools) handleImportOSCAL(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	content, err := rawArgument(request, "oscal_content")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	options := OSCALImportOptions{
+		Layer:        request.GetInt("layer", consts.Layer2),
+		ID:           request.GetString("artifact_id", ""),
+		DocumentType: request.GetString("document_type", ""),
+		CatalogID:    request.GetString("catalog_id", ""),
+		Store:        request.GetBool("store", true),
+		Overwrite:    request.GetBool("overwrite", false),
+	}
+	outputFormat := request.GetString("output_format", "markdown")
+
+	result, err := g.ImportOSCAL(content, options)
+	if err != nil {
+		return mcp.NewToolResultErrorf("Failed to import OSCAL: %v", err), nil
+	}
+
+	if outputFormat == "json" {
+		output, err := marshalOutput(result, "json")
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal JSON: %v", err), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}
+	return mcp.NewToolResultText(result.ToMarkdown()), nil
+}
+
+// ImportOSCAL converts an OSCAL catalog into a Layer 1 GuidanceDocument or Layer 2 Catalog, or an OSCAL
+// profile into a Layer 3 Policy. The artifact is validated and, when options.Store is set, stored.
+func (g *GemaraAuthoringTools) ImportOSCAL(data []byte, options OSCALImportOptions) (*OSCALImportResult, error) {
+	doc, err := oscal.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	converter := &oscalConverter{g: g, options: options}
+	var artifact interface{}
+	switch {
+	case doc.Catalog != nil && options.Layer == consts.Layer1:
+		artifact, err = converter.guidance(doc.Catalog)
+	case doc.Catalog != nil && options.Layer == consts.Layer2:
+		artifact, err = converter.catalog(doc.Catalog)
+	case doc.Catalog != nil:
+		return nil, fmt.Errorf("OSCAL catalogs import into Layer 1 or 2, got layer %d", options.Layer)
+	default:
+		options.Layer = consts.Layer3
+		artifact, err = converter.policy(doc.Profile)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &OSCALImportResult{
+		Model:      doc.Model(),
+		Layer:      options.Layer,
+		ArtifactID: converter.id,
+		Title:      converter.title,
+		Counts:     converter.counts,
+		Notes:      converter.notes,
+	}
+	result.YAML, err = marshalArtifactYAML(artifact)
+	if err != nil {
+		return nil, err
+	}
+
+	if !options.Store {
+		if failure := g.cueValidationFailure(result.YAML, options.Layer); failure != "" {
+			result.Errors = append(result.Errors, failure)
+		} else {
+			result.Valid = true
+		}
+		return result, nil
+	}
+
+	if !options.Overwrite {
+		for _, entry := range g.getLayerEntries(options.Layer) {
+			if entry.ID == result.ArtifactID {
+				return nil, fmt.Errorf("a Layer %d artifact with ID '%s' is already stored; set overwrite or choose another ID", options.Layer, result.ArtifactID)
+			}
+		}
+	}
+	storedID, err := g.StoreValidatedYAML(options.Layer, result.YAML)
+	if err != nil {
+		return nil, err
+	}
+	g.refreshCachedArtifact(options.Layer, storedID)
+	result.Valid = true
+	result.Stored = true
+	return result, nil
+}
+
+// ToMarkdown renders the import result, including the artifact YAML when it was not stored
+func (r *OSCALImportResult) ToMarkdown() string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("## OSCAL Import: %s → Layer %d `%s`\n\n", r.Model, r.Layer, r.ArtifactID))
+	result.WriteString(fmt.Sprintf("- **Title**: %s\n", r.Title))
+	for _, count := range r.Counts {
+		result.WriteString(fmt.Sprintf("- **%s**: %d\n", count.Name, count.Count))
+	}
+	if r.Valid {
+		result.WriteString("- **CUE Validation**: ✅ PASSED\n")
+	} else {
+		result.WriteString("- **CUE Validation**: ❌ FAILED\n")
+	}
+	if r.Stored {
+		result.WriteString("- **Stored**: ✅\n")
+	} else {
+		result.WriteString("- **Stored**: no (preview)\n")
+	}
+	result.WriteString("\n")
+
+	for _, err := range r.Errors {
+		result.WriteString(fmt.Sprintf("❌ %s\n\n", err))
+	}
+	if len(r.Notes) > 0 {
+		result.WriteString("### Conversion Notes\n\n")
+		for _, note := range r.Notes {
+			result.WriteString(fmt.Sprintf("- %s\n", note))
+		}
+		result.WriteString("\n")
+	}
+	if r.Stored {
+		getTool := map[int]string{consts.Layer1: "get_layer1_guidance", consts.Layer2: "list_layer2_controls", consts.Layer3: "get_layer3_policy"}[r.Layer]
+		result.WriteString(fmt.Sprintf("Use %s with ID '%s' to query the imported artifact.\n", getTool, r.ArtifactID))
+		return result.String()
+	}
+	result.WriteString(fmt.Sprintf("```yaml\n%s```\n", r.YAML))
+	return result.String()
+}
+
+// oscalConverter builds one Gemara artifact from an OSCAL document and collects notes on what
+// could not be carried over
+type oscalConverter struct {
+	g       *GemaraAuthoringTools
+	options OSCALImportOptions
+	id      string
+	title   string
+	params  map[string]oscal.Parameter
+	counts  []oscalCount
+	notes   []string
+	// dropped counts parts by name that have no Gemara equivalent
+	dropped map[string]int
+}
+
+// oscalItem is a control or enhancement of a catalog, flattened with its family and parent
+type oscalItem struct {
+	control *oscal.Control
+	family  string
+	parent  string
+}
+
+// guidance converts an OSCAL catalog into a Layer 1 GuidanceDocument. Controls and enhancements
+// become guidelines, statement items become guideline parts, and guidance becomes recommendations.
+func (c *oscalConverter) guidance(catalog *oscal.Catalog) (*gemara.GuidanceDocument, error) {
+	documentType := c.options.DocumentType
+	if documentType == "" {
+		documentType = "Framework"
+	}
+	switch documentType {
+	case "Standard", "Regulation", "Best Practice", "Framework":
+	default:
+		return nil, fmt.Errorf("invalid document_type '%s': must be Standard, Regulation, Best Practice, or Framework", documentType)
+	}
+
+	metadata, err := c.metadata(consts.Layer1, "catalog", catalog.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	c.params = catalog.ParamIndex()
+	families, items := c.flatten(catalog)
+
+	doc := &gemara.GuidanceDocument{
+		Title:        c.title,
+		Metadata:     metadata,
+		DocumentType: gemara.DocumentType(documentType),
+		FrontMatter:  catalog.Metadata.Remarks,
+		Families:     families,
+	}
+	statements := 0
+	for _, item := range items {
+		guideline := gemara.Guideline{
+			Id:              item.control.ID,
+			Title:           item.control.Title,
+			Family:          item.family,
+			Recommendations: c.paragraphs(item.control.Part("guidance")),
+			SeeAlso:         relatedControls(item.control),
+		}
+		if item.parent != "" {
+			guideline.Extends = &gemara.SingleMapping{EntryId: item.parent}
+		}
+		if statement := item.control.Part("statement"); statement != nil {
+			guideline.Objective = c.prose(statement.Prose)
+			guideline.Statements = c.statements(item.control.ID, statement.Parts)
+		}
+		c.dropParts(item.control.Parts, "statement", "guidance")
+		statements += len(guideline.Statements)
+		doc.Guidelines = append(doc.Guidelines, guideline)
+	}
+
+	c.count("Families", len(doc.Families))
+	c.count("Guidelines", len(doc.Guidelines))
+	c.count("Guideline Parts", statements)
+	c.noteDropped()
+	return doc, nil
+}
+
+// catalog converts an OSCAL catalog into a Layer 2 Catalog. Controls and enhancements become
+// controls and each top-level statement item becomes an assessment requirement.
+func (c *oscalConverter) catalog(catalog *oscal.Catalog) (*gemara.Catalog, error) {
+	metadata, err := c.metadata(consts.Layer2, "catalog", catalog.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	c.params = catalog.ParamIndex()
+	families, items := c.flatten(catalog)
+
+	result := &gemara.Catalog{
+		Title:    c.title,
+		Metadata: metadata,
+		Families: families,
+	}
+	requirements := 0
+	unplacedGuidance := 0
+	for _, item := range items {
+		control := gemara.Control{
+			Id:                     item.control.ID,
+			Title:                  item.control.Title,
+			Family:                 item.family,
+			AssessmentRequirements: []gemara.AssessmentRequirement{},
+		}
+		guidance := strings.Join(c.paragraphs(item.control.Part("guidance")), "\n\n")
+
+		if statement := item.control.Part("statement"); statement != nil {
+			control.Objective = c.renderStatement(statement)
+			for i, part := range statement.Parts {
+				if part.Name != "item" {
+					continue
+				}
+				control.AssessmentRequirements = append(control.AssessmentRequirements, gemara.AssessmentRequirement{
+					Id:            partID(part, fmt.Sprintf("%s.%d", item.control.ID, i+1)),
+					Text:          c.renderItem(part, ""),
+					Applicability: []string{},
+				})
+			}
+			if len(control.AssessmentRequirements) == 0 && control.Objective != "" && !hasRequirementChildren(item.control) {
+				control.AssessmentRequirements = append(control.AssessmentRequirements, gemara.AssessmentRequirement{
+					Id:            partID(*statement, item.control.ID+"_smt"),
+					Text:          control.Objective,
+					Applicability: []string{},
+				})
+			}
+		}
+		// Child controls written by export_oscal carry the assessment requirements
+		for _, child := range item.control.Controls {
+			if child.Class != assessmentRequirementClass {
+				continue
+			}
+			requirement := gemara.AssessmentRequirement{Id: child.ID, Applicability: []string{}}
+			if statement := child.Part("statement"); statement != nil {
+				requirement.Text = c.prose(statement.Prose)
+			}
+			requirement.Recommendation = strings.Join(c.paragraphs(child.Part("guidance")), "\n\n")
+			if applicability := oscal.Prop(child.Props, "applicability"); applicability != "" {
+				requirement.Applicability = strings.Split(applicability, ",")
+			}
+			control.AssessmentRequirements = append(control.AssessmentRequirements, requirement)
+		}
+
+		switch {
+		case guidance == "":
+		case len(control.AssessmentRequirements) == 1 && control.AssessmentRequirements[0].Recommendation == "":
+			control.AssessmentRequirements[0].Recommendation = guidance
+		default:
+			unplacedGuidance++
+		}
+		c.dropParts(item.control.Parts, "statement", "guidance")
+		requirements += len(control.AssessmentRequirements)
+		result.Controls = append(result.Controls, control)
+	}
+
+	c.count("Families", len(result.Families))
+	c.count("Controls", len(result.Controls))
+	c.count("Assessment Requirements", requirements)
+	if unplacedGuidance > 0 {
+		c.note("Guidance of %d control(s) with several statement items was not imported; Layer 2 controls have no guidance field. Import the catalog as Layer 1 to keep it as recommendations.", unplacedGuidance)
+	}
+	c.noteDropped()
+	return result, nil
+}
+
+// policy converts an OSCAL profile into a Layer 3 Policy. Each import becomes a catalog or guidance
+// import, excluded and unselected controls become exclusions, and parameter settings and added
+// parts become constraints.
+func (c *oscalConverter) policy(profile *oscal.Profile) (*gemara.Policy, error) {
+	metadata, err := c.metadata(consts.Layer3, "profile", profile.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	if len(profile.Imports) == 0 {
+		return nil, fmt.Errorf("OSCAL profile has no imports")
+	}
+	if c.options.CatalogID != "" && len(profile.Imports) > 1 {
+		return nil, fmt.Errorf("catalog_id can only be used with profiles that have a single import, this one has %d", len(profile.Imports))
+	}
+
+	policy := &gemara.Policy{
+		Title:    c.title,
+		Metadata: metadata,
+		Contacts: gemara.Contacts{
+			Responsible: []gemara.Contact{{Name: "TODO: team responsible for implementing the controls"}},
+			Accountable: []gemara.Contact{{Name: "TODO: owner accountable for the policy"}},
+		},
+		Scope: gemara.Scope{
+			In: gemara.Dimensions{
+				Technologies: []string{"TODO: in-scope technologies"},
+			},
+		},
+	}
+
+	imports := make([]*profileImport, 0, len(profile.Imports))
+	for _, imported := range profile.Imports {
+		resolved := c.resolveImport(imported, profile.BackMatter)
+		imports = append(imports, resolved)
+		policy.Metadata.MappingReferences = append(policy.Metadata.MappingReferences, c.g.scaffoldMappingReference(resolved.referenceID))
+	}
+
+	constraintIDs := idAllocator{}
+	modificationIDs := idAllocator{}
+	if profile.Modify != nil {
+		for _, set := range profile.Modify.SetParameters {
+			controlID := paramControl(set.ParamID)
+			target := importFor(imports, controlID)
+			target.constraints = append(target.constraints, gemara.Constraint{
+				Id:       constraintIDs.next(c.g.sanitizeID(fmt.Sprintf("%s %s", c.id, set.ParamID))),
+				TargetId: controlID,
+				Text:     setParameterText(set),
+			})
+		}
+		for _, alter := range profile.Modify.Alters {
+			target := importFor(imports, alter.ControlID)
+			for _, add := range alter.Adds {
+				for _, part := range add.Parts {
+					text := c.renderItem(part, "")
+					if part.Title != "" {
+						text = fmt.Sprintf("%s: %s", part.Title, text)
+					}
+					if text == "" {
+						continue
+					}
+					target.constraints = append(target.constraints, gemara.Constraint{
+						Id:       constraintIDs.next(c.g.sanitizeID(fmt.Sprintf("%s %s %s", c.id, alter.ControlID, partID(part, part.Name)))),
+						TargetId: alter.ControlID,
+						Text:     text,
+					})
+				}
+			}
+			for _, remove := range alter.Removes {
+				if remove.ByID == "" || !target.hasRequirement(remove.ByID) {
+					c.note("Removal from %s (%s) was not imported; only removals of assessment requirements by ID map to Layer 3 modifications.", alter.ControlID, removalSelector(remove))
+					continue
+				}
+				target.modifications = append(target.modifications, gemara.AssessmentRequirementModifier{
+					Id:                    modificationIDs.next(c.g.sanitizeID(fmt.Sprintf("%s remove %s", c.id, remove.ByID))),
+					TargetId:              remove.ByID,
+					ModificationType:      "remove",
+					ModificationRationale: fmt.Sprintf("Removed by OSCAL profile %s.", c.title),
+				})
+			}
+		}
+	}
+
+	constraints := 0
+	for _, imported := range imports {
+		constraints += len(imported.constraints)
+		if imported.guidance {
+			policy.Imports.Guidance = append(policy.Imports.Guidance, gemara.GuidanceImport{
+				ReferenceId: imported.referenceID,
+				Exclusions:  imported.exclusions,
+				Constraints: imported.constraints,
+			})
+			continue
+		}
+		policy.Imports.Catalogs = append(policy.Imports.Catalogs, gemara.CatalogImport{
+			ReferenceId:                        imported.referenceID,
+			Exclusions:                         imported.exclusions,
+			Constraints:                        imported.constraints,
+			AssessmentRequirementModifications: imported.modifications,
+		})
+	}
+
+	c.count("Catalog Imports", len(policy.Imports.Catalogs))
+	c.count("Guidance Imports", len(policy.Imports.Guidance))
+	c.count("Constraints", constraints)
+	c.note("Contacts and scope are placeholders; OSCAL profiles do not describe them. Replace the TODO values before relying on the policy.")
+	return policy, nil
+}
+
+// profileImport is a profile import resolved to a stored Gemara artifact
+type profileImport struct {
+	referenceID   string
+	guidance      bool
+	catalog       *gemara.Catalog
+	exclusions    []string
+	constraints   []gemara.Constraint
+	modifications []gemara.AssessmentRequirementModifier
+}
+
+// hasRequirement reports whether the imported catalog is stored and has an assessment requirement with the ID
+func (p *profileImport) hasRequirement(id string) bool {
+	if p.catalog == nil {
+		return false
+	}
+	for _, control := range p.catalog.Controls {
+		if containsRequirement(control.AssessmentRequirements, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveImport matches a profile import to a stored Layer 2 catalog or Layer 1 guidance document
+// and turns its control selection into exclusions
+func (c *oscalConverter) resolveImport(imported oscal.ProfileImport, backMatter *oscal.BackMatter) *profileImport {
+	candidates := []string{imported.Href}
+	if strings.HasPrefix(imported.Href, "#") {
+		candidates = nil
+		if resource := backMatter.Resource(strings.TrimPrefix(imported.Href, "#")); resource != nil {
+			candidates = append(candidates, oscal.Prop(resource.Props, "id"), resource.Title)
+			for _, link := range resource.Rlinks {
+				candidates = append(candidates, link.Href)
+			}
+		}
+	}
+	if c.options.CatalogID != "" {
+		candidates = []string{c.options.CatalogID}
+	}
+
+	resolved := &profileImport{}
+	var available []string
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		name := strings.TrimSuffix(path.Base(candidate), path.Ext(candidate))
+		for _, key := range []string{candidate, name, c.g.sanitizeID(name)} {
+			if catalog := c.g.loadLayer2Catalog(key); catalog != nil {
+				resolved.referenceID, resolved.catalog = key, catalog
+				break
+			}
+			if guidance := c.g.loadLayer1Guidance(key); guidance != nil {
+				resolved.referenceID, resolved.guidance = key, true
+				for _, guideline := range guidance.Guidelines {
+					available = append(available, guideline.Id)
+				}
+				break
+			}
+		}
+		if resolved.referenceID == "" {
+			resolved.referenceID = c.storedIDByTitle(candidate)
+		}
+		if resolved.referenceID != "" {
+			break
+		}
+	}
+	if resolved.catalog == nil && resolved.referenceID != "" && !resolved.guidance {
+		resolved.catalog = c.g.loadLayer2Catalog(resolved.referenceID)
+	}
+	if resolved.catalog != nil {
+		for _, control := range resolved.catalog.Controls {
+			available = append(available, control.Id)
+		}
+	}
+
+	if resolved.referenceID == "" {
+		fallback := imported.Href
+		for _, candidate := range candidates {
+			if candidate != "" {
+				fallback = candidate
+				break
+			}
+		}
+		resolved.referenceID = c.g.sanitizeID(strings.TrimSuffix(path.Base(fallback), path.Ext(fallback)))
+		if resolved.referenceID == "" {
+			resolved.referenceID = "todo-catalog"
+		}
+		c.note("Import '%s' does not match a stored catalog or guidance document; it is referenced as '%s'. Import the source catalog first, or pass catalog_id.", imported.Href, resolved.referenceID)
+	}
+
+	for _, exclude := range imported.ExcludeControls {
+		resolved.exclusions = append(resolved.exclusions, selectControls(exclude, available)...)
+	}
+	if len(imported.IncludeControls) > 0 && imported.IncludeAll == nil {
+		if len(available) == 0 {
+			c.note("The controls selected from '%s' could not be converted into exclusions because it is not stored; the policy imports all of its controls.", resolved.referenceID)
+		} else {
+			included := make(map[string]bool)
+			for _, include := range imported.IncludeControls {
+				for _, id := range selectControls(include, available) {
+					included[id] = true
+				}
+			}
+			for _, id := range available {
+				if !included[id] {
+					resolved.exclusions = append(resolved.exclusions, id)
+				}
+			}
+		}
+	}
+	resolved.exclusions = uniqueStrings(resolved.exclusions)
+	return resolved
+}
+
+// storedIDByTitle returns the ID of a stored Layer 2 catalog or Layer 1 guidance document with the given title, or ""
+func (c *oscalConverter) storedIDByTitle(title string) string {
+	for _, layer := range []int{consts.Layer2, consts.Layer1} {
+		for _, entry := range c.g.getLayerEntries(layer) {
+			if entry.Title != "" && strings.EqualFold(entry.Title, title) {
+				return entry.ID
+			}
+		}
+	}
+	return ""
+}
+
+// metadata builds the artifact metadata from OSCAL metadata, generating an ID from the title unless one is given
+func (c *oscalConverter) metadata(layer int, model string, source oscal.Metadata) (gemara.Metadata, error) {
+	c.title = strings.TrimSpace(source.Title)
+	if c.title == "" {
+		return gemara.Metadata{}, fmt.Errorf("OSCAL %s metadata has no title", model)
+	}
+	c.id = strings.TrimSpace(c.options.ID)
+	if c.id == "" {
+		c.id = c.g.sanitizeID(c.title)
+	}
+	if c.id == "" {
+		return gemara.Metadata{}, fmt.Errorf("could not derive an ID from title '%s'; provide an artifact ID", c.title)
+	}
+
+	description := strings.TrimSpace(source.Remarks)
+	if description == "" {
+		description = fmt.Sprintf("Imported from the OSCAL %s \"%s\".", model, c.title)
+	}
+	metadata := gemara.Metadata{
+		Id:          c.id,
+		Version:     source.Version,
+		Date:        oscalDate(source.LastModified, source.Published),
+		Description: description,
+		Author:      oscalAuthor(source, c.g.sanitizeID),
+	}
+	if metadata.Author.Id == "todo-author" {
+		c.note("The OSCAL metadata names no creator party; metadata.author is a placeholder.")
+	}
+	return metadata, nil
+}
+
+// flatten walks the catalog's groups and returns a family per group that has controls, and every
+// control and enhancement in document order. Withdrawn controls and assessment requirement
+// children are left out.
+func (c *oscalConverter) flatten(catalog *oscal.Catalog) ([]gemara.Family, []oscalItem) {
+	var families []gemara.Family
+	var items []oscalItem
+	familyIDs := idAllocator{}
+	withdrawn := 0
+
+	var addControls func(controls []oscal.Control, family, parent string)
+	addControls = func(controls []oscal.Control, family, parent string) {
+		for i := range controls {
+			control := &controls[i]
+			if control.Class == assessmentRequirementClass {
+				continue
+			}
+			if control.Withdrawn() {
+				withdrawn++
+				continue
+			}
+			items = append(items, oscalItem{control: control, family: family, parent: parent})
+			addControls(control.Controls, family, control.ID)
+		}
+	}
+	addFamily := func(id, title, description string) string {
+		familyID := familyIDs.next(id)
+		if description == "" {
+			description = title
+		}
+		families = append(families, gemara.Family{Id: familyID, Title: title, Description: description})
+		return familyID
+	}
+
+	if len(catalog.Controls) > 0 {
+		addControls(catalog.Controls, addFamily("general", "General", "Controls that are not part of a group in the OSCAL catalog."), "")
+	}
+	var addGroups func(groups []oscal.Group)
+	addGroups = func(groups []oscal.Group) {
+		for _, group := range groups {
+			if len(group.Controls) > 0 {
+				id := group.ID
+				if id == "" {
+					id = c.g.sanitizeID(group.Title)
+				}
+				var description string
+				for _, part := range group.Parts {
+					if part.Prose != "" {
+						description = c.prose(part.Prose)
+						break
+					}
+				}
+				addControls(group.Controls, addFamily(id, group.Title, description), "")
+			}
+			addGroups(group.Groups)
+		}
+	}
+	addGroups(catalog.Groups)
+
+	if withdrawn > 0 {
+		c.note("%d withdrawn control(s) were skipped.", withdrawn)
+	}
+	if len(c.params) > 0 {
+		c.note("%d parameter(s) were written into the prose that uses them, as their values or as [Assignment] and [Selection] placeholders.", len(c.params))
+	}
+	return families, items
+}
+
+// statements converts statement items, including nested items, into guideline parts
+func (c *oscalConverter) statements(guidelineID string, parts []oscal.Part) []gemara.Statement {
+	var statements []gemara.Statement
+	for _, part := range parts {
+		if part.Name != "item" {
+			continue
+		}
+		if text := c.prose(part.Prose); text != "" {
+			statements = append(statements, gemara.Statement{
+				Id:    partID(part, ""),
+				Title: oscal.Prop(part.Props, "label"),
+				Text:  text,
+			})
+		}
+		statements = append(statements, c.statements(guidelineID, part.Parts)...)
+	}
+	for i := range statements {
+		if statements[i].Id == "" {
+			statements[i].Id = fmt.Sprintf("%s.%d", guidelineID, i+1)
+		}
+	}
+	return statements
+}
+
+// renderStatement renders a statement part and its items as a single text
+func (c *oscalConverter) renderStatement(statement *oscal.Part) string {
+	lines := []string{}
+	if text := c.prose(statement.Prose); text != "" {
+		lines = append(lines, text)
+	}
+	for _, part := range statement.Parts {
+		if part.Name == "item" {
+			lines = append(lines, c.renderItem(part, ""))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderItem renders a part with its label and nested items, indenting each level
+func (c *oscalConverter) renderItem(part oscal.Part, indent string) string {
+	text := c.prose(part.Prose)
+	if label := oscal.Prop(part.Props, "label"); label != "" {
+		text = strings.TrimSpace(label + " " + text)
+	}
+	lines := []string{indent + text}
+	for _, child := range part.Parts {
+		if child.Name == "item" {
+			lines = append(lines, c.renderItem(child, indent+"  "))
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// paragraphs returns the prose of a part and its sub-parts with parameters resolved, one entry per paragraph
+func (c *oscalConverter) paragraphs(part *oscal.Part) []string {
+	if part == nil {
+		return nil
+	}
+	var paragraphs []string
+	for _, paragraph := range strings.Split(c.prose(part.Prose), "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	for _, child := range part.Parts {
+		paragraphs = append(paragraphs, c.paragraphs(&child)...)
+	}
+	return paragraphs
+}
+
+// prose resolves parameter insertion points and trims the text
+func (c *oscalConverter) prose(text string) string {
+	return strings.TrimSpace(oscal.ResolveProse(text, c.params))
+}
+
+// dropParts counts control parts other than the given names, which have no Gemara equivalent
+func (c *oscalConverter) dropParts(parts []oscal.Part, kept ...string) {
+	for _, part := range parts {
+		if !slices.Contains(kept, part.Name) {
+			if c.dropped == nil {
+				c.dropped = make(map[string]int)
+			}
+			c.dropped[part.Name]++
+		}
+	}
+}
+
+// noteDropped adds a note listing the part names that were not imported
+func (c *oscalConverter) noteDropped() {
+	if len(c.dropped) == 0 {
+		return
+	}
+	names := make([]string, 0, len(c.dropped))
+	for name := range c.dropped {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var counts []string
+	for _, name := range names {
+		counts = append(counts, fmt.Sprintf("%s (%d)", name, c.dropped[name]))
+	}
+	c.note("Control parts without a Gemara equivalent were not imported: %s.", strings.Join(counts, ", "))
+}
+
+func (c *oscalConverter) count(name string, count int) {
+	c.counts = append(c.counts, oscalCount{Name: name, Count: count})
+}
+
+func (c *oscalConverter) note(format string, args ...interface{}) {
+	c.notes = append(c.notes, fmt.Sprintf(format, args...))
+}
+
+// oscalDate converts the first OSCAL timestamp that parses into a Gemara date
+func oscalDate(timestamps ...string) gemara.Date {
+	for _, timestamp := range timestamps {
+		if t, err := time.Parse(time.RFC3339, timestamp); err == nil {
+			return gemara.Date(t.Format("2006-01-02"))
+		}
+	}
+	return ""
+}
+
+// oscalAuthor returns the party in the creator role, or the first organization, as the artifact author
+func oscalAuthor(metadata oscal.Metadata, sanitizeID func(string) string) gemara.Actor {
+	parties := make(map[string]oscal.Party)
+	for _, party := range metadata.Parties {
+		parties[party.UUID] = party
+	}
+	var author *oscal.Party
+	for _, role := range metadata.ResponsibleParties {
+		if role.RoleID != "creator" && role.RoleID != "prepared-by" {
+			continue
+		}
+		for _, uuid := range role.PartyUUIDs {
+			if party, ok := parties[uuid]; ok && party.Name != "" {
+				author = &party
+				break
+			}
+		}
+		if author != nil {
+			break
+		}
+	}
+	if author == nil {
+		for i := range metadata.Parties {
+			if metadata.Parties[i].Type == "organization" && metadata.Parties[i].Name != "" {
+				author = &metadata.Parties[i]
+				break
+			}
+		}
+	}
+	if author == nil || sanitizeID(author.Name) == "" {
+		return gemara.Actor{Id: "todo-author", Name: "TODO: author", Type: gemara.Human}
+	}
+	return gemara.Actor{Id: sanitizeID(author.Name), Name: author.Name, Type: gemara.Human}
+}
+
+// hasRequirementChildren reports whether the control has child controls holding its assessment requirements
+func hasRequirementChildren(control *oscal.Control) bool {
+	for _, child := range control.Controls {
+		if child.Class == assessmentRequirementClass {
+			return true
+		}
+	}
+	return false
+}
+
+// relatedControls returns the IDs of controls linked as related from the control
+func relatedControls(control *oscal.Control) []string {
+	var related []string
+	for _, link := range control.Links {
+		if link.Rel == "related" && strings.HasPrefix(link.Href, "#") {
+			related = append(related, strings.TrimPrefix(link.Href, "#"))
+		}
+	}
+	return related
+}
+
+// selectControls returns the IDs a selection names, plus their enhancements when it selects child controls
+func selectControls(selection oscal.SelectControlByID, available []string) []string {
+	var selected []string
+	for _, id := range selection.WithIDs {
+		selected = append(selected, id)
+		if selection.WithChildControls != "yes" {
+			continue
+		}
+		for _, candidate := range available {
+			if strings.HasPrefix(candidate, id+".") {
+				selected = append(selected, candidate)
+			}
+		}
+	}
+	return selected
+}
+
+// importFor returns the import whose catalog contains the control, or the first import
+func importFor(imports []*profileImport, controlID string) *profileImport {
+	for _, imported := range imports {
+		if imported.catalog == nil {
+			continue
+		}
+		for _, control := range imported.catalog.Controls {
+			if control.Id == controlID {
+				return imported
+			}
+		}
+	}
+	return imports[0]
+}
+
+// paramControl returns the control a parameter belongs to, following the OSCAL convention of
+// prefixing parameter IDs with the control ID (ac-1_prm_1)
+func paramControl(paramID string) string {
+	if i := strings.Index(paramID, "_"); i > 0 {
+		return paramID[:i]
+	}
+	return paramID
+}
+
+// setParameterText describes a profile's parameter setting as a constraint
+func setParameterText(set oscal.SetParameter) string {
+	name := set.ParamID
+	if set.Label != "" {
+		name = fmt.Sprintf("%s (%s)", set.ParamID, set.Label)
+	}
+	switch {
+	case len(set.Values) > 0:
+		return fmt.Sprintf("Set parameter %s to: %s", name, strings.Join(set.Values, ", "))
+	case set.Select != nil && len(set.Select.Choice) > 0:
+		return fmt.Sprintf("Parameter %s must be one of: %s", name, strings.Join(set.Select.Choice, "; "))
+	case len(set.Guidelines) > 0:
+		return fmt.Sprintf("Parameter %s: %s", name, strings.TrimSpace(set.Guidelines[0].Prose))
+	}
+	return fmt.Sprintf("TODO: set parameter %s", name)
+}
+
+// removalSelector describes which content an OSCAL removal targets
+func removalSelector(remove oscal.Removal) string {
+	switch {
+	case remove.ByID != "":
+		return "by-id " + remove.ByID
+	case remove.ByName != "":
+		return "by-name " + remove.ByName
+	case remove.ByClass != "":
+		return "by-class " + remove.ByClass
+	}
+	return "by-item-name " + remove.ByItemName
+}
+
+// partID returns the part's ID, or fallback when it has none
+func partID(part oscal.Part, fallback string) string {
+	if part.ID != "" {
+		return part.ID
+	}
+	return fallback
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"testing"
+
+	"github.com/complytime/gemara-mcp-server/internal/oscal"
+	"github.com/ossf/gemara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOSCALCatalog = `{"catalog": {
+  "uuid": "c1",
+  "metadata": {"title": "Test OSCAL Catalog", "last-modified": "2024-02-01T10:00:00Z", "version": "1.0",
+    "parties": [{"uuid": "p1", "type": "organization", "name": "Standards Body"}],
+    "responsible-parties": [{"role-id": "creator", "party-uuids": ["p1"]}]},
+  "groups": [{"id": "ac", "title": "Access Control", "controls": [
+    {"id": "ac-1", "title": "Policy",
+     "params": [{"id": "ac-1_prm_1", "label": "organization-defined roles"}],
+     "parts": [
+      {"id": "ac-1_smt", "name": "statement", "parts": [
+        {"id": "ac-1_smt.a", "name": "item", "props": [{"name": "label", "value": "a."}], "prose": "Disseminate to {{ insert: param, ac-1_prm_1 }}."},
+        {"id": "ac-1_smt.b", "name": "item", "props": [{"name": "label", "value": "b."}], "prose": "Review the policy."}]},
+      {"id": "ac-1_gdn", "name": "guidance", "prose": "Policy guidance."}]},
+    {"id": "ac-2", "title": "Account Management",
+     "parts": [{"id": "ac-2_smt", "name": "statement", "prose": "Manage accounts."}],
+     "controls": [
+       {"id": "ac-2.1", "title": "Automation", "parts": [{"id": "ac-2.1_smt", "name": "statement", "prose": "Automate it."}]},
+       {"id": "ac-2.2", "title": "Withdrawn", "props": [{"name": "status", "value": "withdrawn"}]}]}
+  ]}]
+}}`
+
+func TestOSCALCatalogImport(t *testing.T) {
+	g := &GemaraAuthoringTools{}
+	doc, err := oscal.Parse([]byte(testOSCALCatalog))
+	require.NoError(t, err)
+
+	converter := &oscalConverter{g: g}
+	catalog, err := converter.catalog(doc.Catalog)
+	require.NoError(t, err)
+	assert.Equal(t, "test-oscal-catalog", catalog.Metadata.Id)
+	assert.Equal(t, gemara.Date("2024-02-01"), catalog.Metadata.Date)
+	assert.Equal(t, "Standards Body", catalog.Metadata.Author.Name)
+	assert.Equal(t, []gemara.Family{{Id: "ac", Title: "Access Control", Description: "Access Control"}}, catalog.Families)
+
+	require.Len(t, catalog.Controls, 3)
+	assert.Equal(t, "a. Disseminate to [Assignment: organization-defined roles].\nb. Review the policy.", catalog.Controls[0].Objective)
+	assert.Equal(t, []gemara.AssessmentRequirement{
+		{Id: "ac-1_smt.a", Text: "a. Disseminate to [Assignment: organization-defined roles].", Applicability: []string{}},
+		{Id: "ac-1_smt.b", Text: "b. Review the policy.", Applicability: []string{}},
+	}, catalog.Controls[0].AssessmentRequirements)
+	assert.Equal(t, "ac-2.1", catalog.Controls[2].Id)
+	assert.Equal(t, "ac", catalog.Controls[2].Family)
+	assert.Equal(t, "ac-2.1_smt", catalog.Controls[2].AssessmentRequirements[0].Id)
+	assert.Contains(t, converter.notes, "1 withdrawn control(s) were skipped.")
+
+	converter = &oscalConverter{g: g, options: OSCALImportOptions{ID: "test-guidance"}}
+	guidance, err := converter.guidance(doc.Catalog)
+	require.NoError(t, err)
+	assert.Equal(t, "test-guidance", guidance.Metadata.Id)
+	assert.Equal(t, gemara.DocumentType("Framework"), guidance.DocumentType)
+	require.Len(t, guidance.Guidelines, 3)
+	assert.Equal(t, []string{"Policy guidance."}, guidance.Guidelines[0].Recommendations)
+	assert.Equal(t, []gemara.Statement{
+		{Id: "ac-1_smt.a", Title: "a.", Text: "Disseminate to [Assignment: organization-defined roles]."},
+		{Id: "ac-1_smt.b", Title: "b.", Text: "Review the policy."},
+	}, guidance.Guidelines[0].Statements)
+	assert.Equal(t, &gemara.SingleMapping{EntryId: "ac-2"}, guidance.Guidelines[2].Extends)
+
+	converter = &oscalConverter{g: g, options: OSCALImportOptions{DocumentType: "Checklist"}}
+	_, err = converter.guidance(doc.Catalog)
+	assert.ErrorContains(t, err, "invalid document_type")
+}
+
+func TestOSCALProfileImport(t *testing.T) {
+	g := &GemaraAuthoringTools{
+		layer1Guidance: map[string]*gemara.GuidanceDocument{},
+		layer2Catalogs: map[string]*gemara.Catalog{},
+	}
+	doc, err := oscal.Parse([]byte(testOSCALCatalog))
+	require.NoError(t, err)
+	catalog, err := (&oscalConverter{g: g}).catalog(doc.Catalog)
+	require.NoError(t, err)
+	g.layer2Catalogs[catalog.Metadata.Id] = catalog
+
+	profile := `profile:
+  uuid: p1
+  metadata:
+    title: Test Baseline
+  imports:
+    - href: "#r1"
+      include-controls:
+        - with-ids: [ac-2]
+          with-child-controls: "yes"
+  modify:
+    set-parameters:
+      - param-id: ac-1_prm_1
+        values: [security team]
+    alters:
+      - control-id: ac-2
+        adds:
+          - parts:
+              - id: ac-2_fr
+                name: item
+                prose: Review accounts monthly.
+        removes:
+          - by-id: ac-2_smt
+          - by-name: guidance
+  back-matter:
+    resources:
+      - uuid: r1
+        rlinks:
+          - href: catalogs/test-oscal-catalog.json
+`
+	doc, err = oscal.Parse([]byte(profile))
+	require.NoError(t, err)
+	converter := &oscalConverter{g: g}
+	policy, err := converter.policy(doc.Profile)
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-baseline", policy.Metadata.Id)
+	require.Len(t, policy.Metadata.MappingReferences, 1)
+	assert.Equal(t, "Test OSCAL Catalog", policy.Metadata.MappingReferences[0].Title)
+	require.Len(t, policy.Imports.Catalogs, 1)
+	imported := policy.Imports.Catalogs[0]
+	assert.Equal(t, "test-oscal-catalog", imported.ReferenceId)
+	assert.Equal(t, []string{"ac-1"}, imported.Exclusions)
+	assert.Equal(t, []gemara.Constraint{
+		{Id: "test-baseline-ac-1_prm_1", TargetId: "ac-1", Text: "Set parameter ac-1_prm_1 to: security team"},
+		{Id: "test-baseline-ac-2-ac-2_fr", TargetId: "ac-2", Text: "Review accounts monthly."},
+	}, imported.Constraints)
+	require.Len(t, imported.AssessmentRequirementModifications, 1)
+	assert.Equal(t, "ac-2_smt", imported.AssessmentRequirementModifications[0].TargetId)
+	assert.Equal(t, gemara.ModType("remove"), imported.AssessmentRequirementModifications[0].ModificationType)
+	assert.Contains(t, converter.notes, "Removal from ac-2 (by-name guidance) was not imported; only removals of assessment requirements by ID map to Layer 3 modifications.")
+}
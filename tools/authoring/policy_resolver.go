@@ -0,0 +1,467 @@
+package authoring
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/complytime/gemara-mcp-server/internal/scope"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+)
+
+// Provenance sources recorded on resolved controls and requirements
+const (
+	provenanceCatalog      = "catalog"
+	provenanceConstraint   = "constraint"
+	provenanceModification = "modification"
+)
+
+// policyProvenance records where an effective control or requirement, or a change to it, came from
+type policyProvenance struct {
+	Source    string `json:"source"`
+	ID        string `json:"id"`
+	Change    string `json:"change,omitempty"`
+	Rationale string `json:"rationale,omitempty"`
+}
+
+// policyConstraint is a policy constraint attached to an effective control
+type policyConstraint struct {
+	ID       string `json:"id"`
+	TargetID string `json:"target_id"`
+	Text     string `json:"text"`
+}
+
+// resolvedRequirement is an assessment requirement after the policy modifications were applied
+type resolvedRequirement struct {
+	ID             string             `json:"id"`
+	Text           string             `json:"text"`
+	Applicability  []string           `json:"applicability,omitempty"`
+	Recommendation string             `json:"recommendation,omitempty"`
+	Provenance     []policyProvenance `json:"provenance"`
+}
+
+// resolvedControl is a control that remains in effect for a policy
+type resolvedControl struct {
+	CatalogID    string                `json:"catalog_id"`
+	ControlID    string                `json:"control_id"`
+	Title        string                `json:"title"`
+	Objective    string                `json:"objective,omitempty"`
+	Family       string                `json:"family,omitempty"`
+	Constraints  []policyConstraint    `json:"constraints,omitempty"`
+	Requirements []resolvedRequirement `json:"requirements"`
+	Provenance   []policyProvenance    `json:"provenance"`
+}
+
+// excludedElement is a control or requirement dropped by the policy, with the reason
+type excludedElement struct {
+	CatalogID string `json:"catalog_id"`
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	Reason    string `json:"reason"`
+}
+
+// resolvedPolicy is the effective, organization-specific control set of a Layer 3 policy
+type resolvedPolicy struct {
+	PolicyID string            `json:"policy_id"`
+	Title    string            `json:"title"`
+	Controls []resolvedControl `json:"controls"`
+	Excluded []excludedElement `json:"excluded"`
+	Warnings []string          `json:"warnings,omitempty"`
+}
+
+// handleResolvePolicyControls returns the effective control set of a Layer 3 policy
+func (g *GemaraAuthoringTools) handleResolvePolicyControls(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	policyID := request.GetString("policy_id", "")
+	outputFormat := request.GetString("output_format", "markdown")
+
+	if policyID == "" {
+		return mcp.NewToolResultError("policy_id is required"), nil
+	}
+
+	resolved, err := g.resolvePolicyControls(policyID)
+	if err != nil {
+		return mcp.NewToolResultErrorf("%v", err), nil
+	}
+
+	if outputFormat == "json" {
+		output, err := marshalOutput(resolved, "json")
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal JSON: %v", err), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}
+
+	return mcp.NewToolResultText(resolved.toMarkdown()), nil
+}
+
+// resolvePolicyControls loads the catalogs imported by a policy, applies its scope, exclusions,
+// constraints, and assessment requirement modifications, and returns the effective control set
+func (g *GemaraAuthoringTools) resolvePolicyControls(policyID string) (*resolvedPolicy, error) {
+	policy := g.loadLayer3Policy(policyID)
+	if policy == nil {
+		return nil, fmt.Errorf("Policy with ID '%s' not found. Use list_layer3_policies to see available policies.", policyID)
+	}
+	return g.resolvePolicy(policyID, policy), nil
+}
+
+// resolvePolicy returns the effective control set of a policy that need not be stored
+func (g *GemaraAuthoringTools) resolvePolicy(policyID string, policy *gemara.Policy) *resolvedPolicy {
+	resolved := &resolvedPolicy{
+		PolicyID: policyID,
+		Title:    policy.Title,
+		Controls: []resolvedControl{},
+		Excluded: []excludedElement{},
+	}
+
+	for _, imported := range policy.Imports.Policies {
+		resolved.Warnings = append(resolved.Warnings, fmt.Sprintf("Imported policy '%s' is not expanded; resolve it separately.", imported))
+	}
+
+	for _, imported := range policy.Imports.Catalogs {
+		catalog := g.loadLayer2Catalog(imported.ReferenceId)
+		if catalog == nil {
+			resolved.Warnings = append(resolved.Warnings, fmt.Sprintf("Imported catalog '%s' is not stored; its controls could not be resolved.", imported.ReferenceId))
+			continue
+		}
+		resolved.applyCatalogImport(g.scopeEngine, policy, catalog, imported)
+	}
+
+	return resolved
+}
+
+// applyCatalogImport resolves the controls of a single imported catalog
+func (r *resolvedPolicy) applyCatalogImport(engine *scope.Engine, policy *gemara.Policy, catalog *gemara.Catalog, imported gemara.CatalogImport) {
+	catalogID := imported.ReferenceId
+	excluded := make(map[string]bool)
+	for _, id := range imported.Exclusions {
+		excluded[id] = true
+	}
+	inScope, outOfScope := scopeCategories(engine, policy.Scope, catalog)
+
+	// Constraints and modifications are reported only when their target is not in the catalog at all,
+	// since targets that were excluded or removed are still valid references
+	targets := make(map[string]bool)
+	for _, control := range catalog.Controls {
+		targets[control.Id] = true
+		for _, req := range control.AssessmentRequirements {
+			targets[req.Id] = true
+		}
+	}
+
+	for _, control := range catalog.Controls {
+		if excluded[control.Id] {
+			r.Excluded = append(r.Excluded, excludedElement{CatalogID: catalogID, ID: control.Id, Kind: elementControl, Reason: "listed in policy exclusions"})
+			continue
+		}
+
+		// A remove that targets the control drops it with all of its requirements
+		removedBy := ""
+		for _, modifier := range imported.AssessmentRequirementModifications {
+			if modifier.TargetId == control.Id && isRemoveModification(modifier) {
+				removedBy = modifier.Id
+				break
+			}
+		}
+		if removedBy != "" {
+			r.Excluded = append(r.Excluded, excludedElement{CatalogID: catalogID, ID: control.Id, Kind: elementControl,
+				Reason: fmt.Sprintf("removed by modification %s", removedBy)})
+			continue
+		}
+
+		effective := resolvedControl{
+			CatalogID:    catalogID,
+			ControlID:    control.Id,
+			Title:        control.Title,
+			Objective:    control.Objective,
+			Family:       control.Family,
+			Requirements: []resolvedRequirement{},
+			Provenance:   []policyProvenance{{Source: provenanceCatalog, ID: catalogID}},
+		}
+
+		requirementIDs := make(map[string]bool)
+		for _, req := range control.AssessmentRequirements {
+			requirementIDs[req.Id] = true
+		}
+
+		for _, constraint := range imported.Constraints {
+			if constraint.TargetId == control.Id || requirementIDs[constraint.TargetId] {
+				effective.Constraints = append(effective.Constraints, policyConstraint{ID: constraint.Id, TargetID: constraint.TargetId, Text: constraint.Text})
+				effective.Provenance = append(effective.Provenance, policyProvenance{Source: provenanceConstraint, ID: constraint.Id, Change: "constrained"})
+			}
+		}
+
+		for _, req := range control.AssessmentRequirements {
+			if excluded[req.Id] {
+				r.Excluded = append(r.Excluded, excludedElement{CatalogID: catalogID, ID: req.Id, Kind: elementAssessmentRequirement, Reason: "listed in policy exclusions"})
+				continue
+			}
+
+			effectiveReq := resolvedRequirement{
+				ID:             req.Id,
+				Text:           req.Text,
+				Applicability:  req.Applicability,
+				Recommendation: req.Recommendation,
+				Provenance:     []policyProvenance{{Source: provenanceCatalog, ID: catalogID}},
+			}
+
+			// Modifications that target the control apply to each of its requirements
+			removed := false
+			for _, modifier := range imported.AssessmentRequirementModifications {
+				if (modifier.TargetId != req.Id && modifier.TargetId != control.Id) || isAddModification(modifier) {
+					continue
+				}
+				if isRemoveModification(modifier) {
+					r.Excluded = append(r.Excluded, excludedElement{CatalogID: catalogID, ID: req.Id, Kind: elementAssessmentRequirement,
+						Reason: fmt.Sprintf("removed by modification %s", modifier.Id)})
+					removed = true
+					break
+				}
+				applyModification(&effectiveReq, modifier)
+			}
+			if removed {
+				continue
+			}
+
+			if reason := requirementScopeReason(effectiveReq.Applicability, inScope, outOfScope); reason != "" {
+				r.Excluded = append(r.Excluded, excludedElement{CatalogID: catalogID, ID: req.Id, Kind: elementAssessmentRequirement, Reason: reason})
+				continue
+			}
+
+			effective.Requirements = append(effective.Requirements, effectiveReq)
+		}
+
+		// Additions target either the control itself or one of its requirements
+		for _, modifier := range imported.AssessmentRequirementModifications {
+			if !isAddModification(modifier) || (modifier.TargetId != control.Id && !requirementIDs[modifier.TargetId]) {
+				continue
+			}
+			added := resolvedRequirement{
+				ID:             modifier.Id,
+				Text:           modifier.Text,
+				Applicability:  modifier.Applicability,
+				Recommendation: modifier.Recommendation,
+				Provenance: []policyProvenance{{
+					Source:    provenanceModification,
+					ID:        modifier.Id,
+					Change:    string(modifier.ModificationType),
+					Rationale: modifier.ModificationRationale,
+				}},
+			}
+			effective.Requirements = append(effective.Requirements, added)
+		}
+
+		r.Controls = append(r.Controls, effective)
+	}
+
+	for _, constraint := range imported.Constraints {
+		if !targets[constraint.TargetId] {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("Constraint '%s' targets '%s', which is not a control or requirement in catalog '%s'.", constraint.Id, constraint.TargetId, catalogID))
+		}
+	}
+	for _, modifier := range imported.AssessmentRequirementModifications {
+		if !targets[modifier.TargetId] {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("Modification '%s' targets '%s', which is not a control or requirement in catalog '%s'.", modifier.Id, modifier.TargetId, catalogID))
+		}
+	}
+}
+
+// isAddModification reports whether a modifier introduces a new requirement
+func isAddModification(modifier gemara.AssessmentRequirementModifier) bool {
+	return strings.EqualFold(string(modifier.ModificationType), "add")
+}
+
+// isRemoveModification reports whether a modifier drops the targeted requirement
+func isRemoveModification(modifier gemara.AssessmentRequirementModifier) bool {
+	switch strings.ToLower(string(modifier.ModificationType)) {
+	case "remove", "exclude":
+		return true
+	}
+	return false
+}
+
+// applyModification overwrites the requirement fields set on the modifier and records the change.
+// Every modification type other than add and remove (modify, replace, override, and the older
+// increase-strictness, clarify, and reduce-strictness) updates the fields it provides.
+func applyModification(req *resolvedRequirement, modifier gemara.AssessmentRequirementModifier) {
+	if modifier.Text != "" {
+		req.Text = modifier.Text
+	}
+	if len(modifier.Applicability) > 0 {
+		req.Applicability = modifier.Applicability
+	}
+	if modifier.Recommendation != "" {
+		req.Recommendation = modifier.Recommendation
+	}
+	req.Provenance = append(req.Provenance, policyProvenance{
+		Source:    provenanceModification,
+		ID:        modifier.Id,
+		Change:    string(modifier.ModificationType),
+		Rationale: modifier.ModificationRationale,
+	})
+}
+
+// scopeCategories returns the applicability categories of a catalog named by the in-scope and out-of-scope
+// dimensions of a policy. Categories are matched by ID or title through the scope engine.
+func scopeCategories(engine *scope.Engine, policyScope gemara.Scope, catalog *gemara.Catalog) (inScope, outOfScope map[string]bool) {
+	inScope = make(map[string]bool)
+	outOfScope = make(map[string]bool)
+	inTerms := dimensionTerms(policyScope.In)
+	outTerms := dimensionTerms(policyScope.Out)
+	for _, category := range catalog.Metadata.ApplicabilityCategories {
+		names := []string{category.Id, category.Title}
+		if len(matchingScopeTerms(engine, inTerms, names)) > 0 {
+			inScope[category.Id] = true
+		}
+		if len(coveringScopeTerms(engine, outTerms, names)) > 0 {
+			outOfScope[category.Id] = true
+		}
+	}
+	return inScope, outOfScope
+}
+
+// scopeTerms are the values of a policy scope dimension, with the taxonomy dimension their synonyms resolve in
+type scopeTerms struct {
+	dimension scope.Dimension
+	values    []string
+}
+
+// dimensionTerms groups the values of a scope dimension set by taxonomy dimension.
+// Users and groups are matched against providers and industries.
+func dimensionTerms(d gemara.Dimensions) []scopeTerms {
+	return []scopeTerms{
+		{dimension: scope.Technologies, values: d.Technologies},
+		{dimension: scope.Boundaries, values: d.Geopolitical},
+		{dimension: scope.Sensitivity, values: d.Sensitivity},
+		{dimension: scope.Providers, values: d.Users},
+		{dimension: scope.Providers, values: d.Groups},
+	}
+}
+
+// scopeValues flattens the values of scope terms
+func scopeValues(terms []scopeTerms) []string {
+	var values []string
+	for _, t := range terms {
+		values = append(values, t.values...)
+	}
+	return values
+}
+
+// matchingScopeTerms returns the scope terms that match any of the values
+func matchingScopeTerms(engine *scope.Engine, terms []scopeTerms, values []string) []string {
+	var matched []string
+	for _, t := range terms {
+		matched = append(matched, engine.Dimension(t.dimension).MatchingTerms(t.values, values)...)
+	}
+	return matched
+}
+
+// coveringScopeTerms returns the scope terms that cover any of the values
+func coveringScopeTerms(engine *scope.Engine, terms []scopeTerms, values []string) []string {
+	var covering []string
+	for _, t := range terms {
+		covering = append(covering, engine.Dimension(t.dimension).CoveringTerms(t.values, values)...)
+	}
+	return covering
+}
+
+// requirementScopeReason explains why a requirement falls outside the policy scope, or returns an empty string.
+// A requirement is out of scope when all of its categories are excluded, or when the policy scope names
+// categories of the catalog and none of them apply to the requirement.
+func requirementScopeReason(applicability []string, inScope, outOfScope map[string]bool) string {
+	if len(applicability) == 0 {
+		return ""
+	}
+	if len(outOfScope) > 0 {
+		allOut := true
+		for _, category := range applicability {
+			if !outOfScope[category] {
+				allOut = false
+				break
+			}
+		}
+		if allOut {
+			return fmt.Sprintf("applicability %s is out of policy scope", strings.Join(applicability, ", "))
+		}
+	}
+	if len(inScope) > 0 {
+		for _, category := range applicability {
+			if inScope[category] {
+				return ""
+			}
+		}
+		return fmt.Sprintf("applicability %s is not in policy scope", strings.Join(applicability, ", "))
+	}
+	return ""
+}
+
+// toMarkdown renders the resolved policy as markdown
+func (r *resolvedPolicy) toMarkdown() string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("# Effective Controls: %s\n\n", r.Title))
+	result.WriteString(fmt.Sprintf("- **Policy ID**: `%s`\n", r.PolicyID))
+	requirementCount := 0
+	for _, control := range r.Controls {
+		requirementCount += len(control.Requirements)
+	}
+	result.WriteString(fmt.Sprintf("- **Controls**: %d\n", len(r.Controls)))
+	result.WriteString(fmt.Sprintf("- **Assessment Requirements**: %d\n", requirementCount))
+	result.WriteString(fmt.Sprintf("- **Excluded**: %d\n\n", len(r.Excluded)))
+
+	currentCatalog := ""
+	for _, control := range r.Controls {
+		if control.CatalogID != currentCatalog {
+			currentCatalog = control.CatalogID
+			result.WriteString(fmt.Sprintf("## Catalog: %s\n\n", currentCatalog))
+		}
+		result.WriteString(fmt.Sprintf("### %s: %s\n\n", control.ControlID, control.Title))
+		if control.Objective != "" {
+			result.WriteString(fmt.Sprintf("%s\n\n", control.Objective))
+		}
+		if len(control.Constraints) > 0 {
+			result.WriteString("**Constraints:**\n")
+			for _, constraint := range control.Constraints {
+				result.WriteString(fmt.Sprintf("- `%s` (on `%s`): %s\n", constraint.ID, constraint.TargetID, constraint.Text))
+			}
+			result.WriteString("\n")
+		}
+		if len(control.Requirements) > 0 {
+			result.WriteString("**Assessment Requirements:**\n")
+			for _, req := range control.Requirements {
+				result.WriteString(fmt.Sprintf("- **%s**: %s", req.ID, req.Text))
+				if len(req.Applicability) > 0 {
+					result.WriteString(fmt.Sprintf(" _(applicability: %s)_", strings.Join(req.Applicability, ", ")))
+				}
+				result.WriteString("\n")
+				for _, p := range req.Provenance {
+					if p.Source != provenanceModification {
+						continue
+					}
+					result.WriteString(fmt.Sprintf("  - %s by `%s`", p.Change, p.ID))
+					if p.Rationale != "" {
+						result.WriteString(fmt.Sprintf(": %s", p.Rationale))
+					}
+					result.WriteString("\n")
+				}
+			}
+			result.WriteString("\n")
+		}
+	}
+
+	if len(r.Excluded) > 0 {
+		result.WriteString("## Excluded\n\n")
+		for _, e := range r.Excluded {
+			result.WriteString(fmt.Sprintf("- `%s` (%s, %s): %s\n", e.ID, e.Kind, e.CatalogID, e.Reason))
+		}
+		result.WriteString("\n")
+	}
+
+	if len(r.Warnings) > 0 {
+		result.WriteString("## Warnings\n\n")
+		for _, w := range r.Warnings {
+			result.WriteString(fmt.Sprintf("- %s\n", w))
+		}
+	}
+
+	return result.String()
+}
@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"testing"
+
+	"github.com/complytime/gemara-mcp-server/internal/scope"
+	"github.com/ossf/gemara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePolicy(t *testing.T) {
+	modifier := func(id, target, modType string) gemara.AssessmentRequirementModifier {
+		return gemara.AssessmentRequirementModifier{Id: id, TargetId: target, ModificationType: gemara.ModType(modType), Text: id + " text"}
+	}
+	tests := []struct {
+		name         string
+		imported     gemara.CatalogImport
+		controls     []string
+		requirements map[string][]string
+		excluded     []string
+		warnings     []string
+	}{
+		{
+			name:         "whole catalog",
+			imported:     gemara.CatalogImport{ReferenceId: "test-catalog"},
+			controls:     []string{"CTL-1", "CTL-2"},
+			requirements: map[string][]string{"CTL-1": {"CTL-1.1", "CTL-1.2"}, "CTL-2": {}},
+			excluded:     []string{},
+		},
+		{
+			name:         "excluded control and requirement",
+			imported:     gemara.CatalogImport{ReferenceId: "test-catalog", Exclusions: []string{"CTL-2", "CTL-1.2"}},
+			controls:     []string{"CTL-1"},
+			requirements: map[string][]string{"CTL-1": {"CTL-1.1"}},
+			excluded:     []string{"CTL-2", "CTL-1.2"},
+		},
+		{
+			name: "modification of a requirement whose control is excluded",
+			imported: gemara.CatalogImport{
+				ReferenceId:                        "test-catalog",
+				Exclusions:                         []string{"CTL-1"},
+				Constraints:                        []gemara.Constraint{{Id: "CON-1", TargetId: "CTL-1.1", Text: "Weekly"}},
+				AssessmentRequirementModifications: []gemara.AssessmentRequirementModifier{modifier("MOD-1", "CTL-1.2", "modify")},
+			},
+			controls:     []string{"CTL-2"},
+			requirements: map[string][]string{"CTL-2": {}},
+			excluded:     []string{"CTL-1"},
+		},
+		{
+			name: "modifications after a remove",
+			imported: gemara.CatalogImport{
+				ReferenceId: "test-catalog",
+				AssessmentRequirementModifications: []gemara.AssessmentRequirementModifier{
+					modifier("MOD-1", "CTL-1.1", "remove"),
+					modifier("MOD-2", "CTL-1.1", "modify"),
+				},
+			},
+			controls:     []string{"CTL-1", "CTL-2"},
+			requirements: map[string][]string{"CTL-1": {"CTL-1.2"}, "CTL-2": {}},
+			excluded:     []string{"CTL-1.1"},
+		},
+		{
+			name: "remove targeting a control",
+			imported: gemara.CatalogImport{
+				ReferenceId:                        "test-catalog",
+				AssessmentRequirementModifications: []gemara.AssessmentRequirementModifier{modifier("MOD-1", "CTL-1", "remove")},
+			},
+			controls:     []string{"CTL-2"},
+			requirements: map[string][]string{"CTL-2": {}},
+			excluded:     []string{"CTL-1"},
+		},
+		{
+			name: "addition to a control",
+			imported: gemara.CatalogImport{
+				ReferenceId:                        "test-catalog",
+				AssessmentRequirementModifications: []gemara.AssessmentRequirementModifier{modifier("CTL-2.1", "CTL-2", "add")},
+			},
+			controls:     []string{"CTL-1", "CTL-2"},
+			requirements: map[string][]string{"CTL-1": {"CTL-1.1", "CTL-1.2"}, "CTL-2": {"CTL-2.1"}},
+			excluded:     []string{},
+		},
+		{
+			name: "unknown targets",
+			imported: gemara.CatalogImport{
+				ReferenceId:                        "test-catalog",
+				Constraints:                        []gemara.Constraint{{Id: "CON-1", TargetId: "CTL-9", Text: "Weekly"}},
+				AssessmentRequirementModifications: []gemara.AssessmentRequirementModifier{modifier("MOD-1", "ctl-1.1", "modify")},
+			},
+			controls:     []string{"CTL-1", "CTL-2"},
+			requirements: map[string][]string{"CTL-1": {"CTL-1.1", "CTL-1.2"}, "CTL-2": {}},
+			excluded:     []string{},
+			warnings: []string{
+				"Constraint 'CON-1' targets 'CTL-9', which is not a control or requirement in catalog 'test-catalog'.",
+				"Modification 'MOD-1' targets 'ctl-1.1', which is not a control or requirement in catalog 'test-catalog'.",
+			},
+		},
+		{
+			name:     "catalog not stored",
+			imported: gemara.CatalogImport{ReferenceId: "missing-catalog"},
+			excluded: []string{},
+			warnings: []string{"Imported catalog 'missing-catalog' is not stored; its controls could not be resolved."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &GemaraAuthoringTools{
+				layer2Catalogs: map[string]*gemara.Catalog{"test-catalog": testCatalog()},
+				scopeEngine:    scope.DefaultEngine(),
+			}
+			policy := &gemara.Policy{Title: "Test Policy", Imports: gemara.Imports{Catalogs: []gemara.CatalogImport{tt.imported}}}
+			resolved := g.resolvePolicy("test-policy", policy)
+
+			var controls []string
+			requirements := make(map[string][]string)
+			for _, control := range resolved.Controls {
+				controls = append(controls, control.ControlID)
+				requirements[control.ControlID] = []string{}
+				for _, req := range control.Requirements {
+					requirements[control.ControlID] = append(requirements[control.ControlID], req.ID)
+				}
+			}
+			excluded := []string{}
+			for _, element := range resolved.Excluded {
+				excluded = append(excluded, element.ID)
+			}
+			assert.Equal(t, tt.controls, controls)
+			if tt.requirements != nil {
+				assert.Equal(t, tt.requirements, requirements)
+			}
+			assert.ElementsMatch(t, tt.excluded, excluded)
+			assert.Equal(t, tt.warnings, resolved.Warnings)
+		})
+	}
+}
+
+func TestResolvePolicyProvenance(t *testing.T) {
+	g := &GemaraAuthoringTools{
+		layer2Catalogs: map[string]*gemara.Catalog{"test-catalog": testCatalog()},
+		scopeEngine:    scope.DefaultEngine(),
+	}
+	policy := &gemara.Policy{Imports: gemara.Imports{Catalogs: []gemara.CatalogImport{{
+		ReferenceId: "test-catalog",
+		Constraints: []gemara.Constraint{{Id: "CON-1", TargetId: "CTL-1.1", Text: "Weekly"}},
+		AssessmentRequirementModifications: []gemara.AssessmentRequirementModifier{
+			{Id: "MOD-1", TargetId: "CTL-1.2", ModificationType: "modify", Text: "Stricter", ModificationRationale: "Audit finding"},
+		},
+	}}}}
+
+	resolved := g.resolvePolicy("test-policy", policy)
+	require.Len(t, resolved.Controls, 2)
+	control := resolved.Controls[0]
+	assert.Equal(t, []policyConstraint{{ID: "CON-1", TargetID: "CTL-1.1", Text: "Weekly"}}, control.Constraints)
+	require.Len(t, control.Requirements, 2)
+	modified := control.Requirements[1]
+	assert.Equal(t, "Stricter", modified.Text)
+	assert.Equal(t, []policyProvenance{
+		{Source: provenanceCatalog, ID: "test-catalog"},
+		{Source: provenanceModification, ID: "MOD-1", Change: "modify", Rationale: "Audit finding"},
+	}, modified.Provenance)
+}
+
+func TestResolvePolicyControlModification(t *testing.T) {
+	g := &GemaraAuthoringTools{
+		layer2Catalogs: map[string]*gemara.Catalog{"test-catalog": testCatalog()},
+		scopeEngine:    scope.DefaultEngine(),
+	}
+	policy := &gemara.Policy{Imports: gemara.Imports{Catalogs: []gemara.CatalogImport{{
+		ReferenceId: "test-catalog",
+		AssessmentRequirementModifications: []gemara.AssessmentRequirementModifier{
+			{Id: "MOD-1", TargetId: "CTL-1", ModificationType: "override", Recommendation: "Review quarterly"},
+		},
+	}}}}
+
+	resolved := g.resolvePolicy("test-policy", policy)
+	assert.Empty(t, resolved.Warnings)
+	require.Len(t, resolved.Controls, 2)
+	require.Len(t, resolved.Controls[0].Requirements, 2)
+	for _, req := range resolved.Controls[0].Requirements {
+		assert.Equal(t, "Review quarterly", req.Recommendation)
+		assert.Equal(t, []policyProvenance{
+			{Source: provenanceCatalog, ID: "test-catalog"},
+			{Source: provenanceModification, ID: "MOD-1", Change: "override"},
+		}, req.Provenance)
+	}
+
+	policy.Imports.Catalogs[0].AssessmentRequirementModifications[0].ModificationType = "remove"
+	resolved = g.resolvePolicy("test-policy", policy)
+	require.Len(t, resolved.Controls, 1)
+	assert.Equal(t, []excludedElement{
+		{CatalogID: "test-catalog", ID: "CTL-1", Kind: elementControl, Reason: "removed by modification MOD-1"},
+	}, resolved.Excluded)
+}
@@ -14,23 +14,65 @@ func (g *GemaraAuthoringTools) registerTools() []server.ServerTool {
 	tools = append(tools, g.newGetLayer1GuidanceTool())
 	tools = append(tools, g.newSearchLayer1GuidanceTool())
 	tools = append(tools, g.newStoreLayer1YAMLTool())
+	tools = append(tools, g.newStoreLayer1CUETool())
+	tools = append(tools, g.newListLayer1GuidelinesTool())
+	tools = append(tools, g.newGetLayer1GuidelineTool())
 
 	// Layer 2 Tools
 	tools = append(tools, g.newListLayer2ControlsTool())
 	tools = append(tools, g.newGetLayer2ControlTool())
 	tools = append(tools, g.newSearchLayer2ControlsTool())
 	tools = append(tools, g.newStoreLayer2YAMLTool())
+	tools = append(tools, g.newStoreLayer2CUETool())
 	tools = append(tools, g.newGetLayer2GuidelineMappingsTool())
+	tools = append(tools, g.newGetReverseMappingsTool())
+	tools = append(tools, g.newGetBaselineForCategoryTool())
 
 	// Layer 3 Tools
 	tools = append(tools, g.newListLayer3PoliciesTool())
 	tools = append(tools, g.newGetLayer3PolicyTool())
 	tools = append(tools, g.newSearchLayer3PoliciesTool())
 	tools = append(tools, g.newStoreLayer3YAMLTool())
+	tools = append(tools, g.newStoreLayer3CUETool())
+	tools = append(tools, g.newResolvePolicyControlsTool())
+	tools = append(tools, g.newGenerateBaselineTool())
 
 	// Artifact search
 	tools = append(tools, g.newFindApplicableArtifactsTool())
 
+	// Scaffolding Tools
+	tools = append(tools, g.newScaffoldLayer1GuidanceTool())
+	tools = append(tools, g.newScaffoldLayer2CatalogTool())
+	tools = append(tools, g.newScaffoldLayer3PolicyTool())
+
+	// Editing Tools
+	tools = append(tools, g.newPatchArtifactTool())
+	tools = append(tools, g.newAddControlTool())
+	tools = append(tools, g.newUpdateControlTool())
+	tools = append(tools, g.newRemoveControlTool())
+	tools = append(tools, g.newAddGuidelineMappingTool())
+	tools = append(tools, g.newAddPolicyModificationTool())
+
+	// Migration Tools
+	tools = append(tools, g.newMigrateArtifactTool())
+
+	// Import and Export Tools
+	tools = append(tools, g.newImportOSCALTool())
+	tools = append(tools, g.newExportOSCALTool())
+	tools = append(tools, g.newExportControlsCSVTool())
+	tools = append(tools, g.newExportMappingsCSVTool())
+	tools = append(tools, g.newImportControlsCSVTool())
+	tools = append(tools, g.newRenderArtifactTool())
+
+	// Analysis Tools
+	tools = append(tools, g.newCoverageReportTool())
+	tools = append(tools, g.newGetTraceabilityGraphTool())
+	tools = append(tools, g.newAnalyzeImpactTool())
+	tools = append(tools, g.newDiffArtifactsTool())
+	tools = append(tools, g.newCheckArtifactFidelityTool())
+	tools = append(tools, g.newValidateStoredArtifactsTool())
+	tools = append(tools, g.newGetStorageHealthTool())
+
 	return tools
 }
 
@@ -41,6 +83,7 @@ func (g *GemaraAuthoringTools) newListLayer1GuidanceTool() server.ServerTool {
 		Tool: mcp.NewTool(
 			"list_layer1_guidance",
 			mcp.WithDescription("List all available Layer 1 Guidance documents. Returns a summary of all stored guidance documents with their IDs, titles, descriptions, and metadata."),
+			mcp.WithString("output_format", mcp.Description("Output format: 'yaml' (default) or 'json'.")),
 		),
 		Handler: g.handleListLayer1Guidance,
 	}
@@ -52,7 +95,7 @@ func (g *GemaraAuthoringTools) newGetLayer1GuidanceTool() server.ServerTool {
 			"get_layer1_guidance",
 			mcp.WithDescription("Get detailed information about a specific Layer 1 Guidance document by its ID. Returns the full guidance document in YAML or JSON format."),
 			mcp.WithString("guidance_id", mcp.Description("The unique identifier of the Layer 1 Guidance document to retrieve."), mcp.Required()),
-			mcp.WithString("output_format", mcp.Description("Output format: 'yaml' (default) or 'json'.")),
+			mcp.WithString("output_format", mcp.Description("Output format: 'yaml' (default), 'json', or 'original'. 'original' returns the stored file byte-for-byte, as YAML or JSON.")),
 		),
 		Handler: g.handleGetLayer1Guidance,
 	}
@@ -77,13 +120,51 @@ func (g *GemaraAuthoringTools) newStoreLayer1YAMLTool() server.ServerTool {
 	return server.ServerTool{
 		Tool: mcp.NewTool(
 			"store_layer1_yaml",
-			mcp.WithDescription("Store a Layer 1 Guidance document from raw YAML content. This preserves all YAML content without data loss. The YAML is validated with CUE before storing."),
-			mcp.WithString("yaml_content", mcp.Description("Raw YAML content containing the complete Layer-1 GuidanceDocument structure. Must include metadata.id and will be validated against the Layer 1 CUE schema."), mcp.Required()),
+			mcp.WithDescription("Store a Layer 1 Guidance document from raw YAML or JSON content. This preserves all YAML content without data loss. The content is validated with CUE before storing. New artifacts are stored as <id>.yaml or <id>.json in the format of the content; an artifact that is already stored keeps its file and format."),
+			mcp.WithString("yaml_content", mcp.Description("Raw YAML content containing the complete Layer-1 GuidanceDocument structure. Must include metadata.id and will be validated against the Layer 1 CUE schema. Provide this or json_content.")),
+			mcp.WithString("json_content", mcp.Description("Raw JSON content, as a JSON object, containing the complete Layer-1 GuidanceDocument structure. Must include metadata.id and will be validated against the Layer 1 CUE schema. Provide this or yaml_content.")),
 		),
 		Handler: g.handleStoreLayer1YAML,
 	}
 }
 
+func (g *GemaraAuthoringTools) newStoreLayer1CUETool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"store_layer1_cue",
+			mcp.WithDescription("Store a Layer 1 Guidance document authored in CUE. The source is evaluated against the Layer 1 #GuidanceDocument schema, the exported YAML is stored like store_layer1_yaml, and, when the storage keeps CUE sources, the CUE source is stored next to it as <id>.cue. The artifact is the top-level value of the source, which can import the Gemara schemas as \"github.com/ossf/gemara/schemas\" and use its own definitions, templates, comprehensions, and defaults. Use validate_gemara_cue to preview the exported YAML."),
+			mcp.WithString("cue_content", mcp.Description("CUE source whose top-level value is the complete Layer-1 GuidanceDocument, including metadata.id."), mcp.Required()),
+		),
+		Handler: g.handleStoreLayer1CUE,
+	}
+}
+
+func (g *GemaraAuthoringTools) newListLayer1GuidelinesTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"list_layer1_guidelines",
+			mcp.WithDescription("List individual Layer 1 guidelines across guidance documents and families. Returns guideline IDs, titles, objectives, and the IDs of their guideline parts."),
+			mcp.WithString("guidance_id", mcp.Description("Optional Layer 1 guidance ID to limit results to a single guidance document.")),
+			mcp.WithString("family", mcp.Description("Optional family ID to limit results to guidelines in that family.")),
+			mcp.WithString("output_format", mcp.Description("Output format: 'yaml' (default) or 'json'.")),
+		),
+		Handler: g.handleListLayer1Guidelines,
+	}
+}
+
+func (g *GemaraAuthoringTools) newGetLayer1GuidelineTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"get_layer1_guideline",
+			mcp.WithDescription("Get a single Layer 1 guideline or guideline part by its ID (e.g. 'AIR-DET-011' or 'AIR-DET-011.2'). Returns the objective, rationale, recommendations, see-also links, and mappings without loading the full guidance document."),
+			mcp.WithString("guideline_id", mcp.Description("The unique identifier of the guideline or guideline part to retrieve."), mcp.Required()),
+			mcp.WithString("guidance_id", mcp.Description("Optional Layer 1 guidance ID to search in when the guideline ID is ambiguous.")),
+			mcp.WithString("output_format", mcp.Description("Output format: 'yaml' (default) or 'json'.")),
+		),
+		Handler: g.handleGetLayer1Guideline,
+	}
+}
+
 // Layer 2 Tool Definitions
 
 func (g *GemaraAuthoringTools) newListLayer2ControlsTool() server.ServerTool {
@@ -93,6 +174,7 @@ func (g *GemaraAuthoringTools) newListLayer2ControlsTool() server.ServerTool {
 			mcp.WithDescription("List all available Layer 2 Controls with optional filtering by technology or Layer 1 reference. Returns controls grouped by catalog."),
 			mcp.WithString("technology", mcp.Description("Optional technology filter to limit results.")),
 			mcp.WithString("layer1_reference", mcp.Description("Optional Layer 1 guidance ID to filter controls that reference it.")),
+			mcp.WithString("applicability", mcp.Description("Optional applicability category ID or title (e.g. 'Maturity2' or 'tlp-green'). Only controls with at least one assessment requirement applicable to it are listed.")),
 			mcp.WithString("output_format", mcp.Description("Output format: 'yaml' (default) or 'json'.")),
 		),
 		Handler: g.handleListLayer2Controls,
@@ -105,7 +187,7 @@ func (g *GemaraAuthoringTools) newGetLayer2ControlTool() server.ServerTool {
 			"get_layer2_control",
 			mcp.WithDescription("Get detailed information about a specific Layer 2 Control by its ID. Returns the full control definition in YAML or JSON format."),
 			mcp.WithString("control_id", mcp.Description("The unique identifier of the Layer 2 Control to retrieve."), mcp.Required()),
-			mcp.WithString("output_format", mcp.Description("Output format: 'yaml' (default) or 'json'.")),
+			mcp.WithString("output_format", mcp.Description("Output format: 'yaml' (default), 'json', or 'original'. 'original' returns the stored file of the catalog that defines the control byte-for-byte, as YAML or JSON.")),
 		),
 		Handler: g.handleGetLayer2Control,
 	}
@@ -122,6 +204,7 @@ func (g *GemaraAuthoringTools) newSearchLayer2ControlsTool() server.ServerTool {
 			mcp.WithArray("boundaries", mcp.Description("Optional array of boundary/jurisdiction filters to apply.")),
 			mcp.WithArray("technologies", mcp.Description("Optional array of technology domain filters to apply.")),
 			mcp.WithArray("providers", mcp.Description("Optional array of provider/industry sector filters to apply.")),
+			mcp.WithString("applicability", mcp.Description("Optional applicability category ID or title (e.g. 'Maturity2' or 'tlp-green'). Only controls with at least one assessment requirement applicable to it are returned.")),
 			mcp.WithString("output_format", mcp.Description("Output format: 'yaml' (default) or 'json'.")),
 		),
 		Handler: g.handleSearchLayer2Controls,
@@ -132,13 +215,25 @@ func (g *GemaraAuthoringTools) newStoreLayer2YAMLTool() server.ServerTool {
 	return server.ServerTool{
 		Tool: mcp.NewTool(
 			"store_layer2_yaml",
-			mcp.WithDescription("Store a Layer 2 Control Catalog from raw YAML content. This preserves all YAML content without data loss. The YAML is validated with CUE before storing."),
-			mcp.WithString("yaml_content", mcp.Description("Raw YAML content containing the complete Layer-2 Catalog structure. Must include metadata.id and will be validated against the Layer 2 CUE schema."), mcp.Required()),
+			mcp.WithDescription("Store a Layer 2 Control Catalog from raw YAML or JSON content. This preserves all YAML content without data loss. The content is validated with CUE before storing. New artifacts are stored as <id>.yaml or <id>.json in the format of the content; an artifact that is already stored keeps its file and format."),
+			mcp.WithString("yaml_content", mcp.Description("Raw YAML content containing the complete Layer-2 Catalog structure. Must include metadata.id and will be validated against the Layer 2 CUE schema. Provide this or json_content.")),
+			mcp.WithString("json_content", mcp.Description("Raw JSON content, as a JSON object, containing the complete Layer-2 Catalog structure. Must include metadata.id and will be validated against the Layer 2 CUE schema. Provide this or yaml_content.")),
 		),
 		Handler: g.handleStoreLayer2YAML,
 	}
 }
 
+func (g *GemaraAuthoringTools) newStoreLayer2CUETool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"store_layer2_cue",
+			mcp.WithDescription("Store a Layer 2 Control Catalog authored in CUE. The source is evaluated against the Layer 2 #Catalog schema, the exported YAML is stored like store_layer2_yaml, and, when the storage keeps CUE sources, the CUE source is stored next to it as <id>.cue. The artifact is the top-level value of the source, which can import the Gemara schemas as \"github.com/ossf/gemara/schemas\" and use its own definitions, templates, comprehensions, and defaults. Use validate_gemara_cue to preview the exported YAML."),
+			mcp.WithString("cue_content", mcp.Description("CUE source whose top-level value is the complete Layer-2 Catalog, including metadata.id."), mcp.Required()),
+		),
+		Handler: g.handleStoreLayer2CUE,
+	}
+}
+
 func (g *GemaraAuthoringTools) newGetLayer2GuidelineMappingsTool() server.ServerTool {
 	return server.ServerTool{
 		Tool: mcp.NewTool(
@@ -152,6 +247,32 @@ func (g *GemaraAuthoringTools) newGetLayer2GuidelineMappingsTool() server.Server
 	}
 }
 
+func (g *GemaraAuthoringTools) newGetReverseMappingsTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"get_reverse_mappings",
+			mcp.WithDescription("Find every Layer 1 guideline and Layer 2 control that maps to an external reference entry (e.g. NIST-800-53 / CA-7 or CRA / 1.2d). Answers questions like 'what satisfies CA-7?' and includes mapping strengths and remarks."),
+			mcp.WithString("reference_id", mcp.Description("The mapping reference ID (e.g. 'NIST-800-53'), as used in guideline-mappings reference-id."), mcp.Required()),
+			mcp.WithString("entry_id", mcp.Description("Optional entry within the reference (e.g. 'CA-7'). If omitted, all entries of the reference are returned.")),
+			mcp.WithString("output_format", mcp.Description("Output format: 'yaml' (default) or 'json'.")),
+		),
+		Handler: g.handleGetReverseMappings,
+	}
+}
+
+func (g *GemaraAuthoringTools) newGetBaselineForCategoryTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"get_baseline_for_category",
+			mcp.WithDescription("Get the baseline for an applicability category: exactly the Layer 2 controls and assessment requirements whose applicability includes the category, such as OSPS Maturity Level 2 or a CCC TLP level. Requirements that do not apply to the category are left out of each control."),
+			mcp.WithString("category", mcp.Description("The applicability category ID or title (e.g. 'Maturity2', 'tlp-green')."), mcp.Required()),
+			mcp.WithString("catalog_id", mcp.Description("Optional catalog ID to restrict the baseline to. Defaults to all stored catalogs.")),
+			mcp.WithString("output_format", mcp.Description("Output format: 'markdown' (default), 'yaml', or 'json'.")),
+		),
+		Handler: g.handleGetBaselineForCategory,
+	}
+}
+
 // Layer 3 Tool Definitions
 
 func (g *GemaraAuthoringTools) newListLayer3PoliciesTool() server.ServerTool {
@@ -171,7 +292,7 @@ func (g *GemaraAuthoringTools) newGetLayer3PolicyTool() server.ServerTool {
 			"get_layer3_policy",
 			mcp.WithDescription("Get detailed information about a specific Layer 3 Policy document by its ID. Returns the full policy document in YAML or JSON format."),
 			mcp.WithString("policy_id", mcp.Description("The unique identifier of the Layer 3 Policy document to retrieve."), mcp.Required()),
-			mcp.WithString("output_format", mcp.Description("Output format: 'yaml' (default) or 'json'.")),
+			mcp.WithString("output_format", mcp.Description("Output format: 'yaml' (default), 'json', or 'original'. 'original' returns the stored file byte-for-byte, as YAML or JSON.")),
 		),
 		Handler: g.handleGetLayer3Policy,
 	}
@@ -193,18 +314,61 @@ func (g *GemaraAuthoringTools) newStoreLayer3YAMLTool() server.ServerTool {
 	return server.ServerTool{
 		Tool: mcp.NewTool(
 			"store_layer3_yaml",
-			mcp.WithDescription("Store a Layer 3 Policy document from raw YAML content. This preserves all YAML content without data loss. The YAML is validated with CUE before storing."),
-			mcp.WithString("yaml_content", mcp.Description("Raw YAML content containing the complete Layer-3 PolicyDocument structure. Must include metadata.id and will be validated against the Layer 3 CUE schema."), mcp.Required()),
+			mcp.WithDescription("Store a Layer 3 Policy document from raw YAML or JSON content. This preserves all YAML content without data loss. The content is validated with CUE before storing. New artifacts are stored as <id>.yaml or <id>.json in the format of the content; an artifact that is already stored keeps its file and format."),
+			mcp.WithString("yaml_content", mcp.Description("Raw YAML content containing the complete Layer-3 PolicyDocument structure. Must include metadata.id and will be validated against the Layer 3 CUE schema. Provide this or json_content.")),
+			mcp.WithString("json_content", mcp.Description("Raw JSON content, as a JSON object, containing the complete Layer-3 PolicyDocument structure. Must include metadata.id and will be validated against the Layer 3 CUE schema. Provide this or yaml_content.")),
 		),
 		Handler: g.handleStoreLayer3YAML,
 	}
 }
 
+func (g *GemaraAuthoringTools) newStoreLayer3CUETool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"store_layer3_cue",
+			mcp.WithDescription("Store a Layer 3 Policy authored in CUE. The source is evaluated against the Layer 3 #Policy schema, the exported YAML is stored like store_layer3_yaml, and, when the storage keeps CUE sources, the CUE source is stored next to it as <id>.cue. The artifact is the top-level value of the source, which can import the Gemara schemas as \"github.com/ossf/gemara/schemas\" and use its own definitions, templates, comprehensions, and defaults. Use validate_gemara_cue to preview the exported YAML."),
+			mcp.WithString("cue_content", mcp.Description("CUE source whose top-level value is the complete Layer-3 Policy, including metadata.id."), mcp.Required()),
+		),
+		Handler: g.handleStoreLayer3CUE,
+	}
+}
+
+func (g *GemaraAuthoringTools) newResolvePolicyControlsTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"resolve_policy_controls",
+			mcp.WithDescription("Resolve the effective, organization-specific control set of a Layer 3 Policy. Loads every catalog the policy imports, drops excluded controls and requirements, filters assessment requirements by the applicability categories named in the policy scope, attaches constraints, and applies assessment requirement modifications (add, modify, remove, replace, override). A modification that targets a control applies to all of its requirements, and a remove that targets a control drops the control. Every control and requirement carries provenance for each change."),
+			mcp.WithString("policy_id", mcp.Description("The unique identifier of the Layer 3 Policy to resolve."), mcp.Required()),
+			mcp.WithString("output_format", mcp.Description("Output format: 'markdown' (default) or 'json'.")),
+		),
+		Handler: g.handleResolvePolicyControls,
+	}
+}
+
+func (g *GemaraAuthoringTools) newGenerateBaselineTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"generate_baseline",
+			mcp.WithDescription("Generate a tailored organization baseline from a stored Layer 2 Catalog as Layer 3 Policy YAML. Selects controls by applicability category and include/exclude lists of families or controls, records everything left out as catalog import exclusions and requirement removals, and fills author, contacts, scope, and rationale with TODO placeholders. The generated YAML is validated with CUE before it is returned."),
+			mcp.WithString("catalog_id", mcp.Description("The ID of the stored Layer 2 Catalog to tailor."), mcp.Required()),
+			mcp.WithString("category", mcp.Description("Optional applicability category ID or title (e.g. 'Maturity2'). Requirements not applicable to it are removed and controls left without requirements are excluded.")),
+			mcp.WithArray("include_families", mcp.Description("Optional family IDs to include. When include_families or include_controls is set, only matching controls are kept.")),
+			mcp.WithArray("exclude_families", mcp.Description("Optional family IDs to exclude.")),
+			mcp.WithArray("include_controls", mcp.Description("Optional control IDs to include.")),
+			mcp.WithArray("exclude_controls", mcp.Description("Optional control IDs to exclude. Exclusions take precedence over inclusions.")),
+			mcp.WithString("policy_id", mcp.Description("Optional ID for the generated policy. Defaults to an ID derived from the catalog and category.")),
+			mcp.WithString("title", mcp.Description("Optional title for the generated policy.")),
+		),
+		Handler: g.handleGenerateBaseline,
+	}
+}
+
 func (g *GemaraAuthoringTools) newFindApplicableArtifactsTool() server.ServerTool {
 	return server.ServerTool{
 		Tool: mcp.NewTool(
 			"find_applicable_artifacts",
-			mcp.WithDescription("Find Layer 1 and Layer 2 artifacts applicable to a given policy scope. Filters artifacts by boundaries, technologies, and providers."),
+			mcp.WithDescription("Find Layer 1 and Layer 2 artifacts applicable to a given policy scope. Filters artifacts by boundaries, technologies, and providers, or by the scope of a stored Layer 3 policy when policy_id is given. In policy mode, out-of-scope dimensions and import exclusions are honored and every artifact is listed with the reason it was included or excluded."),
+			mcp.WithString("policy_id", mcp.Description("Optional ID of a stored Layer 3 Policy whose scope and imports to apply. When set, boundaries, technologies, and providers are ignored.")),
 			mcp.WithArray("boundaries", mcp.Description("Optional array of boundary/jurisdiction filters.")),
 			mcp.WithArray("technologies", mcp.Description("Optional array of technology domain filters.")),
 			mcp.WithArray("providers", mcp.Description("Optional array of provider/industry sector filters.")),
@@ -213,3 +377,355 @@ func (g *GemaraAuthoringTools) newFindApplicableArtifactsTool() server.ServerToo
 		Handler: g.handleFindApplicableArtifacts,
 	}
 }
+
+// Scaffolding Tool Definitions
+
+// scaffoldMetadataOptions returns the parameters shared by all scaffolding tools
+func scaffoldMetadataOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("title", mcp.Description("Title of the new artifact."), mcp.Required()),
+		mcp.WithString("id", mcp.Description("Optional artifact ID. Defaults to an ID derived from the title; a numeric suffix is added if the ID is already stored.")),
+		mcp.WithString("description", mcp.Description("Optional description. Defaults to a TODO placeholder.")),
+		mcp.WithString("version", mcp.Description("Optional version. Defaults to 0.1.0.")),
+		mcp.WithString("author", mcp.Description("Optional author name. Defaults to a TODO placeholder.")),
+		mcp.WithString("author_type", mcp.Description("Optional author type: Human (default), Software, or Software-Assisted.")),
+		mcp.WithArray("categories", mcp.Description("Optional applicability category titles, e.g. ['Maturity Level 1', 'Maturity Level 2'].")),
+		mcp.WithArray("mapping_references", mcp.Description("Optional IDs of referenced artifacts. Titles and versions are taken from stored artifacts when available.")),
+	}
+}
+
+func (g *GemaraAuthoringTools) newScaffoldLayer1GuidanceTool() server.ServerTool {
+	options := []mcp.ToolOption{
+		mcp.WithDescription("Generate a Layer 1 Guidance skeleton from structured inputs. Returns schema-valid YAML with generated, collision-free IDs, one family per requested family, and a placeholder guideline in each family. Fill in the TODO placeholders and store the result with store_layer1_yaml."),
+		mcp.WithString("document_type", mcp.Description("Document type: Standard, Regulation, Best Practice, or Framework (default)."), mcp.Enum("Standard", "Regulation", "Best Practice", "Framework")),
+		mcp.WithArray("families", mcp.Description("Optional family titles. Defaults to a single 'General' family.")),
+	}
+	return server.ServerTool{
+		Tool:    mcp.NewTool("scaffold_layer1_guidance", append(options, scaffoldMetadataOptions()...)...),
+		Handler: g.handleScaffoldLayer1Guidance,
+	}
+}
+
+func (g *GemaraAuthoringTools) newScaffoldLayer2CatalogTool() server.ServerTool {
+	options := []mcp.ToolOption{
+		mcp.WithDescription("Generate a Layer 2 Catalog skeleton from structured inputs. Returns schema-valid YAML with generated, collision-free IDs, one family per requested family, and a placeholder control with one assessment requirement in each family, applicable to all requested categories. Fill in the TODO placeholders and store the result with store_layer2_yaml."),
+		mcp.WithArray("families", mcp.Description("Optional family titles. Defaults to a single 'General' family.")),
+	}
+	return server.ServerTool{
+		Tool:    mcp.NewTool("scaffold_layer2_catalog", append(options, scaffoldMetadataOptions()...)...),
+		Handler: g.handleScaffoldLayer2Catalog,
+	}
+}
+
+func (g *GemaraAuthoringTools) newScaffoldLayer3PolicyTool() server.ServerTool {
+	options := []mcp.ToolOption{
+		mcp.WithDescription("Generate a Layer 3 Policy skeleton from structured inputs. Returns schema-valid YAML with a collision-free ID, contacts, scope, and imports of the given catalogs and guidance, which are also added as mapping references. Fill in the TODO placeholders and store the result with store_layer3_yaml."),
+		mcp.WithArray("catalogs", mcp.Description("Optional Layer 2 Catalog IDs to import.")),
+		mcp.WithArray("guidance", mcp.Description("Optional Layer 1 Guidance IDs to import.")),
+		mcp.WithArray("responsible", mcp.Description("Optional names of the people or groups responsible for implementing the controls.")),
+		mcp.WithArray("accountable", mcp.Description("Optional names of the people or groups accountable for the policy.")),
+		mcp.WithArray("technologies", mcp.Description("Optional in-scope technologies.")),
+		mcp.WithArray("boundaries", mcp.Description("Optional in-scope geopolitical boundaries.")),
+	}
+	return server.ServerTool{
+		Tool:    mcp.NewTool("scaffold_layer3_policy", append(options, scaffoldMetadataOptions()...)...),
+		Handler: g.handleScaffoldLayer3Policy,
+	}
+}
+
+// Editing Tool Definitions
+
+func (g *GemaraAuthoringTools) newPatchArtifactTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"patch_artifact",
+			mcp.WithDescription("Apply a JSON Patch (RFC 6902) to a stored Layer 1-3 artifact. Paths are JSON Pointers into the YAML document (e.g. /controls/0/title). The edited artifact is validated with CUE and stored only if valid; comments and key order of the stored YAML are preserved. Returns a semantic diff of the change."),
+			mcp.WithNumber("layer", mcp.Description("Layer of the artifact (1 = Guidance, 2 = Catalog, 3 = Policy)."), mcp.Required()),
+			mcp.WithString("artifact_id", mcp.Description("ID of the stored artifact to patch."), mcp.Required()),
+			mcp.WithString("patch", mcp.Description("JSON Patch document: an array of operations such as [{\"op\": \"replace\", \"path\": \"/controls/0/title\", \"value\": \"New title\"}]. Supported ops: add, remove, replace, move, copy, test."), mcp.Required()),
+		),
+		Handler: g.handlePatchArtifact,
+	}
+}
+
+func (g *GemaraAuthoringTools) newAddControlTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"add_control",
+			mcp.WithDescription("Add a control to a stored Layer 2 Catalog. The control is placed after the last control of the same family, validated with CUE, and stored without reformatting the rest of the catalog. Returns a semantic diff of the change."),
+			mcp.WithString("catalog_id", mcp.Description("ID of the stored Layer 2 Catalog."), mcp.Required()),
+			mcp.WithString("control", mcp.Description("The control as YAML or JSON, including id, title, objective, family, and assessment-requirements."), mcp.Required()),
+		),
+		Handler: g.handleAddControl,
+	}
+}
+
+func (g *GemaraAuthoringTools) newUpdateControlTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"update_control",
+			mcp.WithDescription("Update fields of a control in a stored Layer 2 Catalog. Only the given fields are rewritten; a null value removes an optional field. The result is validated with CUE before it is stored. Returns a semantic diff of the change."),
+			mcp.WithString("catalog_id", mcp.Description("ID of the stored Layer 2 Catalog."), mcp.Required()),
+			mcp.WithString("control_id", mcp.Description("ID of the control to update."), mcp.Required()),
+			mcp.WithString("fields", mcp.Description("YAML or JSON mapping of control fields to set, e.g. {\"title\": \"New title\", \"threat-mappings\": null}."), mcp.Required()),
+		),
+		Handler: g.handleUpdateControl,
+	}
+}
+
+func (g *GemaraAuthoringTools) newRemoveControlTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"remove_control",
+			mcp.WithDescription("Remove a control from a stored Layer 2 Catalog. The result is validated with CUE before it is stored, and stored policies that modify the control's assessment requirements are reported."),
+			mcp.WithString("catalog_id", mcp.Description("ID of the stored Layer 2 Catalog."), mcp.Required()),
+			mcp.WithString("control_id", mcp.Description("ID of the control to remove."), mcp.Required()),
+		),
+		Handler: g.handleRemoveControl,
+	}
+}
+
+func (g *GemaraAuthoringTools) newAddGuidelineMappingTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"add_guideline_mapping",
+			mcp.WithDescription("Map a control in a stored Layer 2 Catalog to a guideline. The entry is added to the control's existing guideline mapping for the reference, or a new mapping is created. The result is validated with CUE before it is stored."),
+			mcp.WithString("catalog_id", mcp.Description("ID of the stored Layer 2 Catalog."), mcp.Required()),
+			mcp.WithString("control_id", mcp.Description("ID of the control to map."), mcp.Required()),
+			mcp.WithString("reference_id", mcp.Description("ID of the referenced guidance document, as declared in metadata.mapping-references."), mcp.Required()),
+			mcp.WithString("entry_id", mcp.Description("ID of the guideline within the referenced document."), mcp.Required()),
+			mcp.WithNumber("strength", mcp.Description("Optional mapping strength from 1 to 10.")),
+			mcp.WithString("remarks", mcp.Description("Optional remarks about the mapping.")),
+		),
+		Handler: g.handleAddGuidelineMapping,
+	}
+}
+
+func (g *GemaraAuthoringTools) newAddPolicyModificationTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"add_policy_modification",
+			mcp.WithDescription("Add an assessment requirement modification to a catalog import of a stored Layer 3 Policy. The result is validated with CUE before it is stored. Returns a semantic diff of the change."),
+			mcp.WithString("policy_id", mcp.Description("ID of the stored Layer 3 Policy."), mcp.Required()),
+			mcp.WithString("catalog_id", mcp.Description("Reference ID of the imported catalog the modification applies to."), mcp.Required()),
+			mcp.WithString("target_id", mcp.Description("ID of the control or assessment requirement being modified."), mcp.Required()),
+			mcp.WithString("modification_type", mcp.Description("Type of modification."), mcp.Required(), mcp.Enum("add", "modify", "remove", "replace", "override")),
+			mcp.WithString("rationale", mcp.Description("Why the modification is made."), mcp.Required()),
+			mcp.WithString("modification_id", mcp.Description("Optional ID for the modification. Generated from the policy, type, and target if omitted.")),
+			mcp.WithString("text", mcp.Description("Optional replacement or additional requirement text.")),
+			mcp.WithArray("applicability", mcp.Description("Optional applicability categories of the modified requirement.")),
+			mcp.WithString("recommendation", mcp.Description("Optional recommendation for the modified requirement.")),
+		),
+		Handler: g.handleAddPolicyModification,
+	}
+}
+
+// Migration Tool Definitions
+
+func (g *GemaraAuthoringTools) newMigrateArtifactTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"migrate_artifact",
+			mcp.WithDescription("Upgrade an artifact written against an older Gemara schema version, for example a catalog that nests controls under control-families instead of listing them with a family field. Applies versioned transformation steps, re-validates the result with CUE, and reports every transformation. Migrates one stored artifact, YAML content, or every stored artifact (all=true). Stored artifacts are only previewed unless store=true, and are written back to their own file only when the result passes validation. Known versions: legacy (layouts before v0.17) and v0.17 (current)."),
+			mcp.WithNumber("layer", mcp.Description("The layer of the artifact: 1 (Guidance), 2 (Catalog), or 3 (Policy). With all=true, omit it to migrate every layer.")),
+			mcp.WithString("artifact_id", mcp.Description("ID of the stored artifact to migrate. Provide this, yaml_content, or all=true.")),
+			mcp.WithString("yaml_content", mcp.Description("YAML content to migrate. The migrated YAML is returned, not stored.")),
+			mcp.WithBoolean("all", mcp.Description("Migrate every stored artifact of the layer, or of all layers if layer is omitted.")),
+			mcp.WithString("from_version", mcp.Description("Schema version the artifact was written against. Detected from the layout if omitted.")),
+			mcp.WithString("to_version", mcp.Description("Schema version to migrate to. Defaults to the current version, v0.17.")),
+			mcp.WithBoolean("store", mcp.Description("Write migrated stored artifacts back to storage when they pass validation. Defaults to false (preview).")),
+			mcp.WithString("output_format", mcp.Description("Output format: 'markdown' (default) or 'json'.")),
+		),
+		Handler: g.handleMigrateArtifact,
+	}
+}
+
+// Import and Export Tool Definitions
+
+func (g *GemaraAuthoringTools) newImportOSCALTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"import_oscal",
+			mcp.WithDescription("Import an OSCAL catalog or profile (JSON or YAML) as a Gemara artifact. A catalog becomes a Layer 2 Catalog (groups as families, controls and enhancements as controls, statement items as assessment requirements) or a Layer 1 Guidance document (controls as guidelines, statement items as guideline parts, guidance as recommendations). A profile becomes a Layer 3 Policy importing the stored catalog it selects from, with unselected controls as exclusions and parameter settings as constraints. IDs are kept and parameters are written into the prose. The result is validated with CUE and stored unless store=false; conversion notes list everything that could not be carried over."),
+			mcp.WithString("oscal_content", mcp.Description("The OSCAL document, as JSON or YAML, with a top-level catalog or profile."), mcp.Required()),
+			mcp.WithNumber("layer", mcp.Description("Target layer for catalogs: 1 (Guidance) or 2 (Catalog, default). Profiles always import as Layer 3.")),
+			mcp.WithString("artifact_id", mcp.Description("ID for the imported artifact. Derived from the OSCAL title if omitted.")),
+			mcp.WithString("document_type", mcp.Description("Layer 1 document type: Standard, Regulation, Best Practice, or Framework (default).")),
+			mcp.WithString("catalog_id", mcp.Description("For profiles: ID of the stored Layer 2 catalog or Layer 1 guidance the profile imports, when it cannot be resolved from the import href.")),
+			mcp.WithBoolean("store", mcp.Description("Store the artifact after validation. Defaults to true; set false to preview the converted YAML.")),
+			mcp.WithBoolean("overwrite", mcp.Description("Replace a stored artifact with the same ID. Defaults to false.")),
+			mcp.WithString("output_format", mcp.Description("Output format: 'markdown' (default) or 'json'.")),
+		),
+		Handler: g.handleImportOSCAL,
+	}
+}
+
+func (g *GemaraAuthoringTools) newExportOSCALTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"export_oscal",
+			mcp.WithDescription("Export a Gemara artifact as an OSCAL 1.1 document. A Layer 2 Catalog becomes an OSCAL catalog (families as groups, assessment requirements as child controls that import_oscal reads back), a Layer 3 Policy an OSCAL profile or, with oscal_model=component-definition, a component definition of its effective controls, and a Layer 4 evaluation log OSCAL assessment-results with a finding per control evaluation and an observation per assessment log. Returns the document, or writes it to output_path and returns a summary. The summary lists conversion notes and a field mapping table showing which Gemara fields are exported exactly, lossily, or not at all."),
+			mcp.WithNumber("layer", mcp.Description("Layer of the artifact: 2 (Catalog), 3 (Policy), or 4 (Evaluation log)."), mcp.Required()),
+			mcp.WithString("artifact_id", mcp.Description("ID of the stored artifact to export.")),
+			mcp.WithString("artifact_content", mcp.Description("The artifact as YAML or JSON, exported instead of a stored artifact.")),
+			mcp.WithString("oscal_model", mcp.Description("OSCAL model for Layer 3 policies: 'profile' (default) or 'component-definition'.")),
+			mcp.WithString("format", mcp.Description("Serialization of the OSCAL document: 'json' (default) or 'yaml'.")),
+			mcp.WithString("output_path", mcp.Description("File to write the OSCAL document to. Parent directories are created.")),
+			mcp.WithString("output_format", mcp.Description("Response: 'document' (the OSCAL document, default without output_path), 'markdown' (summary with field mapping, default with output_path), or 'json'.")),
+		),
+		Handler: g.handleExportOSCAL,
+	}
+}
+
+func (g *GemaraAuthoringTools) newExportControlsCSVTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"export_controls_csv",
+			mcp.WithDescription("Export the controls of a stored Layer 2 Catalog as CSV for editing in a spreadsheet. Columns: family_id, family_title, family_description, control_id, control_title, objective, requirement_id, requirement_text, applicability, recommendation. Each row is one assessment requirement; family and control columns repeat on every row of a control, and applicability values are separated by semicolons. The same layout is read by import_controls_csv."),
+			mcp.WithString("catalog_id", mcp.Description("The unique identifier of the Layer 2 Catalog to export."), mcp.Required()),
+		),
+		Handler: g.handleExportControlsCSV,
+	}
+}
+
+func (g *GemaraAuthoringTools) newExportMappingsCSVTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"export_mappings_csv",
+			mcp.WithDescription("Export the guideline and threat mappings of Layer 2 Catalogs as CSV with one row per mapping entry. Columns: catalog_id, control_id, control_title, mapping_type (guideline or threat), reference_id, entry_id, strength, remarks."),
+			mcp.WithString("catalog_id", mcp.Description("Optional catalog to export. Defaults to all stored catalogs.")),
+			mcp.WithString("reference_id", mcp.Description("Optional mapping reference ID, e.g. a Layer 1 guidance ID, to export only mappings to it.")),
+		),
+		Handler: g.handleExportMappingsCSV,
+	}
+}
+
+func (g *GemaraAuthoringTools) newImportControlsCSVTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"import_controls_csv",
+			mcp.WithDescription("Build a new Layer 2 Catalog, or update a stored one, from CSV in the export_controls_csv layout. Only control_id is required as a column; column order does not matter and a header row is required. Each row adds an assessment requirement to its control (rows without requirement columns only describe the control), and families are created from family_id or family_title. When catalog_id names a stored catalog, listed controls are added or updated (their requirements are replaced by the CSV's when it lists any), guideline and threat mappings are kept, and the stored file keeps its comments. Nothing is stored unless every row is valid and the catalog passes CUE validation; when store is true and the catalog was not stored, the report is returned as an error."),
+			mcp.WithString("csv_content", mcp.Description("The CSV content, including the header row."), mcp.Required()),
+			mcp.WithString("catalog_id", mcp.Description("ID of the stored catalog to update, or of the new catalog. Defaults to an ID derived from title.")),
+			mcp.WithString("title", mcp.Description("Title of the catalog. Required when creating a catalog; replaces the title of an updated one.")),
+			mcp.WithString("description", mcp.Description("Description of a new catalog.")),
+			mcp.WithString("version", mcp.Description("Version of a new catalog. Defaults to 0.1.0.")),
+			mcp.WithString("author", mcp.Description("Author name of a new catalog. Defaults to a TODO placeholder.")),
+			mcp.WithString("author_type", mcp.Description("Author type of a new catalog: Human (default), Software, or Software-Assisted.")),
+			mcp.WithBoolean("remove_missing", mcp.Description("Remove stored controls that are not in the CSV. Defaults to false.")),
+			mcp.WithBoolean("store", mcp.Description("Store the catalog after validation. Defaults to true; set false to preview the YAML.")),
+			mcp.WithString("output_format", mcp.Description("Output format: 'markdown' (default) or 'json'.")),
+		),
+		Handler: g.handleImportControlsCSV,
+	}
+}
+
+func (g *GemaraAuthoringTools) newRenderArtifactTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"render_artifact",
+			mcp.WithDescription("Render a stored Layer 1 Guidance document, Layer 2 Catalog, or Layer 3 Policy as a documentation page. Pages are cross-linked: guidelines list the controls mapped to them, controls show their mapping tables and the policies importing them, and policies link their imported catalogs, exclusions, and constrained controls. Returns the page, or with output_dir writes the whole site (every stored artifact, an index page, a family index, and search.json) and returns where it was written. The render-site command writes the same site from the command line."),
+			mcp.WithNumber("layer", mcp.Description("Layer of the artifact: 1 (Guidance), 2 (Catalog), or 3 (Policy)."), mcp.Required()),
+			mcp.WithString("artifact_id", mcp.Description("ID of the stored artifact to render."), mcp.Required()),
+			mcp.WithString("format", mcp.Description("Page format: 'markdown' (default without output_dir) or 'html' (default with output_dir).")),
+			mcp.WithString("output_dir", mcp.Description("Directory to write the whole site to. Existing files with the same names are replaced.")),
+			mcp.WithString("template_dir", mcp.Description("Directory of templates overriding the embedded ones by file name, e.g. catalog.html.tmpl or layout.md.tmpl.")),
+			mcp.WithString("site_title", mcp.Description("Title shown in the header of every page. Defaults to 'Gemara Artifacts'.")),
+		),
+		Handler: g.handleRenderArtifact,
+	}
+}
+
+// Analysis Tool Definitions
+
+func (g *GemaraAuthoringTools) newCoverageReportTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"coverage_report",
+			mcp.WithDescription("Report how a Layer 1 Guidance document is covered by Layer 2 controls. For each guideline and guideline part, lists the controls mapping to it via guideline-mappings with maximum and aggregate mapping strength, and lists guidelines with no coverage at all."),
+			mcp.WithString("guidance_id", mcp.Description("The unique identifier of the Layer 1 Guidance document to report on."), mcp.Required()),
+			mcp.WithString("output_format", mcp.Description("Output format: 'markdown' (default), 'json', or 'csv'.")),
+		),
+		Handler: g.handleCoverageReport,
+	}
+}
+
+func (g *GemaraAuthoringTools) newGetTraceabilityGraphTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"get_traceability_graph",
+			mcp.WithDescription("Return the traceability subgraph around an artifact or element across Layers 1-3. Nodes are guidance documents, guidelines, catalogs, controls, policies, and external mapping references; edges come from guideline-mappings and policy imports. Use it to render lineage from a regulation down to the policies that enforce it."),
+			mcp.WithString("node_id", mcp.Description("The node to start from: an artifact ID, a guideline or control ID, a reference entry ID (e.g. 'CA-7'), or a full node key such as 'control:CATALOG/CONTROL-ID'."), mcp.Required()),
+			mcp.WithNumber("depth", mcp.Description("Maximum number of hops to traverse from the starting node (default 2).")),
+			mcp.WithString("direction", mcp.Description("Traversal direction: 'upstream' (towards guidance and regulations), 'downstream' (towards controls and policies), or 'both' (default).")),
+			mcp.WithString("output_format", mcp.Description("Output format: 'json' (default), 'mermaid', or 'dot' (Graphviz).")),
+		),
+		Handler: g.handleGetTraceabilityGraph,
+	}
+}
+
+func (g *GemaraAuthoringTools) newAnalyzeImpactTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"analyze_impact",
+			mcp.WithDescription("Analyze the impact of updating a stored Layer 1 Guidance document or Layer 2 Catalog before storing it. Compares the incoming YAML with the stored version of the same ID, lists modified, removed, and added guidelines, controls, and assessment requirements, and reports the dependent controls, guidelines, policy constraints, and policy modifications (by target-id) affected by the change."),
+			mcp.WithNumber("layer", mcp.Description("The layer of the incoming artifact: 1 (Guidance) or 2 (Catalog)."), mcp.Required()),
+			mcp.WithString("yaml_content", mcp.Description("The proposed new version of the artifact as YAML."), mcp.Required()),
+			mcp.WithString("output_format", mcp.Description("Output format: 'markdown' (default) or 'json'.")),
+		),
+		Handler: g.handleAnalyzeImpact,
+	}
+}
+
+func (g *GemaraAuthoringTools) newDiffArtifactsTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"diff_artifacts",
+			mcp.WithDescription("Compare two versions of a Gemara artifact by element identity instead of text. Each side is either a stored artifact ID or YAML content, so two stored artifacts, two revisions, or a stored artifact and a proposed update can be compared. Reports document-level field changes and added, removed, and modified guidelines, guideline parts, controls, assessment requirements, policy imports, constraints, and modifications with field-level changes."),
+			mcp.WithNumber("layer", mcp.Description("The layer of both artifacts: 1 (Guidance), 2 (Catalog), or 3 (Policy)."), mcp.Required()),
+			mcp.WithString("left_id", mcp.Description("ID of the stored artifact to use as the old version. Provide this or left_yaml.")),
+			mcp.WithString("left_yaml", mcp.Description("YAML content of the old version. Provide this or left_id.")),
+			mcp.WithString("right_id", mcp.Description("ID of the stored artifact to use as the new version. Provide this or right_yaml.")),
+			mcp.WithString("right_yaml", mcp.Description("YAML content of the new version. Provide this or right_id.")),
+			mcp.WithString("output_format", mcp.Description("Output format: 'markdown' (default) or 'json'.")),
+		),
+		Handler: g.handleDiffArtifacts,
+	}
+}
+
+func (g *GemaraAuthoringTools) newCheckArtifactFidelityTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"check_artifact_fidelity",
+			mcp.WithDescription("Detect data loss between an artifact's raw YAML and the gemara Go types that search, get, and analysis tools read it through. Compares the YAML tree with the artifact decoded and re-marshalled, and reports fields that are dropped (for example nested control-families[].controls in older catalogs) or whose values change, grouped by path pattern. Checks one stored artifact, proposed YAML content, or every stored artifact of the layer."),
+			mcp.WithNumber("layer", mcp.Description("The layer of the artifact: 1 (Guidance), 2 (Catalog), or 3 (Policy)."), mcp.Required()),
+			mcp.WithString("artifact_id", mcp.Description("ID of the stored artifact to check. Omit this and yaml_content to check every stored artifact of the layer.")),
+			mcp.WithString("yaml_content", mcp.Description("YAML content to check instead of a stored artifact.")),
+			mcp.WithString("output_format", mcp.Description("Output format: 'markdown' (default) or 'json'.")),
+		),
+		Handler: g.handleCheckArtifactFidelity,
+	}
+}
+
+func (g *GemaraAuthoringTools) newValidateStoredArtifactsTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"validate_stored_artifacts",
+			mcp.WithDescription("Validate every stored artifact at once. Each indexed file is checked as written on disk against the CUE schema of its layer, for references that do not resolve (undeclared families and mapping references, see-also links, mapping entries missing from stored guidance, and policy imports of missing catalogs, guidance, controls, or requirements), and for fields the gemara types drop. Results are aggregated per layer, and files in the layer directories that could not be indexed at all are listed with the reason. Layer 4 evaluation logs are not indexed by storage and are not checked; validate them one at a time with validate_gemara_yaml."),
+			mcp.WithNumber("layer", mcp.Description("Only validate this layer: 1 (Guidance), 2 (Catalog), or 3 (Policy). Omit to validate layers 1 to 3.")),
+			mcp.WithString("output_format", mcp.Description("Output format: 'markdown' (default) or 'json'.")),
+		),
+		Handler: g.handleValidateStoredArtifacts,
+	}
+}
+
+func (g *GemaraAuthoringTools) newGetStorageHealthTool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"get_storage_health",
+			mcp.WithDescription("Explain why a stored artifact is missing. Rescans the artifacts directory and reports, per layer, how many files are indexed and which files were quarantined because they could not be indexed, with the parse error: invalid YAML, a missing metadata.id, or an ID that another file already uses. Quarantined files are invisible to list, get, and search tools until fixed."),
+			mcp.WithString("output_format", mcp.Description("Output format: 'markdown' (default) or 'json'.")),
+		),
+		Handler: g.handleGetStorageHealth,
+	}
+}
@@ -0,0 +1,349 @@
+package authoring
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+)
+
+// idAllocator hands out IDs that are unique, ignoring case, within one artifact or layer
+type idAllocator map[string]bool
+
+// reserve marks an existing ID as taken
+func (a idAllocator) reserve(id string) {
+	a[strings.ToLower(id)] = true
+}
+
+// next returns base, or base with the first free numeric suffix if base is already taken
+func (a idAllocator) next(base string) string {
+	id := base
+	for i := 2; a[strings.ToLower(id)]; i++ {
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+	a.reserve(id)
+	return id
+}
+
+// scaffoldIDs records the IDs generated for a scaffolded artifact
+type scaffoldIDs struct {
+	ArtifactID string
+	Families   []string
+	Categories []string
+	Elements   []string
+}
+
+// handleScaffoldLayer1Guidance returns a schema-valid Layer 1 Guidance skeleton built from structured inputs
+func (g *GemaraAuthoringTools) handleScaffoldLayer1Guidance(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	documentType := request.GetString("document_type", "Framework")
+	switch documentType {
+	case "Standard", "Regulation", "Best Practice", "Framework":
+	default:
+		return mcp.NewToolResultErrorf("invalid document_type '%s': must be Standard, Regulation, Best Practice, or Framework", documentType), nil
+	}
+
+	metadata, ids, err := g.scaffoldMetadata(consts.Layer1, request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	allocator := idAllocator{}
+	allocator.reserve(metadata.Id)
+
+	guidance := &gemara.GuidanceDocument{
+		Title:        request.GetString("title", ""),
+		Metadata:     metadata,
+		DocumentType: gemara.DocumentType(documentType),
+	}
+	for _, family := range g.scaffoldFamilies(request, allocator, ids) {
+		guidance.Families = append(guidance.Families, family)
+		guidelineID := allocator.next(fmt.Sprintf("%s-%s-01", metadata.Id, family.Id))
+		ids.Elements = append(ids.Elements, guidelineID)
+		guidance.Guidelines = append(guidance.Guidelines, gemara.Guideline{
+			Id:        guidelineID,
+			Title:     fmt.Sprintf("TODO: first %s guideline", family.Title),
+			Objective: "TODO: describe the outcome this guideline achieves.",
+			Family:    family.Id,
+			Statements: []gemara.Statement{{
+				Id:   allocator.next(guidelineID + ".01"),
+				Text: "TODO: state the first part of the guideline.",
+			}},
+		})
+	}
+
+	return g.scaffoldResult(consts.Layer1, "Guidance", guidance, ids)
+}
+
+// handleScaffoldLayer2Catalog returns a schema-valid Layer 2 Catalog skeleton built from structured inputs
+func (g *GemaraAuthoringTools) handleScaffoldLayer2Catalog(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	metadata, ids, err := g.scaffoldMetadata(consts.Layer2, request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	allocator := idAllocator{}
+	allocator.reserve(metadata.Id)
+
+	var applicability []string
+	for _, category := range metadata.ApplicabilityCategories {
+		applicability = append(applicability, category.Id)
+	}
+	if applicability == nil {
+		applicability = []string{}
+	}
+
+	catalog := &gemara.Catalog{
+		Title:    request.GetString("title", ""),
+		Metadata: metadata,
+	}
+	for _, family := range g.scaffoldFamilies(request, allocator, ids) {
+		catalog.Families = append(catalog.Families, family)
+		controlID := allocator.next(fmt.Sprintf("%s-%s-01", metadata.Id, family.Id))
+		ids.Elements = append(ids.Elements, controlID)
+		catalog.Controls = append(catalog.Controls, gemara.Control{
+			Id:        controlID,
+			Title:     fmt.Sprintf("TODO: first %s control", family.Title),
+			Objective: "TODO: describe the objective of this control.",
+			Family:    family.Id,
+			AssessmentRequirements: []gemara.AssessmentRequirement{{
+				Id:            allocator.next(controlID + ".01"),
+				Text:          "TODO: state a verifiable requirement.",
+				Applicability: applicability,
+			}},
+		})
+	}
+
+	return g.scaffoldResult(consts.Layer2, "Catalog", catalog, ids)
+}
+
+// handleScaffoldLayer3Policy returns a schema-valid Layer 3 Policy skeleton built from structured inputs
+func (g *GemaraAuthoringTools) handleScaffoldLayer3Policy(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	metadata, ids, err := g.scaffoldMetadata(consts.Layer3, request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	policy := &gemara.Policy{
+		Title:    request.GetString("title", ""),
+		Metadata: metadata,
+		Contacts: gemara.Contacts{
+			Responsible: scaffoldContacts(g.extractStringArray(request, "responsible"), "TODO: team responsible for implementing the controls"),
+			Accountable: scaffoldContacts(g.extractStringArray(request, "accountable"), "TODO: owner accountable for the policy"),
+		},
+		Scope: gemara.Scope{
+			In: gemara.Dimensions{
+				Technologies: g.extractStringArray(request, "technologies"),
+				Geopolitical: g.extractStringArray(request, "boundaries"),
+			},
+		},
+	}
+	if len(policy.Scope.In.Technologies) == 0 && len(policy.Scope.In.Geopolitical) == 0 {
+		policy.Scope.In.Technologies = []string{"TODO: in-scope technologies"}
+	}
+	for _, catalogID := range g.extractStringArray(request, "catalogs") {
+		policy.Imports.Catalogs = append(policy.Imports.Catalogs, gemara.CatalogImport{ReferenceId: catalogID})
+	}
+	for _, guidanceID := range g.extractStringArray(request, "guidance") {
+		policy.Imports.Guidance = append(policy.Imports.Guidance, gemara.GuidanceImport{ReferenceId: guidanceID})
+	}
+
+	return g.scaffoldResult(consts.Layer3, "Policy", policy, ids)
+}
+
+// scaffoldMetadata builds the metadata shared by all scaffolds, generating an artifact ID that
+// does not collide with stored artifacts of the same layer
+func (g *GemaraAuthoringTools) scaffoldMetadata(layer int, request mcp.CallToolRequest) (gemara.Metadata, *scaffoldIDs, error) {
+	title := request.GetString("title", "")
+	if title == "" {
+		return gemara.Metadata{}, nil, fmt.Errorf("title is required")
+	}
+
+	authorType, err := parseActorType(request.GetString("author_type", "Human"))
+	if err != nil {
+		return gemara.Metadata{}, nil, err
+	}
+	authorName := request.GetString("author", "")
+	authorID := g.sanitizeID(authorName)
+	if authorName == "" {
+		authorName = "TODO: author"
+		authorID = "todo-author"
+	}
+
+	baseID := strings.TrimSpace(request.GetString("id", ""))
+	if baseID == "" {
+		baseID = g.sanitizeID(title)
+	}
+	if baseID == "" {
+		return gemara.Metadata{}, nil, fmt.Errorf("could not derive an ID from title '%s'; provide id", title)
+	}
+	stored := idAllocator{}
+	for _, entry := range g.getLayerEntries(layer) {
+		stored.reserve(entry.ID)
+	}
+	ids := &scaffoldIDs{ArtifactID: stored.next(baseID)}
+
+	description := request.GetString("description", "")
+	if description == "" {
+		description = fmt.Sprintf("TODO: describe %s.", title)
+	}
+
+	metadata := gemara.Metadata{
+		Id:          ids.ArtifactID,
+		Version:     request.GetString("version", "0.1.0"),
+		Description: description,
+		Author: gemara.Actor{
+			Id:   authorID,
+			Name: authorName,
+			Type: authorType,
+		},
+	}
+
+	references := g.extractStringArray(request, "mapping_references")
+	if layer == consts.Layer3 {
+		references = append(references, g.extractStringArray(request, "catalogs")...)
+		references = append(references, g.extractStringArray(request, "guidance")...)
+	}
+	for _, referenceID := range uniqueStrings(references) {
+		metadata.MappingReferences = append(metadata.MappingReferences, g.scaffoldMappingReference(referenceID))
+	}
+
+	categories := idAllocator{}
+	for _, categoryTitle := range uniqueStrings(g.extractStringArray(request, "categories")) {
+		categoryID := categories.next(g.sanitizeID(categoryTitle))
+		ids.Categories = append(ids.Categories, categoryID)
+		metadata.ApplicabilityCategories = append(metadata.ApplicabilityCategories, gemara.Category{
+			Id:          categoryID,
+			Title:       categoryTitle,
+			Description: fmt.Sprintf("TODO: describe when %s applies.", categoryTitle),
+		})
+	}
+
+	return metadata, ids, nil
+}
+
+// scaffoldFamilies builds a family for each requested family title, or a single placeholder family
+func (g *GemaraAuthoringTools) scaffoldFamilies(request mcp.CallToolRequest, allocator idAllocator, ids *scaffoldIDs) []gemara.Family {
+	titles := uniqueStrings(g.extractStringArray(request, "families"))
+	if len(titles) == 0 {
+		titles = []string{"General"}
+	}
+
+	var families []gemara.Family
+	for _, title := range titles {
+		familyID := allocator.next(g.sanitizeID(title))
+		ids.Families = append(ids.Families, familyID)
+		families = append(families, gemara.Family{
+			Id:          familyID,
+			Title:       title,
+			Description: fmt.Sprintf("TODO: describe the %s family.", title),
+		})
+	}
+	return families
+}
+
+// scaffoldMappingReference describes a referenced artifact, taking its title and version from storage when it is stored
+func (g *GemaraAuthoringTools) scaffoldMappingReference(referenceID string) gemara.MappingReference {
+	reference := gemara.MappingReference{
+		Id:      referenceID,
+		Title:   fmt.Sprintf("TODO: title of %s", referenceID),
+		Version: "TODO: version",
+	}
+	if guidance := g.loadLayer1Guidance(referenceID); guidance != nil {
+		reference.Title = guidance.Title
+		if guidance.Metadata.Version != "" {
+			reference.Version = guidance.Metadata.Version
+		}
+	} else if catalog := g.loadLayer2Catalog(referenceID); catalog != nil {
+		reference.Title = catalog.Title
+		if catalog.Metadata.Version != "" {
+			reference.Version = catalog.Metadata.Version
+		}
+	} else if policy := g.loadLayer3Policy(referenceID); policy != nil {
+		reference.Title = policy.Title
+		if policy.Metadata.Version != "" {
+			reference.Version = policy.Metadata.Version
+		}
+	}
+	return reference
+}
+
+// scaffoldResult validates a scaffolded artifact and renders it with the generated IDs
+func (g *GemaraAuthoringTools) scaffoldResult(layer int, kind string, artifact interface{}, ids *scaffoldIDs) (*mcp.CallToolResult, error) {
+	yamlContent, err := marshalArtifactYAML(artifact)
+	if err != nil {
+		return mcp.NewToolResultErrorf("failed to marshal %s YAML: %v", kind, err), nil
+	}
+	if failure := g.cueValidationFailure(yamlContent, layer); failure != "" {
+		return mcp.NewToolResultErrorf("Scaffolded %s failed CUE validation:\n%s\n\n```yaml\n%s```", kind, failure, yamlContent), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("# Layer %d %s Scaffold\n\n", layer, kind))
+	result.WriteString(fmt.Sprintf("- **ID**: `%s`\n", ids.ArtifactID))
+	if len(ids.Families) > 0 {
+		result.WriteString(fmt.Sprintf("- **Families**: %s\n", strings.Join(ids.Families, ", ")))
+	}
+	if len(ids.Categories) > 0 {
+		result.WriteString(fmt.Sprintf("- **Applicability Categories**: %s\n", strings.Join(ids.Categories, ", ")))
+	}
+	if len(ids.Elements) > 0 {
+		result.WriteString(fmt.Sprintf("- **Placeholder Entries**: %s\n", strings.Join(ids.Elements, ", ")))
+	}
+	result.WriteString("- **CUE Validation**: ✅ PASSED\n\n")
+	result.WriteString(fmt.Sprintf("Replace the TODO placeholders, add content, and store the result with `store_layer%d_yaml`.\n\n", layer))
+	result.WriteString(fmt.Sprintf("```yaml\n%s```\n", yamlContent))
+
+	return mcp.NewToolResultText(result.String()), nil
+}
+
+// cueValidationFailure validates YAML against the layer schema and returns the failure details, or "" when valid
+func (g *GemaraAuthoringTools) cueValidationFailure(yamlContent string, layer int) string {
+	validationResult := g.infoTools.PerformCUEValidation(yamlContent, layer)
+	if validationResult.Valid {
+		return ""
+	}
+	failure := validationResult.Error
+	if len(validationResult.Errors) > 0 {
+		failure += "\n" + strings.Join(validationResult.Errors, "\n")
+	}
+	return failure
+}
+
+// uniqueStrings returns values without duplicates, keeping the first occurrence of each
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+// scaffoldContacts returns a contact for each name, or a single placeholder contact
+func scaffoldContacts(names []string, placeholder string) []gemara.Contact {
+	if len(names) == 0 {
+		names = []string{placeholder}
+	}
+	var contacts []gemara.Contact
+	for _, name := range names {
+		contacts = append(contacts, gemara.Contact{Name: name})
+	}
+	return contacts
+}
+
+// parseActorType converts a schema actor type name into a gemara.ActorType
+func parseActorType(value string) (gemara.ActorType, error) {
+	switch value {
+	case "Human":
+		return gemara.Human, nil
+	case "Software":
+		return gemara.Software, nil
+	case "Software-Assisted":
+		return gemara.SoftwareAssisted, nil
+	}
+	return gemara.Human, fmt.Errorf("invalid author_type '%s': must be Human, Software, or Software-Assisted", value)
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDAllocatorNext(t *testing.T) {
+	tests := []struct {
+		name     string
+		reserved []string
+		bases    []string
+		want     []string
+	}{
+		{name: "free base", bases: []string{"CTL"}, want: []string{"CTL"}},
+		{name: "repeated base", bases: []string{"CTL", "CTL", "CTL"}, want: []string{"CTL", "CTL-2", "CTL-3"}},
+		{name: "reserved base", reserved: []string{"CTL"}, bases: []string{"CTL"}, want: []string{"CTL-2"}},
+		{name: "reserved in another case", reserved: []string{"ctl"}, bases: []string{"CTL"}, want: []string{"CTL-2"}},
+		{name: "generated IDs collide ignoring case", bases: []string{"ctl", "CTL"}, want: []string{"ctl", "CTL-2"}},
+		{name: "skips reserved suffixes", reserved: []string{"CTL", "CTL-2", "ctl-3"}, bases: []string{"CTL"}, want: []string{"CTL-4"}},
+		{name: "suffixed base collides with a generated ID", bases: []string{"CTL", "CTL", "CTL-2"}, want: []string{"CTL", "CTL-2", "CTL-2-2"}},
+		{name: "distinct bases", reserved: []string{"CTL"}, bases: []string{"GDL", "CTL"}, want: []string{"GDL", "CTL-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocator := idAllocator{}
+			for _, id := range tt.reserved {
+				allocator.reserve(id)
+			}
+			var got []string
+			for _, base := range tt.bases {
+				got = append(got, allocator.next(base))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package authoring
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/complytime/gemara-mcp-server/internal/consts"
@@ -20,6 +21,11 @@ func (g *GemaraAuthoringTools) handleFindApplicableArtifacts(ctx context.Context
 	providers := g.extractStringArray(request, "providers")
 	outputFormat := request.GetString("output_format", "yaml")
 
+	// A stored policy supplies its own scope, imports, and exclusions
+	if policyID := request.GetString("policy_id", ""); policyID != "" {
+		return g.handleFindApplicableForPolicy(policyID, outputFormat)
+	}
+
 	// Find applicable Layer 1 Guidance documents
 	// Use storage index to get all Layer 1 artifacts, then load and check applicability
 	var applicableLayer1 []string
@@ -50,7 +56,7 @@ func (g *GemaraAuthoringTools) handleFindApplicableArtifacts(ctx context.Context
 
 		// Controls are now at catalog level, not nested in families
 		for _, control := range catalog.Controls {
-			if g.matchesLayer2Applicability(control, technologies, boundaries, providers) {
+			if g.matchesLayer2Applicability(control, catalog.Metadata.ApplicabilityCategories, technologies, boundaries, providers) {
 				applicableLayer2 = append(applicableLayer2, controlRef{
 					catalogID: entry.ID,
 					familyID:  control.Family,
@@ -184,6 +190,14 @@ func (g *GemaraAuthoringTools) getLayerEntries(layer int) []*storage.ArtifactInd
 				Title: catalog.Title,
 			})
 		}
+	case consts.Layer3:
+		for policyID, policy := range g.layer3Policies {
+			entries = append(entries, &storage.ArtifactIndexEntry{
+				ID:    policyID,
+				Layer: consts.Layer3,
+				Title: policy.Title,
+			})
+		}
 	}
 	return entries
 }
@@ -220,6 +234,22 @@ func (g *GemaraAuthoringTools) loadLayer2Catalog(catalogID string) *gemara.Catal
 	return nil
 }
 
+// loadLayer3Policy loads a Layer 3 Policy from cache or storage
+func (g *GemaraAuthoringTools) loadLayer3Policy(policyID string) *gemara.Policy {
+	if p, exists := g.layer3Policies[policyID]; exists {
+		return p
+	}
+	if g.storage != nil {
+		if retrieved, err := g.storage.Retrieve(consts.Layer3, policyID); err == nil {
+			if p, ok := retrieved.(*gemara.Policy); ok {
+				g.layer3Policies[policyID] = p
+				return p
+			}
+		}
+	}
+	return nil
+}
+
 // findControlFamily finds a control family by ID
 func (g *GemaraAuthoringTools) findControlFamily(catalogID, familyID string) *gemara.Family {
 	catalog, ok := g.layer2Catalogs[catalogID]
@@ -247,3 +277,214 @@ func (g *GemaraAuthoringTools) findControl(catalogID, familyID, controlID string
 	}
 	return nil
 }
+
+// applicabilityDecision explains why an artifact or element was included in or excluded from a policy's scope
+type applicabilityDecision struct {
+	Layer      int    `json:"layer"`
+	ArtifactID string `json:"artifact_id"`
+	ElementID  string `json:"element_id,omitempty"`
+	Title      string `json:"title"`
+	Included   bool   `json:"included"`
+	Reason     string `json:"reason"`
+}
+
+// policyScopeTerms holds the in-scope and out-of-scope terms of a policy
+type policyScopeTerms struct {
+	In  []scopeTerms
+	Out []scopeTerms
+}
+
+// handleFindApplicableForPolicy finds Layer 1 guidance and Layer 2 controls applicable to a stored policy,
+// honoring its in-scope and out-of-scope dimensions and the exclusions of its imports
+func (g *GemaraAuthoringTools) handleFindApplicableForPolicy(policyID, outputFormat string) (*mcp.CallToolResult, error) {
+	policy := g.loadLayer3Policy(policyID)
+	if policy == nil {
+		return mcp.NewToolResultErrorf("Policy with ID '%s' not found. Use list_layer3_policies to see available policies.", policyID), nil
+	}
+
+	decisions := g.decidePolicyApplicability(policy)
+
+	if outputFormat == "json" {
+		result := map[string]interface{}{
+			"policy_id": policyID,
+			"scope": map[string]interface{}{
+				"in":  policy.Scope.In,
+				"out": policy.Scope.Out,
+			},
+			"decisions": decisions,
+		}
+		output, err := marshalOutput(result, "json")
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal JSON: %v", err), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("# Applicable Artifacts for Policy: %s\n\n", policy.Title))
+
+	result.WriteString("## Policy Scope\n\n")
+	terms := policyScope(policy)
+	inValues, outValues := scopeValues(terms.In), scopeValues(terms.Out)
+	if len(inValues) > 0 {
+		result.WriteString(fmt.Sprintf("- **In Scope**: %s\n", strings.Join(inValues, ", ")))
+	}
+	if len(outValues) > 0 {
+		result.WriteString(fmt.Sprintf("- **Out of Scope**: %s\n", strings.Join(outValues, ", ")))
+	}
+	if len(inValues) == 0 && len(outValues) == 0 {
+		result.WriteString("- **Scope**: No scope dimensions declared\n")
+	}
+	result.WriteString("\n")
+
+	for _, section := range []struct {
+		layer int
+		title string
+	}{{consts.Layer1, "Layer 1 Guidance"}, {consts.Layer2, "Layer 2 Controls"}} {
+		result.WriteString(fmt.Sprintf("## %s\n\n", section.title))
+		for _, included := range []bool{true, false} {
+			var matched []applicabilityDecision
+			for _, d := range decisions {
+				if d.Layer == section.layer && d.Included == included {
+					matched = append(matched, d)
+				}
+			}
+			if included {
+				result.WriteString(fmt.Sprintf("### Included (%d)\n\n", len(matched)))
+			} else {
+				result.WriteString(fmt.Sprintf("### Excluded (%d)\n\n", len(matched)))
+			}
+			if len(matched) == 0 {
+				continue
+			}
+			for _, d := range matched {
+				id := d.ArtifactID
+				if d.ElementID != "" {
+					id = fmt.Sprintf("%s / %s", d.ArtifactID, d.ElementID)
+				}
+				result.WriteString(fmt.Sprintf("- **%s**: %s - %s\n", id, d.Title, d.Reason))
+			}
+			result.WriteString("\n")
+		}
+	}
+
+	return mcp.NewToolResultText(result.String()), nil
+}
+
+// decidePolicyApplicability decides for every stored guidance document and control whether it applies to the policy
+func (g *GemaraAuthoringTools) decidePolicyApplicability(policy *gemara.Policy) []applicabilityDecision {
+	terms := policyScope(policy)
+	var decisions []applicabilityDecision
+
+	guidanceImports := make(map[string]gemara.GuidanceImport)
+	for _, imported := range policy.Imports.Guidance {
+		guidanceImports[imported.ReferenceId] = imported
+	}
+	for _, entry := range g.getLayerEntries(consts.Layer1) {
+		guidance := g.loadLayer1Guidance(entry.ID)
+		if guidance == nil {
+			continue
+		}
+		var values []string
+		for _, category := range guidance.Metadata.ApplicabilityCategories {
+			values = append(values, category.Id, category.Title)
+		}
+		imported, isImported := guidanceImports[entry.ID]
+		decision := g.decideScope(terms, values, isImported, "")
+		decision.Layer = consts.Layer1
+		decision.ArtifactID = entry.ID
+		decision.Title = guidance.Title
+		decisions = append(decisions, decision)
+
+		// Excluded guidelines only matter when the document itself is in scope
+		if decision.Included {
+			for _, guideline := range guidance.Guidelines {
+				if slices.Contains(imported.Exclusions, guideline.Id) {
+					decisions = append(decisions, applicabilityDecision{
+						Layer:      consts.Layer1,
+						ArtifactID: entry.ID,
+						ElementID:  guideline.Id,
+						Title:      guideline.Title,
+						Reason:     "listed in the exclusions of the policy's guidance import",
+					})
+				}
+			}
+		}
+	}
+
+	catalogImports := make(map[string]gemara.CatalogImport)
+	for _, imported := range policy.Imports.Catalogs {
+		catalogImports[imported.ReferenceId] = imported
+	}
+	for _, entry := range g.getLayerEntries(consts.Layer2) {
+		catalog := g.loadLayer2Catalog(entry.ID)
+		if catalog == nil {
+			continue
+		}
+		imported, isImported := catalogImports[entry.ID]
+		for _, control := range catalog.Controls {
+			// Requirements that match out-of-scope terms do not count towards the control,
+			// so a control is only out of scope when all of its requirements are
+			var values, allValues []string
+			for _, req := range control.AssessmentRequirements {
+				reqValues := requirementApplicabilityValues(req.Applicability, catalog.Metadata.ApplicabilityCategories)
+				allValues = append(allValues, reqValues...)
+				if len(coveringScopeTerms(g.scopeEngine, terms.Out, reqValues)) == 0 {
+					values = append(values, reqValues...)
+				}
+			}
+			if len(values) == 0 {
+				values = allValues
+			}
+			excludedBy := ""
+			if slices.Contains(imported.Exclusions, control.Id) {
+				excludedBy = "listed in the exclusions of the policy's catalog import"
+			}
+			decision := g.decideScope(terms, values, isImported, excludedBy)
+			decision.Layer = consts.Layer2
+			decision.ArtifactID = entry.ID
+			decision.ElementID = control.Id
+			decision.Title = control.Title
+			decisions = append(decisions, decision)
+		}
+	}
+
+	return decisions
+}
+
+// decideScope applies, in order, explicit exclusions, out-of-scope terms, policy imports, and in-scope terms.
+// Out-of-scope terms only exclude values they cover, so a policy excluding "Germany" keeps EU-wide artifacts.
+func (g *GemaraAuthoringTools) decideScope(terms policyScopeTerms, values []string, isImported bool, excludedBy string) applicabilityDecision {
+	if excludedBy != "" {
+		return applicabilityDecision{Reason: excludedBy}
+	}
+	if out := coveringScopeTerms(g.scopeEngine, terms.Out, values); len(out) > 0 {
+		return applicabilityDecision{Reason: fmt.Sprintf("matches out-of-scope %s", strings.Join(out, ", "))}
+	}
+	in := matchingScopeTerms(g.scopeEngine, terms.In, values)
+	if isImported {
+		reason := "imported by the policy"
+		if len(in) > 0 {
+			reason += fmt.Sprintf(" and matches in-scope %s", strings.Join(in, ", "))
+		}
+		return applicabilityDecision{Included: true, Reason: reason}
+	}
+	if len(scopeValues(terms.In)) == 0 {
+		return applicabilityDecision{Included: true, Reason: "the policy declares no in-scope restrictions"}
+	}
+	if len(in) > 0 {
+		return applicabilityDecision{Included: true, Reason: fmt.Sprintf("matches in-scope %s", strings.Join(in, ", "))}
+	}
+	if len(values) == 0 {
+		return applicabilityDecision{Reason: "declares no applicability to match against the policy scope"}
+	}
+	return applicabilityDecision{Reason: "matches none of the in-scope dimensions"}
+}
+
+// policyScope collects the terms of all in-scope and out-of-scope dimensions of a policy
+func policyScope(policy *gemara.Policy) policyScopeTerms {
+	return policyScopeTerms{
+		In:  dimensionTerms(policy.Scope.In),
+		Out: dimensionTerms(policy.Scope.Out),
+	}
+}
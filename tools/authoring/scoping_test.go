@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/complytime/gemara-mcp-server/internal/scope"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecidePolicyApplicability(t *testing.T) {
+	guidance := func(id string, categories ...gemara.Category) *gemara.GuidanceDocument {
+		return &gemara.GuidanceDocument{
+			Title:      id + " Guidance",
+			Metadata:   gemara.Metadata{Id: id, ApplicabilityCategories: categories},
+			Guidelines: []gemara.Guideline{{Id: "G-1", Title: "Kept"}, {Id: "G-X", Title: "Excluded"}},
+		}
+	}
+	control := func(id string, applicability ...[]string) gemara.Control {
+		c := gemara.Control{Id: id, Title: id + " Control"}
+		for i, app := range applicability {
+			c.AssessmentRequirements = append(c.AssessmentRequirements, gemara.AssessmentRequirement{Id: id + "." + string(rune('1'+i)), Applicability: app})
+		}
+		return c
+	}
+	g := &GemaraAuthoringTools{
+		layer1Guidance: map[string]*gemara.GuidanceDocument{
+			"EU-DOC":    guidance("EU-DOC", gemara.Category{Id: "eu", Title: "European Union"}),
+			"ITALY-DOC": guidance("ITALY-DOC", gemara.Category{Id: "italy", Title: "Italy"}),
+			"US-DOC":    guidance("US-DOC", gemara.Category{Id: "us", Title: "United States"}),
+			"JP-DOC":    guidance("JP-DOC", gemara.Category{Id: "jp", Title: "Japan"}),
+			"DE-DOC":    guidance("DE-DOC", gemara.Category{Id: "de", Title: "Germany"}),
+			"NONE-DOC":  guidance("NONE-DOC"),
+		},
+		layer2Catalogs: map[string]*gemara.Catalog{
+			"CAT": {
+				Title: "Catalog",
+				Metadata: gemara.Metadata{Id: "CAT", ApplicabilityCategories: []gemara.Category{
+					{Id: "de", Title: "Germany"},
+					{Id: "fr", Title: "France"},
+				}},
+				Controls: []gemara.Control{
+					control("C1", []string{"de"}),
+					control("C2", []string{"fr"}),
+					control("C3", []string{"de"}, []string{"fr"}),
+					control("C4", []string{"fr"}),
+				},
+			},
+		},
+		layer3Policies: map[string]*gemara.Policy{},
+		scopeEngine:    scope.DefaultEngine(),
+	}
+	policy := &gemara.Policy{
+		Scope: gemara.Scope{
+			In:  gemara.Dimensions{Geopolitical: []string{"eu"}, Technologies: []string{"IT"}},
+			Out: gemara.Dimensions{Geopolitical: []string{"Germany"}},
+		},
+		Imports: gemara.Imports{
+			Guidance: []gemara.GuidanceImport{{ReferenceId: "US-DOC", Exclusions: []string{"G-X"}}},
+			Catalogs: []gemara.CatalogImport{{ReferenceId: "CAT", Exclusions: []string{"C4", "c2"}}},
+		},
+	}
+
+	decisions := make(map[string]applicabilityDecision)
+	for _, d := range g.decidePolicyApplicability(policy) {
+		decisions[d.ArtifactID+"/"+d.ElementID] = d
+	}
+
+	tests := []struct {
+		name     string
+		key      string
+		included bool
+		reason   string
+	}{
+		{name: "in-scope synonym in another case", key: "EU-DOC/", included: true, reason: "matches in-scope eu"},
+		{name: "child of an in-scope term, not the IT technology", key: "ITALY-DOC/", included: true, reason: "matches in-scope eu"},
+		{name: "imported document", key: "US-DOC/", included: true, reason: "imported by the policy"},
+		{name: "excluded guideline of an imported document", key: "US-DOC/G-X", reason: "listed in the exclusions of the policy's guidance import"},
+		{name: "outside the in-scope terms", key: "JP-DOC/", reason: "matches none of the in-scope dimensions"},
+		{name: "out-of-scope term", key: "DE-DOC/", reason: "matches out-of-scope Germany"},
+		{name: "no applicability", key: "NONE-DOC/", reason: "declares no applicability to match against the policy scope"},
+		{name: "control with out-of-scope requirements only", key: "CAT/C1", reason: "matches out-of-scope Germany"},
+		{name: "imported control, exclusions are case sensitive", key: "CAT/C2", included: true, reason: "imported by the policy and matches in-scope eu"},
+		{name: "out-of-scope requirements do not exclude the control", key: "CAT/C3", included: true, reason: "imported by the policy and matches in-scope eu"},
+		{name: "excluded control", key: "CAT/C4", reason: "listed in the exclusions of the policy's catalog import"},
+	}
+
+	assert.Len(t, decisions, len(tests))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, ok := decisions[tt.key]
+			if !assert.True(t, ok, "no decision for %s", tt.key) {
+				return
+			}
+			assert.Equal(t, tt.included, d.Included)
+			assert.Equal(t, tt.reason, d.Reason)
+		})
+	}
+
+	// Without in-scope terms everything that is not excluded applies
+	policy.Scope.In = gemara.Dimensions{}
+	for _, d := range g.decidePolicyApplicability(policy) {
+		if d.ArtifactID == "JP-DOC" {
+			assert.True(t, d.Included)
+			assert.Equal(t, "the policy declares no in-scope restrictions", d.Reason)
+		}
+	}
+}
+
+func TestMatchesApplicability(t *testing.T) {
+	g := &GemaraAuthoringTools{scopeEngine: scope.DefaultEngine()}
+	categories := []gemara.Category{
+		{Id: "k8s", Title: "Containers"},
+		{Id: "iaas", Title: "IaaS"},
+		{Id: "mobile"},
+	}
+	control := func(applicability ...string) *gemara.Control {
+		return &gemara.Control{Id: "CTL-1", AssessmentRequirements: []gemara.AssessmentRequirement{{Id: "CTL-1.1", Applicability: applicability}}}
+	}
+
+	tests := []struct {
+		name         string
+		guidance     []gemara.Category
+		control      *gemara.Control
+		technologies []string
+		boundaries   []string
+		providers    []string
+		want         bool
+	}{
+		{name: "no scope matches guidance without categories", want: true},
+		{name: "guidance without categories", technologies: []string{"Cloud"}},
+		{name: "boundary covers a member state", guidance: []gemara.Category{{Id: "de", Title: "Germany"}}, boundaries: []string{"EU"}, want: true},
+		{name: "technology synonym is not a boundary synonym", guidance: []gemara.Category{{Id: "italy", Title: "Italy"}}, technologies: []string{"IT"}},
+		{name: "boundary synonym", guidance: []gemara.Category{{Id: "italy", Title: "Italy"}}, boundaries: []string{"IT"}, want: true},
+		{name: "technology term in another case covers a child", guidance: []gemara.Category{{Id: "iaas"}}, technologies: []string{"cloud"}, want: true},
+		{name: "unrelated provider", guidance: []gemara.Category{{Id: "azure", Title: "Azure"}}, providers: []string{"aws"}},
+		{name: "unknown term matches itself", guidance: []gemara.Category{{Id: "quantum"}}, technologies: []string{"Quantum"}, want: true},
+		{name: "control matches any scope without a technology", control: control("mobile"), boundaries: []string{"EU"}, want: true},
+		{name: "control matches through the declared category title", control: control("k8s"), technologies: []string{"Kubernetes"}, want: true},
+		{name: "control with unrelated applicability", control: control("mobile"), technologies: []string{"AI"}},
+		{name: "control without requirements", control: &gemara.Control{Id: "CTL-2"}, technologies: []string{"Cloud"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bool
+			if tt.control != nil {
+				got = g.matchesLayer2Applicability(*tt.control, categories, tt.technologies, tt.boundaries, tt.providers)
+			} else {
+				guidance := &gemara.GuidanceDocument{Metadata: gemara.Metadata{ApplicabilityCategories: tt.guidance}}
+				got = g.matchesLayer1Applicability(guidance, tt.technologies, tt.boundaries, tt.providers)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFindApplicableArtifactsJSON(t *testing.T) {
+	g := testMappingTools()
+	g.scopeEngine = scope.DefaultEngine()
+	g.layer1Guidance["GUIDE"].Metadata.ApplicabilityCategories = []gemara.Category{{Id: "containers"}}
+	for _, catalog := range g.layer2Catalogs {
+		catalog.Metadata.ApplicabilityCategories = []gemara.Category{{Id: "k8s", Title: "Kubernetes"}}
+		catalog.Controls[0].AssessmentRequirements = []gemara.AssessmentRequirement{{Id: "CTL-1.1", Applicability: []string{"k8s"}}}
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"technologies": []any{"Container Orchestration"}, "output_format": "json"}
+	result, err := g.handleFindApplicableArtifacts(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var found struct {
+		Layer1 []string            `json:"layer1_guidance"`
+		Layer2 []map[string]string `json:"layer2_controls"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &found))
+	assert.Equal(t, []string{"GUIDE"}, found.Layer1)
+
+	// Colliding control IDs are reported once per catalog
+	var controls []string
+	for _, ctrl := range found.Layer2 {
+		controls = append(controls, ctrl["catalog_id"]+"/"+ctrl["control_id"])
+	}
+	sort.Strings(controls)
+	assert.Equal(t, []string{"CAT-A/CTL-1", "CAT-B/CTL-1"}, controls)
+}
@@ -0,0 +1,101 @@
+package authoring
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/complytime/gemara-mcp-server/internal/site"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+)
+
+// sitePageKinds maps layers to the site pages that render their artifacts
+var sitePageKinds = map[int]string{
+	consts.Layer1: site.KindGuidance,
+	consts.Layer2: site.KindCatalog,
+	consts.Layer3: site.KindPolicy,
+}
+
+// handleRenderArtifact renders the site page of a stored artifact, optionally writing the whole site
+func (g *GemaraAuthoringTools) handleRenderArtifact(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	layer := request.GetInt("layer", 0)
+	artifactID := request.GetString("artifact_id", "")
+	outputDir := request.GetString("output_dir", "")
+	kind, ok := sitePageKinds[layer]
+	if !ok {
+		return mcp.NewToolResultErrorf("invalid layer %d: render_artifact supports layers 1, 2, and 3", layer), nil
+	}
+	if artifactID == "" {
+		return mcp.NewToolResultError("artifact_id is required"), nil
+	}
+
+	defaultFormat := site.FormatMarkdown
+	if outputDir != "" {
+		defaultFormat = site.FormatHTML
+	}
+	options := site.Options{
+		Format:      request.GetString("format", defaultFormat),
+		Title:       request.GetString("site_title", ""),
+		TemplateDir: request.GetString("template_dir", ""),
+	}
+	renderer, err := g.newSiteRenderer(options)
+	if err != nil {
+		return mcp.NewToolResultErrorf("Failed to render artifact: %v", err), nil
+	}
+	page, err := renderer.Page(kind, artifactID)
+	if err != nil {
+		return mcp.NewToolResultErrorf("Failed to render artifact: %v", err), nil
+	}
+	if outputDir == "" {
+		return mcp.NewToolResultText(string(page.Content)), nil
+	}
+
+	pages, err := renderer.Write(outputDir)
+	if err != nil {
+		return mcp.NewToolResultErrorf("Failed to write site: %v", err), nil
+	}
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Rendered %d files to %s.\n\n", len(pages), outputDir))
+	result.WriteString(fmt.Sprintf("- **%s**: %s\n", page.Title, filepath.Join(outputDir, filepath.FromSlash(page.Path))))
+	result.WriteString(fmt.Sprintf("- **Index**: %s\n", filepath.Join(outputDir, "index"+filepath.Ext(page.Path))))
+	result.WriteString(fmt.Sprintf("- **Search index**: %s\n", filepath.Join(outputDir, site.SearchIndexFile)))
+	return mcp.NewToolResultText(result.String()), nil
+}
+
+// RenderSite writes every stored guidance document, catalog and policy into outputDir as a
+// cross-linked static site and returns the written files
+func (g *GemaraAuthoringTools) RenderSite(outputDir string, options site.Options) ([]site.Page, error) {
+	renderer, err := g.newSiteRenderer(options)
+	if err != nil {
+		return nil, err
+	}
+	return renderer.Write(outputDir)
+}
+
+// newSiteRenderer creates a site renderer over all stored Layer 1-3 artifacts
+func (g *GemaraAuthoringTools) newSiteRenderer(options site.Options) (*site.Renderer, error) {
+	artifacts := site.Artifacts{
+		Guidance: map[string]*gemara.GuidanceDocument{},
+		Catalogs: map[string]*gemara.Catalog{},
+		Policies: map[string]*gemara.Policy{},
+	}
+	for _, entry := range g.getLayerEntries(consts.Layer1) {
+		if guidance := g.loadLayer1Guidance(entry.ID); guidance != nil {
+			artifacts.Guidance[entry.ID] = guidance
+		}
+	}
+	for _, entry := range g.getLayerEntries(consts.Layer2) {
+		if catalog := g.loadLayer2Catalog(entry.ID); catalog != nil {
+			artifacts.Catalogs[entry.ID] = catalog
+		}
+	}
+	for _, entry := range g.getLayerEntries(consts.Layer3) {
+		if policy := g.loadLayer3Policy(entry.ID); policy != nil {
+			artifacts.Policies[entry.ID] = policy
+		}
+	}
+	return site.New(artifacts, options)
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderArtifact(t *testing.T) {
+	catalog := testExportCatalog()
+	g := &GemaraAuthoringTools{
+		layer1Guidance: map[string]*gemara.GuidanceDocument{},
+		layer2Catalogs: map[string]*gemara.Catalog{catalog.Metadata.Id: catalog},
+		layer3Policies: map[string]*gemara.Policy{},
+	}
+	render := func(arguments map[string]any) *mcp.CallToolResult {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = arguments
+		result, err := g.handleRenderArtifact(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+	text := func(result *mcp.CallToolResult) string {
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	result := render(map[string]any{"layer": 2, "artifact_id": "export-catalog"})
+	require.False(t, result.IsError, text(result))
+	assert.Contains(t, text(result), "### AC-01: MFA")
+	assert.Contains(t, text(result), "| guideline | [NIST SP 800-53](https://example.com/800-53) | ia-2 | 8 |  |")
+
+	dir := filepath.Join(t.TempDir(), "site")
+	result = render(map[string]any{"layer": 2, "artifact_id": "export-catalog", "output_dir": dir})
+	require.False(t, result.IsError, text(result))
+	assert.Contains(t, text(result), filepath.Join(dir, "catalogs", "export-catalog.html"))
+	_, err := os.Stat(filepath.Join(dir, "search.json"))
+	assert.NoError(t, err)
+
+	assert.True(t, render(map[string]any{"layer": 4, "artifact_id": "export-catalog"}).IsError)
+	assert.Contains(t, text(render(map[string]any{"layer": 3, "artifact_id": "missing"})), "artifact missing not found")
+}
@@ -0,0 +1,116 @@
+package authoring
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/complytime/gemara-mcp-server/storage"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// layerHealth counts the indexed and quarantined files of one layer
+type layerHealth struct {
+	Layer       int `json:"layer"`
+	Indexed     int `json:"indexed"`
+	Quarantined int `json:"quarantined"`
+}
+
+// storageHealth reports which artifact files storage indexed and which it quarantined
+type storageHealth struct {
+	BaseDir     string                     `json:"base_dir"`
+	Layers      []layerHealth              `json:"layers"`
+	Quarantined []*storage.QuarantinedFile `json:"quarantined"`
+}
+
+// handleGetStorageHealth reports the files storage could not index and why
+func (g *GemaraAuthoringTools) handleGetStorageHealth(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	outputFormat := request.GetString("output_format", "markdown")
+
+	if g.storage == nil {
+		return mcp.NewToolResultError("storage not available"), nil
+	}
+	if err := g.storage.Rescan(); err != nil {
+		slog.Warn("Failed to rescan storage for new artifacts", "error", err)
+	}
+
+	health := &storageHealth{BaseDir: g.storage.GetBaseDir(), Quarantined: g.storageProblems(0)}
+	for layer := consts.Layer1; layer <= consts.Layer3; layer++ {
+		health.Layers = append(health.Layers, layerHealth{
+			Layer:       layer,
+			Indexed:     len(g.storage.List(layer)),
+			Quarantined: len(g.storageProblems(layer)),
+		})
+	}
+
+	if outputFormat == "json" {
+		output, err := marshalOutput(health, "json")
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal JSON: %v", err), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}
+	return mcp.NewToolResultText(health.toMarkdown()), nil
+}
+
+// toMarkdown renders the per-layer counts and the quarantined files
+func (h *storageHealth) toMarkdown() string {
+	var result strings.Builder
+	result.WriteString("## Storage Health\n\n")
+	result.WriteString(fmt.Sprintf("Artifacts directory: `%s`\n\n", h.BaseDir))
+	result.WriteString("| Layer | Indexed | Quarantined |\n")
+	result.WriteString("|-------|---------|-------------|\n")
+	for _, layer := range h.Layers {
+		result.WriteString(fmt.Sprintf("| %d | %d | %d |\n", layer.Layer, layer.Indexed, layer.Quarantined))
+	}
+	result.WriteString("\n")
+
+	if len(h.Quarantined) == 0 {
+		result.WriteString("✅ Every artifact file in the layer directories is indexed.\n")
+		return result.String()
+	}
+	result.WriteString(fmt.Sprintf("⚠️ %d file(s) could not be indexed.\n\n", len(h.Quarantined)))
+	writeQuarantinedFiles(&result, h.Quarantined)
+	return result.String()
+}
+
+// writeQuarantinedFiles renders quarantined files as a markdown table
+func writeQuarantinedFiles(result *strings.Builder, files []*storage.QuarantinedFile) {
+	result.WriteString("These files are in a layer directory but could not be indexed, so list, get, and search tools do not see them. Fix the error and they are picked up on the next rescan.\n\n")
+	result.WriteString("| Layer | File | Error |\n")
+	result.WriteString("|-------|------|-------|\n")
+	for _, file := range files {
+		result.WriteString(fmt.Sprintf("| %d | `%s` | %s |\n", file.Layer, file.FilePath, escapeTableCell(file.Error)))
+	}
+	result.WriteString("\n")
+}
+
+// storageProblems returns the files of a layer (or of all layers if layer is 0) that storage could not
+// index, or nil when the storage does not keep such files
+func (g *GemaraAuthoringTools) storageProblems(layer int) []*storage.QuarantinedFile {
+	if quarantine, ok := g.storage.(storage.QuarantineStore); ok {
+		return quarantine.ListProblems(layer)
+	}
+	return nil
+}
+
+// quarantineNote returns a short note for list results naming the files of a layer that could not
+// be indexed, or "" if there are none
+func (g *GemaraAuthoringTools) quarantineNote(layer int) string {
+	if g.storage == nil {
+		return ""
+	}
+	files := g.storageProblems(layer)
+	if len(files) == 0 {
+		return ""
+	}
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("\n⚠️ %d Layer %d file(s) could not be indexed and are missing from this list:\n", len(files), layer))
+	for _, file := range files {
+		result.WriteString(fmt.Sprintf("- `%s`: %s\n", file.FilePath, file.Error))
+	}
+	result.WriteString("Use get_storage_health for details.\n")
+	return result.String()
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/complytime/gemara-mcp-server/storage"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageHealth(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"layer3/a-policy.yaml":  "title: First\nmetadata:\n  id: P1\n",
+		"layer3/b-policy.yaml":  "title: Second\nmetadata:\n  id: P1\n",
+		"layer3/no-id.yaml":     "title: No ID\nmetadata:\n  description: missing\n",
+		"layer3/typo.yaml":      "title: Typo\nmetadata: [\n",
+		"layer3/notes.txt":      "not an artifact",
+		"layer1/guidance.yaml":  "title: Guidance\nmetadata:\n  id: G1\n",
+		"layer4/evaluation.yml": "metadata:\n  id: E1\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	store, err := storage.NewArtifactStorage(dir)
+	require.NoError(t, err)
+	g := &GemaraAuthoringTools{
+		storage:        store,
+		layer1Guidance: map[string]*gemara.GuidanceDocument{},
+		layer2Catalogs: map[string]*gemara.Catalog{},
+		layer3Policies: map[string]*gemara.Policy{},
+	}
+
+	problems := store.ListProblems(0)
+	require.Len(t, problems, 3)
+	assert.Equal(t, filepath.Join(dir, "layer3", "a-policy.yaml"), problems[0].FilePath)
+	assert.Contains(t, problems[0].Error, "duplicate ID 'P1'")
+	assert.Equal(t, "metadata.id is missing", problems[1].Error)
+	assert.Contains(t, problems[2].Error, "error decoding YAML")
+	assert.Empty(t, store.ListProblems(1))
+
+	// Quarantined files are addressed by layer and file name
+	assert.Equal(t, "no-id.yaml", problems[1].FileName)
+	content, err := store.RetrieveQuarantined(3, "no-id.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, files["layer3/no-id.yaml"], content)
+	_, err = store.RetrieveQuarantined(2, "no-id.yaml")
+	assert.ErrorContains(t, err, "file is not quarantined: layer 2, no-id.yaml")
+	_, err = store.RetrieveQuarantined(3, problems[1].FilePath)
+	assert.Error(t, err)
+
+	call := func(handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), arguments map[string]any) string {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = arguments
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	health := call(g.handleGetStorageHealth, map[string]any{})
+	assert.Contains(t, health, "| 3 | 1 | 3 |")
+	assert.Contains(t, health, "`"+filepath.Join(dir, "layer3", "no-id.yaml")+"` | metadata.id is missing |")
+
+	list := call(g.handleListLayer3Policies, map[string]any{})
+	assert.Contains(t, list, "3 Layer 3 file(s) could not be indexed")
+	assert.NotContains(t, call(g.handleListLayer1Guidance, map[string]any{}), "could not be indexed")
+
+	// Storing a fixed artifact over a quarantined file releases it, and a rescan drops removed files
+	_, err = store.StoreRawYAML(3, "title: Fixed\nmetadata:\n  id: no-id\n")
+	require.NoError(t, err)
+	assert.Len(t, store.ListProblems(3), 2)
+	require.NoError(t, os.Remove(filepath.Join(dir, "layer3", "typo.yaml")))
+	require.NoError(t, store.Rescan())
+	assert.Len(t, store.ListProblems(3), 1)
+
+	// Storage that does not keep quarantined files reports none
+	g.storage = plainStorage{store}
+	assert.Contains(t, call(g.handleGetStorageHealth, map[string]any{}), "| 3 | 2 | 0 |")
+	assert.NotContains(t, call(g.handleListLayer3Policies, map[string]any{}), "could not be indexed")
+}
@@ -4,8 +4,6 @@ import (
 	"fmt"
 
 	"github.com/complytime/gemara-mcp-server/internal/consts"
-	"github.com/goccy/go-yaml"
-	"github.com/ossf/gemara"
 )
 
 // StoreValidatedYAML stores YAML content with CUE validation
@@ -45,51 +43,17 @@ func (g *GemaraAuthoringTools) LoadAndValidateArtifact(layer int, artifactID str
 	if g.storage == nil {
 		return fmt.Errorf("storage not available")
 	}
+	if layer < consts.Layer1 || layer > consts.Layer3 {
+		return fmt.Errorf("layer %d validation not implemented", layer)
+	}
 
-	// Retrieve artifact
-	retrieved, err := g.storage.Retrieve(layer, artifactID)
+	// Validate the file as written rather than a re-marshalled copy, which would drop comments and
+	// fields the gemara types do not model and could hide what is actually on disk
+	yamlContent, err := g.retrieveRaw(layer, artifactID)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve artifact: %w", err)
 	}
 
-	// Convert to YAML for validation
-	var yamlContent string
-	switch layer {
-	case consts.Layer1:
-		if guidance, ok := retrieved.(*gemara.GuidanceDocument); ok {
-			// Marshal back to YAML for validation
-			yamlBytes, err := yaml.Marshal(guidance)
-			if err != nil {
-				return fmt.Errorf("failed to marshal for validation: %w", err)
-			}
-			yamlContent = string(yamlBytes)
-		} else {
-			return fmt.Errorf("retrieved artifact is not a Layer 1 Guidance document")
-		}
-	case consts.Layer2:
-		if catalog, ok := retrieved.(*gemara.Catalog); ok {
-			yamlBytes, err := yaml.Marshal(catalog)
-			if err != nil {
-				return fmt.Errorf("failed to marshal for validation: %w", err)
-			}
-			yamlContent = string(yamlBytes)
-		} else {
-			return fmt.Errorf("retrieved artifact is not a Layer 2 Catalog")
-		}
-	case consts.Layer3:
-		if policy, ok := retrieved.(*gemara.Policy); ok {
-			yamlBytes, err := yaml.Marshal(policy)
-			if err != nil {
-				return fmt.Errorf("failed to marshal for validation: %w", err)
-			}
-			yamlContent = string(yamlBytes)
-		} else {
-			return fmt.Errorf("retrieved artifact is not a Layer 3 Policy document")
-		}
-	default:
-		return fmt.Errorf("layer %d validation not implemented", layer)
-	}
-
 	// Validate
 	validationResult := g.infoTools.PerformCUEValidation(yamlContent, layer)
 	if !validationResult.Valid {
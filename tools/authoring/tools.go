@@ -1,11 +1,17 @@
 package authoring
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
 
+	"github.com/complytime/gemara-mcp-server/internal/scope"
 	"github.com/complytime/gemara-mcp-server/storage"
 	"github.com/complytime/gemara-mcp-server/tools/info"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/ossf/gemara"
 )
@@ -25,6 +31,10 @@ type GemaraAuthoringTools struct {
 	layer3Policies map[string]*gemara.Policy
 	// CUE schema cache
 	schemaCache map[int]string // layer -> schema content
+	// Scope matching engine shared by all search and scoping tools
+	scopeEngine *scope.Engine
+	// Serializes read-modify-write edits of stored artifacts
+	editMu sync.Mutex
 }
 
 // NewGemaraAuthoringTools creates a new GemaraAuthoringTools instance with default local storage.
@@ -35,6 +45,13 @@ func NewGemaraAuthoringTools() (*GemaraAuthoringTools, error) {
 // NewGemaraAuthoringToolsWithStorage creates a new GemaraAuthoringTools instance with the provided storage.
 // If storage is nil, it will use the default local file-based storage.
 func NewGemaraAuthoringToolsWithStorage(customStorage storage.Storage) (*GemaraAuthoringTools, error) {
+	return NewGemaraAuthoringToolsWithInfo(customStorage, nil)
+}
+
+// NewGemaraAuthoringToolsWithInfo creates a new GemaraAuthoringTools instance with the provided storage that
+// validates with the provided info tools. If storage is nil, it will use the default local file-based storage,
+// and if infoTools is nil, info tools with the default schema version are created.
+func NewGemaraAuthoringToolsWithInfo(customStorage storage.Storage, infoTools *info.GemaraInfoTools) (*GemaraAuthoringTools, error) {
 	g := &GemaraAuthoringTools{
 		layer1Guidance: make(map[string]*gemara.GuidanceDocument),
 		layer2Catalogs: make(map[string]*gemara.Catalog),
@@ -43,9 +60,11 @@ func NewGemaraAuthoringToolsWithStorage(customStorage storage.Storage) (*GemaraA
 	}
 
 	// Initialize info tools for validation and schema access
-	infoTools, err := info.NewGemaraInfoTools()
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize info tools: %w", err)
+	if infoTools == nil {
+		var err error
+		if infoTools, err = info.NewGemaraInfoTools(); err != nil {
+			return nil, fmt.Errorf("failed to initialize info tools: %w", err)
+		}
 	}
 	g.infoTools = infoTools
 
@@ -78,6 +97,20 @@ func NewGemaraAuthoringToolsWithStorage(customStorage storage.Storage) (*GemaraA
 		slog.Info("Artifact storage initialized successfully", "type", "remote")
 	}
 
+	// Use the artifacts directory's scope taxonomy if present, otherwise the built-in one
+	g.scopeEngine = scope.DefaultEngine()
+	if baseDir != "" {
+		taxonomyPath := filepath.Join(baseDir, scope.TaxonomyFileName)
+		if _, err := os.Stat(taxonomyPath); err == nil {
+			engine, err := scope.LoadEngine(taxonomyPath)
+			if err != nil {
+				return nil, err
+			}
+			g.scopeEngine = engine
+			slog.Info("Loaded scope taxonomy", "path", taxonomyPath)
+		}
+	}
+
 	g.tools = g.registerTools()
 	g.prompts = g.registerPrompts()
 
@@ -101,3 +134,17 @@ func (g *GemaraAuthoringTools) Register(s *server.MCPServer) {
 	s.AddPrompts(g.prompts...)
 	s.AddResources(g.resources...)
 }
+
+// CallTool invokes a registered tool by name with the given arguments, exactly as an MCP client
+// call would, so the command line can reuse the tool handlers without starting a server
+func (g *GemaraAuthoringTools) CallTool(ctx context.Context, name string, arguments map[string]any) (*mcp.CallToolResult, error) {
+	for _, tool := range g.tools {
+		if tool.Tool.Name == name {
+			request := mcp.CallToolRequest{}
+			request.Params.Name = name
+			request.Params.Arguments = arguments
+			return tool.Handler(ctx, request)
+		}
+	}
+	return nil, fmt.Errorf("unknown tool %q", name)
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/complytime/gemara-mcp-server/storage"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallTool(t *testing.T) {
+	catalog := testExportCatalog()
+	g := &GemaraAuthoringTools{
+		layer1Guidance: map[string]*gemara.GuidanceDocument{},
+		layer2Catalogs: map[string]*gemara.Catalog{catalog.Metadata.Id: catalog},
+		layer3Policies: map[string]*gemara.Policy{},
+	}
+	g.tools = g.registerTools()
+
+	result, err := g.CallTool(context.Background(), "get_layer2_control", map[string]any{"control_id": "AC-02", "output_format": "json"})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "Catalog: export-catalog")
+
+	result, err = g.CallTool(context.Background(), "get_layer2_control", map[string]any{"control_id": "XX-99"})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+
+	_, err = g.CallTool(context.Background(), "no_such_tool", nil)
+	assert.ErrorContains(t, err, `unknown tool "no_such_tool"`)
+}
+
+func TestListAndSearchJSON(t *testing.T) {
+	store, err := storage.NewArtifactStorage(t.TempDir())
+	require.NoError(t, err)
+	g := &GemaraAuthoringTools{
+		storage:        store,
+		layer1Guidance: map[string]*gemara.GuidanceDocument{},
+		layer2Catalogs: map[string]*gemara.Catalog{},
+		layer3Policies: map[string]*gemara.Policy{},
+	}
+	g.tools = g.registerTools()
+
+	call := func(name string, arguments map[string]any) string {
+		arguments["output_format"] = "json"
+		result, err := g.CallTool(context.Background(), name, arguments)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		text := result.Content[0].(mcp.TextContent).Text
+		require.True(t, json.Valid([]byte(text)), "%s did not return JSON: %s", name, text)
+		return text
+	}
+
+	// Empty stores and searches without matches still return JSON
+	for _, name := range []string{"list_layer1_guidance", "list_layer2_controls", "list_layer3_policies"} {
+		assert.Equal(t, "[]", call(name, map[string]any{}))
+	}
+	for _, name := range []string{"search_layer1_guidance", "search_layer2_controls", "search_layer3_policies"} {
+		assert.Equal(t, "[]", call(name, map[string]any{"search_term": "nothing"}))
+	}
+
+	_, err = store.StoreRawYAML(1, "title: Guidance\nmetadata:\n  id: G1\n  description: Test guidance\n  version: \"1.0\"\n")
+	require.NoError(t, err)
+	var guidance []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(call("list_layer1_guidance", map[string]any{})), &guidance))
+	assert.Equal(t, []map[string]any{{"guidance_id": "G1", "title": "Guidance", "description": "Test guidance", "version": "1.0"}}, guidance)
+}
@@ -6,13 +6,78 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
-	"strings"
 
+	"github.com/complytime/gemara-mcp-server/internal/scope"
+	"github.com/complytime/gemara-mcp-server/storage"
 	"github.com/goccy/go-yaml"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/ossf/gemara"
 )
 
+// outputOriginal is the output_format of get tools that returns the stored file byte-for-byte
+const outputOriginal = "original"
+
+// originalArtifact returns the stored file of an artifact exactly as it is on disk, in the YAML or
+// JSON it was stored in
+func (g *GemaraAuthoringTools) originalArtifact(layer int, artifactID string) *mcp.CallToolResult {
+	if g.storage == nil {
+		return mcp.NewToolResultError("storage not available: output_format 'original' needs a stored artifact")
+	}
+	raw, ok := g.storage.(storage.RawStore)
+	if !ok {
+		return mcp.NewToolResultError("output_format 'original' is not supported by this storage; use 'yaml' or 'json'")
+	}
+	content, err := raw.RetrieveRaw(layer, artifactID)
+	if err != nil {
+		return mcp.NewToolResultErrorf("Layer %d artifact with ID '%s' is not stored: %v", layer, artifactID, err)
+	}
+	return mcp.NewToolResultText(content)
+}
+
+// retrieveRaw returns the stored file of an artifact exactly as it is stored. Storage that does not
+// keep the files returns the artifact re-marshalled as YAML instead.
+func (g *GemaraAuthoringTools) retrieveRaw(layer int, artifactID string) (string, error) {
+	if raw, ok := g.storage.(storage.RawStore); ok {
+		return raw.RetrieveRaw(layer, artifactID)
+	}
+	artifact, err := g.storage.Retrieve(layer, artifactID)
+	if err != nil {
+		return "", err
+	}
+	data, err := storage.MarshalArtifact(artifact)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal artifact: %w", err)
+	}
+	return string(data), nil
+}
+
+// storedArtifactYAML returns the stored file of an artifact as YAML, converting artifacts stored as
+// JSON so that they can be edited with yamldoc. Storing the edited YAML converts it back to JSON.
+func (g *GemaraAuthoringTools) storedArtifactYAML(layer int, artifactID string) (string, error) {
+	content, err := g.retrieveRaw(layer, artifactID)
+	if err != nil {
+		return "", err
+	}
+	converted, err := storage.ConvertFormat([]byte(content), storage.FormatYAML)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert stored JSON to YAML: %w", err)
+	}
+	return string(converted), nil
+}
+
+// yamlArtifactID returns the metadata.id of YAML or JSON content, or "" if it has none
+func yamlArtifactID(content string) string {
+	var artifact struct {
+		Metadata struct {
+			ID string `yaml:"id"`
+		} `yaml:"metadata"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &artifact); err != nil {
+		return ""
+	}
+	return artifact.Metadata.ID
+}
+
 // stringPtr returns a pointer to the given string
 func stringPtr(s string) *string {
 	return &s
@@ -36,6 +101,39 @@ func marshalOutput(data interface{}, outputFormat string) (string, error) {
 	return string(yamlBytes), nil
 }
 
+// artifactContent returns the yaml_content or json_content argument of a store tool. JSON content
+// must be a JSON object, so that it is stored as JSON rather than read as YAML.
+func artifactContent(request mcp.CallToolRequest) (string, error) {
+	yamlContent := request.GetString("yaml_content", "")
+	jsonContent := request.GetString("json_content", "")
+	switch {
+	case yamlContent != "" && jsonContent != "":
+		return "", fmt.Errorf("provide only one of yaml_content or json_content")
+	case jsonContent != "":
+		if storage.DetectFormat([]byte(jsonContent)) != storage.FormatJSON {
+			var parsed interface{}
+			if err := json.Unmarshal([]byte(jsonContent), &parsed); err != nil {
+				return "", fmt.Errorf("json_content is not valid JSON: %w", err)
+			}
+			return "", fmt.Errorf("json_content must be a JSON object")
+		}
+		return jsonContent, nil
+	case yamlContent != "":
+		return yamlContent, nil
+	default:
+		return "", fmt.Errorf("yaml_content or json_content is required")
+	}
+}
+
+// marshalArtifactYAML renders a gemara artifact as YAML in the same form storage writes it
+func marshalArtifactYAML(artifact interface{}) (string, error) {
+	yamlBytes, err := storage.MarshalArtifact(artifact)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+	return string(yamlBytes), nil
+}
+
 // sanitizeID creates a valid ID from a string (lowercase, replace spaces/special chars with hyphens)
 func (g *GemaraAuthoringTools) sanitizeID(s string) string {
 	result := ""
@@ -111,118 +209,70 @@ func (g *GemaraAuthoringTools) getArtifactsDir() string {
 	return fallbackPath
 }
 
-// containsIgnoreCase performs case-insensitive substring search
-func containsIgnoreCase(s, substr string) bool {
-	// Simple case-insensitive search
-	// In production, use strings.Contains with proper Unicode case folding
-	if len(substr) == 0 {
-		return true
-	}
-	if len(substr) > len(s) {
-		return false
-	}
-	// Simple approach: check if substr appears in s (case-insensitive)
-	for i := 0; i <= len(s)-len(substr); i++ {
-		match := true
-		for j := 0; j < len(substr); j++ {
-			c1 := s[i+j]
-			c2 := substr[j]
-			// Simple case-insensitive comparison
-			if c1 != c2 && c1 != c2+32 && c1 != c2-32 {
-				match = false
-				break
-			}
-		}
-		if match {
-			return true
-		}
-	}
-	return false
-}
-
 // matchesLayer1Applicability checks if Layer 1 Guidance matches the policy scope
-// Layer 1 applicability now uses ApplicabilityCategories in metadata
+// Scope terms are matched against the IDs and titles of the document's applicability categories
 func (g *GemaraAuthoringTools) matchesLayer1Applicability(guidance *gemara.GuidanceDocument, technologyScope, boundariesScope, providersScope []string) bool {
 	// If no scope is provided, match all
 	if len(technologyScope) == 0 && len(boundariesScope) == 0 && len(providersScope) == 0 {
 		return true
 	}
 
-	// Check if applicability categories exist
-	if len(guidance.Metadata.ApplicabilityCategories) == 0 {
-		// If no applicability categories, match if no scopes are required
-		return len(technologyScope) == 0 && len(boundariesScope) == 0 && len(providersScope) == 0
-	}
-
-	// Match against applicability categories by ID or Title
-	// Since the new structure uses Categories instead of specific fields,
-	// we'll match if any category ID or title matches any scope term
-	allScopes := append(append(technologyScope, boundariesScope...), providersScope...)
-	if len(allScopes) == 0 {
-		return true
-	}
-
+	var values []string
 	for _, category := range guidance.Metadata.ApplicabilityCategories {
-		for _, scope := range allScopes {
-			// Case-insensitive match on category ID or title
-			if containsIgnoreCase(category.Id, scope) || containsIgnoreCase(scope, category.Id) ||
-				containsIgnoreCase(category.Title, scope) || containsIgnoreCase(scope, category.Title) {
-				return true
-			}
-		}
+		values = append(values, category.Id, category.Title)
 	}
 
-	return false
+	return g.matchesScopeTerms(values, technologyScope, boundariesScope, providersScope)
 }
 
 // matchesLayer2Applicability checks if Layer 2 Control matches the policy scope
-// Layer 2 controls can have technology field and assessment requirements with applicability
-func (g *GemaraAuthoringTools) matchesLayer2Applicability(control gemara.Control, technologyScope, boundariesScope, providersScope []string) bool {
+// Scope terms are matched against the applicability of the control's assessment requirements,
+// using both the category IDs and the titles the catalog declares for them
+func (g *GemaraAuthoringTools) matchesLayer2Applicability(control gemara.Control, categories []gemara.Category, technologyScope, boundariesScope, providersScope []string) bool {
 	// If no scope is provided, match all
 	if len(technologyScope) == 0 && len(boundariesScope) == 0 && len(providersScope) == 0 {
 		return true
 	}
 
-	// Check technology match by examining the catalog metadata or control family
-	// For now, we'll check assessment requirements' applicability
-	hasMatchingApplicability := false
+	// Only a technology scope restricts controls; boundaries and providers are catalog-wide concerns
+	if len(technologyScope) == 0 {
+		return true
+	}
+
+	values := controlApplicabilityValues(control, categories)
+	return g.matchesScopeTerms(values, technologyScope, boundariesScope, providersScope)
+}
+
+// matchesScopeTerms reports whether any technology, boundary, or provider scope term matches the values.
+// Each term's synonyms resolve only in its own taxonomy dimension.
+func (g *GemaraAuthoringTools) matchesScopeTerms(values, technologyScope, boundariesScope, providersScope []string) bool {
+	return len(matchingScopeTerms(g.scopeEngine, []scopeTerms{
+		{dimension: scope.Technologies, values: technologyScope},
+		{dimension: scope.Boundaries, values: boundariesScope},
+		{dimension: scope.Providers, values: providersScope},
+	}, values)) > 0
+}
 
-	// Check assessment requirements for applicability
+// controlApplicabilityValues returns the applicability category IDs of a control's assessment requirements
+// together with the titles the catalog declares for them
+func controlApplicabilityValues(control gemara.Control, categories []gemara.Category) []string {
+	var values []string
 	for _, req := range control.AssessmentRequirements {
-		if len(req.Applicability) > 0 {
-			// If any assessment requirement has applicability that matches scope, consider it a match
-			for _, app := range req.Applicability {
-				appLower := strings.ToLower(app)
-				// Check against technology scope
-				for _, tech := range technologyScope {
-					if containsIgnoreCase(appLower, strings.ToLower(tech)) || containsIgnoreCase(strings.ToLower(tech), appLower) {
-						hasMatchingApplicability = true
-						break
-					}
-				}
-				// Check against boundaries scope
-				for _, boundary := range boundariesScope {
-					if containsIgnoreCase(appLower, strings.ToLower(boundary)) || containsIgnoreCase(strings.ToLower(boundary), appLower) {
-						hasMatchingApplicability = true
-						break
-					}
-				}
-				// Check against providers scope
-				for _, provider := range providersScope {
-					if containsIgnoreCase(appLower, strings.ToLower(provider)) || containsIgnoreCase(strings.ToLower(provider), appLower) {
-						hasMatchingApplicability = true
-						break
-					}
-				}
-			}
-		}
+		values = append(values, requirementApplicabilityValues(req.Applicability, categories)...)
 	}
+	return values
+}
 
-	// If technology scope is provided but no matching applicability found, return false
-	if len(technologyScope) > 0 && !hasMatchingApplicability {
-		return false
+// requirementApplicabilityValues returns applicability category IDs together with their declared titles
+func requirementApplicabilityValues(applicability []string, categories []gemara.Category) []string {
+	var values []string
+	for _, app := range applicability {
+		values = append(values, app)
+		for _, category := range categories {
+			if category.Id == app && category.Title != "" {
+				values = append(values, category.Title)
+			}
+		}
 	}
-
-	// If no specific scope requirements or we found matches, return true
-	return true
+	return values
 }
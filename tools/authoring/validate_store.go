@@ -0,0 +1,448 @@
+package authoring
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/complytime/gemara-mcp-server/storage"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ossf/gemara"
+)
+
+// Outcomes of validating a stored artifact
+const (
+	artifactValid   = "valid"
+	artifactWarning = "warning"
+	artifactFailed  = "failed"
+)
+
+// StoredArtifactResult is the validation outcome of one indexed artifact. Schema and reference
+// errors fail the artifact; fidelity issues only warn, since the file is still stored as written.
+type StoredArtifactResult struct {
+	Layer           int             `json:"layer"`
+	ArtifactID      string          `json:"artifact_id"`
+	File            string          `json:"file"`
+	Status          string          `json:"status"`
+	SchemaErrors    []string        `json:"schema_errors,omitempty"`
+	ReferenceErrors []string        `json:"reference_errors,omitempty"`
+	FidelityIssues  []fidelityIssue `json:"fidelity_issues,omitempty"`
+}
+
+// LayerValidationSummary counts the validation outcomes of one layer
+type LayerValidationSummary struct {
+	Layer     int `json:"layer"`
+	Artifacts int `json:"artifacts"`
+	Valid     int `json:"valid"`
+	Warnings  int `json:"warnings"`
+	Failed    int `json:"failed"`
+	Unindexed int `json:"unindexed"`
+}
+
+// StoreValidationReport is the result of validating every stored artifact of one or more layers
+type StoreValidationReport struct {
+	Layers    []LayerValidationSummary   `json:"layers"`
+	Artifacts []StoredArtifactResult     `json:"artifacts"`
+	Unindexed []*storage.QuarantinedFile `json:"unindexed"`
+}
+
+// storedReferences holds the stored artifacts that references are resolved against. It is built
+// before validation starts so the concurrent workers only read it.
+type storedReferences struct {
+	guidance map[string]*gemara.GuidanceDocument
+	catalogs map[string]*gemara.Catalog
+	policies map[string]bool
+}
+
+// handleValidateStoredArtifacts validates every stored artifact against its schema, its references, and the gemara types
+func (g *GemaraAuthoringTools) handleValidateStoredArtifacts(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	layer := request.GetInt("layer", 0)
+	outputFormat := request.GetString("output_format", "markdown")
+
+	report, err := g.ValidateStoredArtifacts(layer)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if outputFormat == "json" {
+		output, err := marshalOutput(report, "json")
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal JSON: %v", err), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}
+	return mcp.NewToolResultText(report.ToMarkdown()), nil
+}
+
+// ValidateStoredArtifacts validates the indexed artifacts of a layer, or of layers 1 to 3 when layer
+// is 0, and lists the files of those layer directories that storage quarantined. Layer 4 is not
+// supported because storage does not index evaluation logs, so there is nothing to list or resolve
+// references against. Artifacts are validated concurrently; the report is ordered by layer and artifact ID.
+func (g *GemaraAuthoringTools) ValidateStoredArtifacts(layer int) (*StoreValidationReport, error) {
+	if g.storage == nil {
+		return nil, fmt.Errorf("storage not available")
+	}
+	if layer != 0 && (layer < consts.Layer1 || layer > consts.Layer3) {
+		return nil, fmt.Errorf("layer must be between %d and %d, got %d; Layer 4 evaluation logs are not indexed, validate them by file", consts.Layer1, consts.Layer3, layer)
+	}
+	layers := []int{consts.Layer1, consts.Layer2, consts.Layer3}
+	if layer != 0 {
+		layers = []int{layer}
+	}
+
+	// Rescan storage so files added since the last scan are validated or reported
+	if err := g.storage.Rescan(); err != nil {
+		slog.Warn("Failed to rescan storage for new artifacts", "error", err)
+	}
+
+	refs := g.loadStoredReferences()
+	report := &StoreValidationReport{Artifacts: []StoredArtifactResult{}, Unindexed: []*storage.QuarantinedFile{}}
+	var entries []*storage.ArtifactIndexEntry
+	for _, l := range layers {
+		layerEntries := g.storage.List(l)
+		sort.Slice(layerEntries, func(i, j int) bool { return layerEntries[i].ID < layerEntries[j].ID })
+		entries = append(entries, layerEntries...)
+		report.Unindexed = append(report.Unindexed, g.storageProblems(l)...)
+	}
+
+	report.Artifacts = make([]StoredArtifactResult, len(entries))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(runtime.NumCPU(), len(entries)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				report.Artifacts[i] = g.validateStoredArtifact(entries[i], refs)
+			}
+		}()
+	}
+	for i := range entries {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, l := range layers {
+		summary := LayerValidationSummary{Layer: l}
+		for _, result := range report.Artifacts {
+			if result.Layer != l {
+				continue
+			}
+			summary.Artifacts++
+			switch result.Status {
+			case artifactValid:
+				summary.Valid++
+			case artifactWarning:
+				summary.Warnings++
+			default:
+				summary.Failed++
+			}
+		}
+		for _, file := range report.Unindexed {
+			if file.Layer == l {
+				summary.Unindexed++
+			}
+		}
+		report.Layers = append(report.Layers, summary)
+	}
+	return report, nil
+}
+
+// Failed reports whether any artifact failed validation or any file could not be indexed
+func (r *StoreValidationReport) Failed() bool {
+	if len(r.Unindexed) > 0 {
+		return true
+	}
+	for _, result := range r.Artifacts {
+		if result.Status == artifactFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// validateStoredArtifact checks the file of one index entry as written on disk
+func (g *GemaraAuthoringTools) validateStoredArtifact(entry *storage.ArtifactIndexEntry, refs *storedReferences) StoredArtifactResult {
+	result := StoredArtifactResult{Layer: entry.Layer, ArtifactID: entry.ID, File: entry.FilePath, Status: artifactFailed}
+	content, err := g.retrieveRaw(entry.Layer, entry.ID)
+	if err != nil {
+		result.SchemaErrors = []string{err.Error()}
+		return result
+	}
+
+	// Schema validation needs the info tools; without them only references and fidelity are checked
+	if g.infoTools != nil {
+		validation := g.infoTools.PerformCUEValidation(content, entry.Layer)
+		if !validation.Valid {
+			if validation.Error != "" {
+				result.SchemaErrors = append(result.SchemaErrors, strings.TrimSpace(validation.Error))
+			}
+			result.SchemaErrors = append(result.SchemaErrors, validation.Errors...)
+		}
+	}
+
+	artifact, err := g.loadArtifactForDiff(entry.Layer, "", content)
+	if err != nil {
+		result.ReferenceErrors = []string{err.Error()}
+	} else {
+		result.ReferenceErrors = refs.check(artifact)
+	}
+
+	if fidelity, err := g.checkFidelity(entry.Layer, content); err == nil {
+		result.FidelityIssues = fidelity.Issues
+	}
+
+	switch {
+	case len(result.SchemaErrors) > 0 || len(result.ReferenceErrors) > 0:
+		result.Status = artifactFailed
+	case len(result.FidelityIssues) > 0:
+		result.Status = artifactWarning
+	default:
+		result.Status = artifactValid
+	}
+	return result
+}
+
+// loadStoredReferences loads every stored artifact that a reference can point to
+func (g *GemaraAuthoringTools) loadStoredReferences() *storedReferences {
+	refs := &storedReferences{
+		guidance: make(map[string]*gemara.GuidanceDocument),
+		catalogs: make(map[string]*gemara.Catalog),
+		policies: make(map[string]bool),
+	}
+	for _, entry := range g.getLayerEntries(consts.Layer1) {
+		if guidance := g.loadLayer1Guidance(entry.ID); guidance != nil {
+			refs.guidance[entry.ID] = guidance
+		}
+	}
+	for _, entry := range g.getLayerEntries(consts.Layer2) {
+		if catalog := g.loadLayer2Catalog(entry.ID); catalog != nil {
+			refs.catalogs[entry.ID] = catalog
+		}
+	}
+	for _, entry := range g.getLayerEntries(consts.Layer3) {
+		refs.policies[entry.ID] = true
+	}
+	return refs
+}
+
+// check returns the references of an artifact that do not resolve: undeclared families and
+// mapping references, see-also links to missing guidelines, mapping entries missing from stored
+// guidance, and policy imports of unstored artifacts or of elements they do not contain
+func (r *storedReferences) check(artifact interface{}) []string {
+	var problems []string
+	switch a := artifact.(type) {
+	case *gemara.GuidanceDocument:
+		families := declaredFamilies(a.Families)
+		guidelines := make(map[string]bool)
+		for _, guideline := range a.Guidelines {
+			guidelines[guideline.Id] = true
+		}
+		for _, guideline := range a.Guidelines {
+			owner := fmt.Sprintf("guideline '%s'", guideline.Id)
+			if len(families) > 0 && !families[guideline.Family] {
+				problems = append(problems, fmt.Sprintf("%s: family '%s' is not declared in families", owner, guideline.Family))
+			}
+			for _, id := range guideline.SeeAlso {
+				if !guidelines[id] {
+					problems = append(problems, fmt.Sprintf("%s: see-also '%s' is not a guideline in this document", owner, id))
+				}
+			}
+			problems = append(problems, r.checkMappings(owner, "guideline-mappings", guideline.GuidelineMappings, a.Metadata.MappingReferences)...)
+			problems = append(problems, r.checkMappings(owner, "principle-mappings", guideline.PrincipleMappings, a.Metadata.MappingReferences)...)
+		}
+	case *gemara.Catalog:
+		families := declaredFamilies(a.Families)
+		for _, control := range a.Controls {
+			owner := fmt.Sprintf("control '%s'", control.Id)
+			if len(families) > 0 && !families[control.Family] {
+				problems = append(problems, fmt.Sprintf("%s: family '%s' is not declared in families", owner, control.Family))
+			}
+			problems = append(problems, r.checkMappings(owner, "guideline-mappings", control.GuidelineMappings, a.Metadata.MappingReferences)...)
+		}
+	case *gemara.Policy:
+		for _, id := range a.Imports.Policies {
+			if !r.policies[id] {
+				problems = append(problems, fmt.Sprintf("imported policy '%s' is not stored", id))
+			}
+		}
+		for _, imported := range a.Imports.Catalogs {
+			catalog, ok := r.catalogs[imported.ReferenceId]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("imported catalog '%s' is not stored", imported.ReferenceId))
+				continue
+			}
+			elements := catalogElementIDs(catalog)
+			owner := fmt.Sprintf("catalog import '%s'", imported.ReferenceId)
+			problems = append(problems, missingTargets(owner, "exclusion", imported.Exclusions, elements, "control or requirement")...)
+			for _, constraint := range imported.Constraints {
+				problems = append(problems, missingTargets(owner, fmt.Sprintf("constraint '%s' target", constraint.Id), []string{constraint.TargetId}, elements, "control or requirement")...)
+			}
+			for _, modifier := range imported.AssessmentRequirementModifications {
+				problems = append(problems, missingTargets(owner, fmt.Sprintf("modification '%s' target", modifier.Id), []string{modifier.TargetId}, elements, "control or requirement")...)
+			}
+		}
+		for _, imported := range a.Imports.Guidance {
+			guidance, ok := r.guidance[imported.ReferenceId]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("imported guidance '%s' is not stored", imported.ReferenceId))
+				continue
+			}
+			elements := guidanceElementIDs(guidance)
+			owner := fmt.Sprintf("guidance import '%s'", imported.ReferenceId)
+			problems = append(problems, missingTargets(owner, "exclusion", imported.Exclusions, elements, "guideline or statement")...)
+			for _, constraint := range imported.Constraints {
+				problems = append(problems, missingTargets(owner, fmt.Sprintf("constraint '%s' target", constraint.Id), []string{constraint.TargetId}, elements, "guideline or statement")...)
+			}
+		}
+	}
+	return problems
+}
+
+// checkMappings reports mappings whose reference is not declared in metadata.mapping-references,
+// and guideline mapping entries that are missing from the referenced guidance when it is stored
+func (r *storedReferences) checkMappings(owner, field string, mappings []gemara.MultiMapping, declared []gemara.MappingReference) []string {
+	var problems []string
+	for _, mapping := range mappings {
+		found := false
+		for _, reference := range declared {
+			if reference.Id == mapping.ReferenceId {
+				found = true
+				break
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s: %s reference '%s' is not declared in metadata.mapping-references", owner, field, mapping.ReferenceId))
+		}
+		guidance, ok := r.guidance[mapping.ReferenceId]
+		if !ok || field != "guideline-mappings" {
+			continue
+		}
+		elements := guidanceElementIDs(guidance)
+		for _, entry := range mapping.Entries {
+			if !elements[entry.ReferenceId] {
+				problems = append(problems, fmt.Sprintf("%s: %s entry '%s' is not a guideline or statement in guidance '%s'", owner, field, entry.ReferenceId, mapping.ReferenceId))
+			}
+		}
+	}
+	return problems
+}
+
+// missingTargets reports the IDs that are not among the elements of an imported artifact
+func missingTargets(owner, what string, ids []string, elements map[string]bool, kind string) []string {
+	var problems []string
+	for _, id := range ids {
+		if !elements[id] {
+			problems = append(problems, fmt.Sprintf("%s: %s '%s' is not a %s in the imported artifact", owner, what, id, kind))
+		}
+	}
+	return problems
+}
+
+// declaredFamilies returns the IDs of the families an artifact declares
+func declaredFamilies(families []gemara.Family) map[string]bool {
+	ids := make(map[string]bool)
+	for _, family := range families {
+		ids[family.Id] = true
+	}
+	return ids
+}
+
+// guidanceElementIDs returns the IDs of the guidelines and statements of a guidance document
+func guidanceElementIDs(guidance *gemara.GuidanceDocument) map[string]bool {
+	ids := make(map[string]bool)
+	for _, guideline := range guidance.Guidelines {
+		ids[guideline.Id] = true
+		for _, statement := range guideline.Statements {
+			ids[statement.Id] = true
+		}
+	}
+	return ids
+}
+
+// catalogElementIDs returns the IDs of the controls and assessment requirements of a catalog
+func catalogElementIDs(catalog *gemara.Catalog) map[string]bool {
+	ids := make(map[string]bool)
+	for _, control := range catalog.Controls {
+		ids[control.Id] = true
+		for _, req := range control.AssessmentRequirements {
+			ids[req.Id] = true
+		}
+	}
+	return ids
+}
+
+// ToMarkdown renders the per-layer summary, the failed artifacts, the warnings, and the unindexed files
+func (r *StoreValidationReport) ToMarkdown() string {
+	var result strings.Builder
+	result.WriteString("## Stored Artifact Validation\n\n")
+	result.WriteString("| Layer | Artifacts | Valid | Warnings | Failed | Unindexed Files |\n")
+	result.WriteString("|-------|-----------|-------|----------|--------|-----------------|\n")
+	failed := 0
+	for _, summary := range r.Layers {
+		result.WriteString(fmt.Sprintf("| %d | %d | %d | %d | %d | %d |\n",
+			summary.Layer, summary.Artifacts, summary.Valid, summary.Warnings, summary.Failed, summary.Unindexed))
+		failed += summary.Failed
+	}
+	result.WriteString("\n")
+
+	if !r.Failed() {
+		result.WriteString(fmt.Sprintf("✅ All %d stored artifact(s) passed schema and reference validation.\n\n", len(r.Artifacts)))
+	} else {
+		result.WriteString(fmt.Sprintf("❌ %d artifact(s) failed validation and %d file(s) could not be indexed.\n\n", failed, len(r.Unindexed)))
+	}
+
+	if failed > 0 {
+		result.WriteString("### Failed Artifacts\n\n")
+		for _, artifact := range r.Artifacts {
+			if artifact.Status != artifactFailed {
+				continue
+			}
+			result.WriteString(fmt.Sprintf("#### Layer %d `%s`\n\n", artifact.Layer, artifact.ArtifactID))
+			result.WriteString(fmt.Sprintf("File: `%s`\n\n", artifact.File))
+			for _, err := range artifact.SchemaErrors {
+				result.WriteString(fmt.Sprintf("- Schema: %s\n", strings.ReplaceAll(err, "\n", "\n  ")))
+			}
+			for _, err := range artifact.ReferenceErrors {
+				result.WriteString(fmt.Sprintf("- Reference: %s\n", err))
+			}
+			writeFidelityIssues(&result, artifact.FidelityIssues)
+			result.WriteString("\n")
+		}
+	}
+
+	var warnings []StoredArtifactResult
+	for _, artifact := range r.Artifacts {
+		if artifact.Status == artifactWarning {
+			warnings = append(warnings, artifact)
+		}
+	}
+	if len(warnings) > 0 {
+		result.WriteString("### Warnings\n\n")
+		for _, artifact := range warnings {
+			result.WriteString(fmt.Sprintf("#### Layer %d `%s`\n\n", artifact.Layer, artifact.ArtifactID))
+			result.WriteString(fmt.Sprintf("File: `%s`\n\n", artifact.File))
+			writeFidelityIssues(&result, artifact.FidelityIssues)
+			result.WriteString("\n")
+		}
+	}
+
+	if len(r.Unindexed) > 0 {
+		result.WriteString("### Unindexed Files\n\n")
+		writeQuarantinedFiles(&result, r.Unindexed)
+	}
+	return result.String()
+}
+
+// writeFidelityIssues lists the fields an artifact loses when read through the gemara types
+func writeFidelityIssues(result *strings.Builder, issues []fidelityIssue) {
+	for _, issue := range issues {
+		result.WriteString(fmt.Sprintf("- Fidelity: `%s` %s (%d occurrence(s))\n", issue.Path, issue.Problem, issue.Occurrences))
+	}
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authoring
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/complytime/gemara-mcp-server/storage"
+	"github.com/ossf/gemara"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateStoredArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"layer1/guidance.yaml": `title: Guidance
+metadata:
+  id: G1
+families:
+  - {id: FAM, title: Family}
+guidelines:
+  - id: G1.01
+    title: First
+    family: FAM
+    see-also: [G1.99]
+    statements:
+      - {id: G1.01.a, text: Statement}
+`,
+		"layer2/catalog.yaml": `title: Catalog
+metadata:
+  id: C1
+  mapping-references:
+    - {id: G1, title: Guidance, version: "1"}
+families:
+  - {id: F1, title: Family}
+controls:
+  - id: C1-01
+    title: Control
+    family: F1
+    assessment-requirements:
+      - {id: C1-01.01, text: Requirement, applicability: [all]}
+    guideline-mappings:
+      - reference-id: G1
+        entries:
+          - {reference-id: G1.01.a}
+          - {reference-id: G1.77}
+`,
+		"layer3/policy.yaml": `title: Policy
+metadata:
+  id: P1
+imports:
+  catalogs:
+    - reference-id: C1
+      exclusions: [C1-01.01, C1-99]
+    - reference-id: MISSING
+`,
+		"layer3/broken.yaml": "title: Broken\nmetadata:\n  id: [P2\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	store, err := storage.NewArtifactStorage(dir)
+	require.NoError(t, err)
+	g := &GemaraAuthoringTools{
+		storage:        store,
+		layer1Guidance: map[string]*gemara.GuidanceDocument{},
+		layer2Catalogs: map[string]*gemara.Catalog{},
+		layer3Policies: map[string]*gemara.Policy{},
+	}
+
+	report, err := g.ValidateStoredArtifacts(0)
+	require.NoError(t, err)
+	assert.True(t, report.Failed())
+	assert.Equal(t, []LayerValidationSummary{
+		{Layer: 1, Artifacts: 1, Failed: 1},
+		{Layer: 2, Artifacts: 1, Failed: 1},
+		{Layer: 3, Artifacts: 1, Failed: 1, Unindexed: 1},
+	}, report.Layers)
+
+	require.Len(t, report.Artifacts, 3)
+	assert.Equal(t, []string{"guideline 'G1.01': see-also 'G1.99' is not a guideline in this document"}, report.Artifacts[0].ReferenceErrors)
+	assert.Equal(t, []string{"control 'C1-01': guideline-mappings entry 'G1.77' is not a guideline or statement in guidance 'G1'"}, report.Artifacts[1].ReferenceErrors)
+	assert.Equal(t, []string{
+		"catalog import 'C1': exclusion 'C1-99' is not a control or requirement in the imported artifact",
+		"imported catalog 'MISSING' is not stored",
+	}, report.Artifacts[2].ReferenceErrors)
+
+	require.Len(t, report.Unindexed, 1)
+	assert.Equal(t, filepath.Join(dir, "layer3", "broken.yaml"), report.Unindexed[0].FilePath)
+	assert.Contains(t, report.Unindexed[0].Error, "error decoding YAML")
+	assert.NotContains(t, report.Unindexed[0].Error, "\n")
+
+	markdown := report.ToMarkdown()
+	assert.Contains(t, markdown, "| 3 | 1 | 0 | 0 | 1 | 1 |")
+	assert.Contains(t, markdown, "- Reference: imported catalog 'MISSING' is not stored")
+	assert.Contains(t, markdown, "### Unindexed Files")
+
+	_, err = g.ValidateStoredArtifacts(4)
+	assert.Error(t, err)
+}
@@ -0,0 +1,167 @@
+package info
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	cueerrors "cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/load"
+	"cuelang.org/go/encoding/yaml"
+	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GemaraSchemaPackage is the import path CUE sources use to import the Gemara schemas
+const GemaraSchemaPackage = "github.com/ossf/gemara/schemas"
+
+const (
+	// cueModule is the module CUE sources are evaluated in. The schemas are placed in its cue.mod/pkg
+	// directory, so imports of GemaraSchemaPackage resolve without a registry.
+	cueModule = "module: \"gemara.local/artifact\"\nlanguage: version: \"v0.15.1\"\n"
+	// cueSourceFile is the file name CUE sources are evaluated as, and that error positions refer to
+	cueSourceFile = "/artifact.cue"
+)
+
+// CUEEvaluationResult holds the result of evaluating CUE source against a layer schema
+type CUEEvaluationResult struct {
+	ValidationResult
+	// YAML is the artifact exported from the CUE source. It is only set when the source is valid.
+	YAML string `json:"yaml,omitempty"`
+}
+
+// handleValidateGemaraCUE evaluates CUE source against a layer schema and returns the exported YAML
+func (g *GemaraInfoTools) handleValidateGemaraCUE(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	cueContent := request.GetString("cue_content", "")
+	layer := request.GetInt("layer", 0)
+	outputFormat := request.GetString("output_format", "text")
+
+	if cueContent == "" {
+		return mcp.NewToolResultError("cue_content is required"), nil
+	}
+	if layer < consts.MinLayer || layer > consts.MaxLayer {
+		return mcp.NewToolResultErrorf("layer must be between %d and %d, got %d", consts.MinLayer, consts.MaxLayer, layer), nil
+	}
+
+	evaluation := g.EvaluateCUE(cueContent, layer)
+	report := struct {
+		ValidationReport
+		YAML string `json:"yaml,omitempty"`
+	}{g.newValidationReport(evaluation.ValidationResult, layer), evaluation.YAML}
+
+	if outputFormat == "json" {
+		jsonBytes, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultErrorf("failed to marshal JSON: %v", err), nil
+		}
+		return mcp.NewToolResultText(string(jsonBytes)), nil
+	}
+
+	result := fmt.Sprintf("# Gemara Layer %d CUE Validation Report\n\n## CUE Schema Validation\n", layer)
+	if evaluation.Valid {
+		result += "✅ CUE validation PASSED\n\n"
+		result += fmt.Sprintf("The CUE source evaluates to a valid Layer %d artifact.\n\n", layer)
+		result += "## Exported YAML\n\n"
+		result += fmt.Sprintf("```yaml\n%s```\n\n", evaluation.YAML)
+	} else {
+		result += "❌ CUE validation FAILED\n\n"
+		if evaluation.Error != "" {
+			result += fmt.Sprintf("**Validation Error:**\n```\n%s\n```\n\n", evaluation.Error)
+		}
+		if len(evaluation.Errors) > 0 {
+			result += "**Detailed Errors:**\n"
+			for i, err := range evaluation.Errors {
+				result += fmt.Sprintf("  %d. %s\n", i+1, err)
+			}
+			result += "\n"
+		}
+	}
+	result += report.schemaInformation()
+	return mcp.NewToolResultText(result), nil
+}
+
+// EvaluateCUE evaluates CUE source against the entry point of a layer schema and exports the result
+// as YAML. The artifact is the top-level value of the source, which may import GemaraSchemaPackage
+// and declare its own definitions, templates, and defaults. Error positions refer to artifact.cue.
+func (g *GemaraInfoTools) EvaluateCUE(cueContent string, layer int) CUEEvaluationResult {
+	result := CUEEvaluationResult{ValidationResult: ValidationResult{Valid: true, Errors: []string{}}}
+	fail := func(message string, err error) CUEEvaluationResult {
+		result.Valid = false
+		result.Error = message
+		if err != nil {
+			result.Error = fmt.Sprintf("%s: %v", message, err)
+			result.Errors = errorDetails(err)
+		}
+		return result
+	}
+
+	if layer < consts.MinLayer || layer > consts.MaxLayer {
+		return fail(fmt.Sprintf("Invalid layer: %d (must be %d-%d)", layer, consts.MinLayer, consts.MaxLayer), nil)
+	}
+	// Sources import the whole schema package, so a policy can use catalog definitions and so on
+	sources, err := g.schemaSources(consts.Layer1, consts.Layer2, consts.Layer3, consts.Layer4)
+	if err != nil {
+		return fail(err.Error(), nil)
+	}
+
+	overlay := map[string]load.Source{
+		"/cue.mod/module.cue": load.FromString(cueModule),
+		cueSourceFile:         load.FromString(cueContent),
+	}
+	for name, content := range sources {
+		overlay[path.Join("/cue.mod/pkg", GemaraSchemaPackage, name)] = load.FromString(content)
+	}
+	cfg := &load.Config{
+		Overlay: overlay,
+		Dir:     "/",
+	}
+	ctx := cuecontext.New()
+
+	// The entry point is built from the schema package itself, so sources that do not import it are
+	// still checked against the layer schema
+	schemaInstance := load.Instances([]string{GemaraSchemaPackage}, cfg)[0]
+	if err := schemaInstance.Err; err != nil {
+		return fail("schema build failed", err)
+	}
+	schema := ctx.BuildInstance(schemaInstance)
+	if err := schema.Err(); err != nil {
+		return fail("schema compilation failed", err)
+	}
+	entryPoint := layerEntryPoint(schema, layer)
+	if !entryPoint.Exists() {
+		return fail(fmt.Sprintf("could not find entry point definition for layer %d", layer), nil)
+	}
+
+	sourceInstance := load.Instances([]string{cueSourceFile}, cfg)[0]
+	if err := sourceInstance.Err; err != nil {
+		return fail("Failed to load CUE source", err)
+	}
+	value := ctx.BuildInstance(sourceInstance)
+	if err := value.Err(); err != nil {
+		return fail("CUE evaluation failed", err)
+	}
+
+	unified := value.Unify(entryPoint)
+	if err := unified.Validate(cue.Concrete(true)); err != nil {
+		return fail("Validation failed", err)
+	}
+	exported, err := yaml.Encode(unified)
+	if err != nil {
+		return fail("Failed to export YAML", err)
+	}
+	result.YAML = string(exported)
+	return result
+}
+
+// errorDetails lists each error in a CUE error with its position
+func errorDetails(err error) []string {
+	var details []string
+	for _, e := range cueerrors.Errors(err) {
+		details = append(details, strings.TrimSpace(cueerrors.Details(e, nil)))
+	}
+	return details
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package info
+
+import (
+	"testing"
+
+	"github.com/complytime/gemara-mcp-server/internal/schematest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cueCatalog = `package catalog
+
+import "github.com/ossf/gemara/schemas"
+
+#Requirement: schemas.#AssessmentRequirement & {applicability: *["all"] | [...string]}
+
+title: "Generated Catalog"
+metadata: {
+	id:          "cue-catalog"
+	description: "A catalog generated from CUE"
+	author: {id: "test", name: "TEST", type: "Human"}
+}
+families: [{id: "DATA", title: "Data", description: "Data protection"}]
+controls: [for i in [1, 2] {
+	id:        "DATA-\(i)"
+	title:     "Control \(i)"
+	objective: "Objective \(i)"
+	family:    "DATA"
+	"assessment-requirements": [#Requirement & {id: "DATA-\(i).1", text: "Requirement \(i)"}]
+}]
+`
+
+// TestEvaluateCUE tests evaluating CUE source against a layer schema
+func TestEvaluateCUE(t *testing.T) {
+	g, err := NewGemaraInfoToolsWithClient("main", schematest.Client())
+	require.NoError(t, err)
+
+	result := g.EvaluateCUE(cueCatalog, 2)
+	require.True(t, result.Valid, result.Error)
+	assert.Contains(t, result.YAML, "id: DATA-2.1\n")
+	assert.Contains(t, result.YAML, "- all\n")
+
+	result = g.EvaluateCUE(cueCatalog+"unknown: true\n", 2)
+	assert.False(t, result.Valid)
+	assert.Empty(t, result.YAML)
+	require.NotEmpty(t, result.Errors)
+	assert.Contains(t, result.Errors[0], "unknown: field not allowed")
+	assert.Contains(t, result.Errors[0], "/artifact.cue:")
+
+	result = g.EvaluateCUE(cueCatalog, 1)
+	assert.False(t, result.Valid)
+}
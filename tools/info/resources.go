@@ -213,14 +213,14 @@ func (g *GemaraInfoTools) getCUESchema(layer int) (string, error) {
 	cacheKey := fmt.Sprintf("%s:layer:%d", g.schemaVersion, layer)
 	
 	// Check cache first
-	if schema, ok := g.schemaCache[cacheKey]; ok {
+	if schema, ok := g.cachedSchema(cacheKey); ok {
 		return schema, nil
 	}
 
 	// Fetch schema from GitHub using the configured version
 	schemaURL := fmt.Sprintf("https://raw.githubusercontent.com/ossf/gemara/%s/schemas/layer-%d.cue", g.schemaVersion, layer)
 
-	resp, err := http.Get(schemaURL)
+	resp, err := g.httpClient.Get(schemaURL)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch schema from %s: %w", schemaURL, err)
 	}
@@ -238,7 +238,7 @@ func (g *GemaraInfoTools) getCUESchema(layer int) (string, error) {
 	schemaContent := string(schemaBytes)
 
 	// Cache the schema with version-aware key
-	g.schemaCache[cacheKey] = schemaContent
+	g.cacheSchema(cacheKey, schemaContent)
 
 	return schemaContent, nil
 }
@@ -249,14 +249,14 @@ func (g *GemaraInfoTools) getCommonCUESchema(schemaName string) (string, error)
 	cacheKey := fmt.Sprintf("%s:common:%s", g.schemaVersion, schemaName)
 
 	// Check cache first
-	if schema, ok := g.schemaCache[cacheKey]; ok {
+	if schema, ok := g.cachedSchema(cacheKey); ok {
 		return schema, nil
 	}
 
 	// Fetch schema from GitHub using the configured version
 	schemaURL := fmt.Sprintf("https://raw.githubusercontent.com/ossf/gemara/%s/schemas/%s", g.schemaVersion, schemaName)
 
-	resp, err := http.Get(schemaURL)
+	resp, err := g.httpClient.Get(schemaURL)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch schema from %s: %w", schemaURL, err)
 	}
@@ -274,7 +274,7 @@ func (g *GemaraInfoTools) getCommonCUESchema(schemaName string) (string, error)
 	schemaContent := string(schemaBytes)
 
 	// Cache the schema
-	g.schemaCache[cacheKey] = schemaContent
+	g.cacheSchema(cacheKey, schemaContent)
 
 	return schemaContent, nil
 }
@@ -283,7 +283,7 @@ func (g *GemaraInfoTools) getCommonCUESchema(schemaName string) (string, error)
 func (g *GemaraInfoTools) handleLexiconResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	// Check cache first
 	cacheKey := "lexicon:gemara.openssf.org"
-	if lexicon, ok := g.schemaCache[cacheKey]; ok {
+	if lexicon, ok := g.cachedSchema(cacheKey); ok {
 		return []mcp.ResourceContents{
 			&mcp.TextResourceContents{
 				URI:      request.Params.URI,
@@ -295,7 +295,7 @@ func (g *GemaraInfoTools) handleLexiconResource(ctx context.Context, request mcp
 
 	// Fetch lexicon from Gemara website
 	lexiconURL := "https://gemara.openssf.org/lexicon.html"
-	resp, err := http.Get(lexiconURL)
+	resp, err := g.httpClient.Get(lexiconURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch lexicon from %s: %w", lexiconURL, err)
 	}
@@ -313,7 +313,7 @@ func (g *GemaraInfoTools) handleLexiconResource(ctx context.Context, request mcp
 	lexiconContent := string(lexiconBytes)
 
 	// Cache the lexicon
-	g.schemaCache[cacheKey] = lexiconContent
+	g.cacheSchema(cacheKey, lexiconContent)
 
 	return []mcp.ResourceContents{
 		&mcp.TextResourceContents{
@@ -323,3 +323,18 @@ func (g *GemaraInfoTools) handleLexiconResource(ctx context.Context, request mcp
 		},
 	}, nil
 }
+
+// cachedSchema returns a cached schema or lexicon. The cache is shared by concurrent validations.
+func (g *GemaraInfoTools) cachedSchema(cacheKey string) (string, bool) {
+	g.cacheMu.RLock()
+	defer g.cacheMu.RUnlock()
+	content, ok := g.schemaCache[cacheKey]
+	return content, ok
+}
+
+// cacheSchema stores a fetched schema or lexicon in the cache
+func (g *GemaraInfoTools) cacheSchema(cacheKey, content string) {
+	g.cacheMu.Lock()
+	defer g.cacheMu.Unlock()
+	g.schemaCache[cacheKey] = content
+}
@@ -2,6 +2,9 @@ package info
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"sync"
 
 	"github.com/complytime/gemara-mcp-server/tools/prompts"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -14,8 +17,11 @@ type GemaraInfoTools struct {
 	resources []server.ServerResource
 	// CUE schema cache - key format: "version:layer" or "version:common:name"
 	schemaCache map[string]string
+	cacheMu     sync.RWMutex
 	// Schema version (branch/tag) to use when fetching from GitHub
 	schemaVersion string
+	// HTTP client used to fetch schemas and the lexicon
+	httpClient *http.Client
 }
 
 // NewGemaraInfoTools creates a new GemaraInfoTools instance with default schema version "main".
@@ -26,12 +32,22 @@ func NewGemaraInfoTools() (*GemaraInfoTools, error) {
 // NewGemaraInfoToolsWithVersion creates a new GemaraInfoTools instance with the specified schema version.
 // Version can be a branch name (e.g., "main", "develop") or a tag (e.g., "v1.0.0").
 func NewGemaraInfoToolsWithVersion(version string) (*GemaraInfoTools, error) {
+	return NewGemaraInfoToolsWithClient(version, nil)
+}
+
+// NewGemaraInfoToolsWithClient creates a new GemaraInfoTools instance that fetches schemas of the specified
+// version with the provided HTTP client. If client is nil, http.DefaultClient is used.
+func NewGemaraInfoToolsWithClient(version string, client *http.Client) (*GemaraInfoTools, error) {
 	if version == "" {
 		version = "main"
 	}
+	if client == nil {
+		client = http.DefaultClient
+	}
 	g := &GemaraInfoTools{
 		schemaCache:  make(map[string]string),
 		schemaVersion: version,
+		httpClient:    client,
 	}
 
 	g.tools = g.registerTools()
@@ -55,9 +71,24 @@ func (g *GemaraInfoTools) Register(s *server.MCPServer) {
 	s.AddResources(g.resources...)
 }
 
+// CallTool invokes a registered tool by name with the given arguments, exactly as an MCP client
+// call would, so the command line can reuse the tool handlers without starting a server
+func (g *GemaraInfoTools) CallTool(ctx context.Context, name string, arguments map[string]any) (*mcp.CallToolResult, error) {
+	for _, tool := range g.tools {
+		if tool.Tool.Name == name {
+			request := mcp.CallToolRequest{}
+			request.Params.Name = name
+			request.Params.Arguments = arguments
+			return tool.Handler(ctx, request)
+		}
+	}
+	return nil, fmt.Errorf("unknown tool %q", name)
+}
+
 func (g *GemaraInfoTools) registerTools() []server.ServerTool {
 	var tools []server.ServerTool
 	tools = append(tools, g.newValidateGemaraYAMLTool())
+	tools = append(tools, g.newValidateGemaraCUETool())
 	tools = append(tools, g.newGetGemaraInfoTool())
 	return tools
 }
@@ -66,8 +97,9 @@ func (g *GemaraInfoTools) newValidateGemaraYAMLTool() server.ServerTool {
 	return server.ServerTool{
 		Tool: mcp.NewTool(
 			"validate_gemara_yaml",
-			mcp.WithDescription("Validate YAML content against a Gemara layer schema using CUE. Returns a detailed validation report with any errors found."),
-			mcp.WithString("yaml_content", mcp.Description("Raw YAML content to validate."), mcp.Required()),
+			mcp.WithDescription("Validate YAML or JSON content against a Gemara layer schema using CUE. Returns a detailed validation report with any errors found."),
+			mcp.WithString("yaml_content", mcp.Description("Raw YAML content to validate. Provide this or json_content.")),
+			mcp.WithString("json_content", mcp.Description("Raw JSON content to validate, as a JSON object. Provide this or yaml_content.")),
 			mcp.WithNumber("layer", mcp.Description("Layer number (1-4) to validate against."), mcp.Required()),
 			mcp.WithString("output_format", mcp.Description("Output format: 'text' (default), 'json', or 'sarif' (Static Analysis Results Interchange Format).")),
 		),
@@ -75,6 +107,19 @@ func (g *GemaraInfoTools) newValidateGemaraYAMLTool() server.ServerTool {
 	}
 }
 
+func (g *GemaraInfoTools) newValidateGemaraCUETool() server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(
+			"validate_gemara_cue",
+			mcp.WithDescription("Evaluate CUE source against a Gemara layer schema and return the exported YAML. The artifact is the top-level value of the source, which can import the Gemara schemas as \""+GemaraSchemaPackage+"\" and use its own definitions, templates, comprehensions, and defaults to generate the artifact. Returns a validation report with errors and their positions in artifact.cue."),
+			mcp.WithString("cue_content", mcp.Description("CUE source whose top-level value is the artifact."), mcp.Required()),
+			mcp.WithNumber("layer", mcp.Description("Layer number (1-4) to validate against."), mcp.Required()),
+			mcp.WithString("output_format", mcp.Description("Output format: 'text' (default) or 'json'.")),
+		),
+		Handler: g.handleValidateGemaraCUE,
+	}
+}
+
 func (g *GemaraInfoTools) newGetGemaraInfoTool() server.ServerTool {
 	return server.ServerTool{
 		Tool: mcp.NewTool(
@@ -6,21 +6,36 @@ import (
 	"fmt"
 
 	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/ast/astutil"
 	"cuelang.org/go/cue/cuecontext"
 	"cuelang.org/go/cue/load"
+	cuejson "cuelang.org/go/encoding/json"
 	"cuelang.org/go/encoding/yaml"
 	"github.com/complytime/gemara-mcp-server/internal/consts"
+	"github.com/complytime/gemara-mcp-server/storage"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
-// handleValidateGemaraYAML validates YAML content against a layer schema using CUE
+// handleValidateGemaraYAML validates YAML or JSON content against a layer schema using CUE
 func (g *GemaraInfoTools) handleValidateGemaraYAML(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	yamlContent := request.GetString("yaml_content", "")
+	jsonContent := request.GetString("json_content", "")
 	layer := request.GetInt("layer", 0)
 	outputFormat := request.GetString("output_format", "text")
 
-	if yamlContent == "" {
-		return mcp.NewToolResultError("yaml_content is required"), nil
+	if (yamlContent == "") == (jsonContent == "") {
+		return mcp.NewToolResultError("provide exactly one of yaml_content or json_content"), nil
+	}
+	if jsonContent != "" {
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(jsonContent), &parsed); err != nil {
+			return mcp.NewToolResultErrorf("json_content is not valid JSON: %v", err), nil
+		}
+		if _, ok := parsed.(map[string]interface{}); !ok {
+			return mcp.NewToolResultError("json_content must be a JSON object"), nil
+		}
+		yamlContent = jsonContent
 	}
 
 	if layer < consts.MinLayer || layer > consts.MaxLayer {
@@ -29,18 +44,7 @@ func (g *GemaraInfoTools) handleValidateGemaraYAML(ctx context.Context, request
 
 	// Perform CUE validation
 	validationResult := g.PerformCUEValidation(yamlContent, layer)
-	report := ValidationReport{
-		ValidationResult: validationResult,
-		Layer:            layer,
-		SchemaVersion:    g.schemaVersion,
-		Schema: struct {
-			URL        string `json:"url"`
-			Repository string `json:"repository"`
-		}{
-			URL:        fmt.Sprintf("https://github.com/ossf/gemara/blob/%s/schemas/layer-%d.cue", g.schemaVersion, layer),
-			Repository: fmt.Sprintf("https://github.com/ossf/gemara/tree/%s/schemas", g.schemaVersion),
-		},
-	}
+	report := g.newValidationReport(validationResult, layer)
 
 	// Handle JSON format output
 	if outputFormat == "json" {
@@ -55,7 +59,7 @@ func (g *GemaraInfoTools) handleValidateGemaraYAML(ctx context.Context, request
 	return mcp.NewToolResultText(report.ToText(yamlContent)), nil
 }
 
-// PerformCUEValidation performs CUE schema validation on YAML content
+// PerformCUEValidation performs CUE schema validation on YAML or JSON content
 // This is exported so it can be used by validation scripts
 func (g *GemaraInfoTools) PerformCUEValidation(yamlContent string, layer int) ValidationResult {
 	result := ValidationResult{
@@ -63,55 +67,24 @@ func (g *GemaraInfoTools) PerformCUEValidation(yamlContent string, layer int) Va
 		Errors: []string{},
 	}
 
-	const (
-		base     = "gemara://schema/common/base"
-		metadata = "gemara://schema/common/metadata"
-		mapping  = "gemara://schema/common/mapping"
-	)
-
-	baseSchemaContent, err := g.getSchemaResourceContent(base)
-	if err != nil {
-		result.Valid = false
-		result.Error = fmt.Sprintf("failed to load schema resource %s: %v", base, err)
-		return result
-	}
-
-	metadataSchemaContent, err := g.getSchemaResourceContent(metadata)
-	if err != nil {
-		result.Valid = false
-		result.Error = fmt.Sprintf("failed to load schema resource %s: %v", metadata, err)
-		return result
-	}
-
-	mappingSchemaContent, err := g.getSchemaResourceContent(mapping)
-	if err != nil {
-		result.Valid = false
-		result.Error = fmt.Sprintf("Failed to load schema resource %s: %v", mapping, err)
-		return result
-	}
-
 	// Load layer-specific schema using resource system
 	if layer < consts.MinLayer || layer > consts.MaxLayer {
 		result.Valid = false
 		result.Error = fmt.Sprintf("Invalid layer: %d (must be %d-%d)", layer, consts.MinLayer, consts.MaxLayer)
 		return result
 	}
-
-	layerResourceURI := g.getLayerSchemaResourceURI(layer)
-	layerSchemaContent, err := g.getSchemaResourceContent(layerResourceURI)
+	sources, err := g.schemaSources(layer)
 	if err != nil {
 		result.Valid = false
-		result.Error = fmt.Sprintf("Failed to load layer schema resource %s: %v", layerResourceURI, err)
+		result.Error = err.Error()
 		return result
 	}
 
 	// Create an Overlay
 	// This maps "fake" filenames to the content strings.
-	overlay := map[string]load.Source{
-		"/base.cue":     load.FromBytes([]byte(baseSchemaContent)),
-		"/metadata.cue": load.FromBytes([]byte(metadataSchemaContent)),
-		"/mapping.cue":  load.FromBytes([]byte(mappingSchemaContent)),
-		"/layer.cue":    load.FromBytes([]byte(layerSchemaContent)),
+	overlay := map[string]load.Source{}
+	for name, content := range sources {
+		overlay["/"+name] = load.FromBytes([]byte(content))
 	}
 
 	// 3. Configure the Loader
@@ -146,17 +119,7 @@ func (g *GemaraInfoTools) PerformCUEValidation(yamlContent string, layer int) Va
 	}
 
 	// 6. Narrow down the schema based on the Layer
-	var entryPoint cue.Value
-	switch layer {
-	case consts.Layer1:
-		entryPoint = schema.LookupPath(cue.ParsePath("#GuidanceDocument"))
-	case consts.Layer2:
-		entryPoint = schema.LookupPath(cue.ParsePath("#Catalog"))
-	case consts.Layer3:
-		entryPoint = schema.LookupPath(cue.ParsePath("#Policy"))
-	case consts.Layer4:
-		entryPoint = schema.LookupPath(cue.ParsePath("#EvaluationLog"))
-	}
+	entryPoint := layerEntryPoint(schema, layer)
 
 	// If the lookup fails, we default back to the whole schema or error out
 	if !entryPoint.Exists() {
@@ -165,11 +128,28 @@ func (g *GemaraInfoTools) PerformCUEValidation(yamlContent string, layer int) Va
 		return result
 	}
 
-	yamlFile, err := yaml.Extract("data.yml", yamlContent)
-	if err != nil {
-		result.Valid = false
-		result.Error = fmt.Sprintf("Failed to parse YAML: %v", err)
-		return result
+	// JSON is parsed as JSON so errors point at JSON positions
+	var yamlFile *ast.File
+	if storage.DetectFormat([]byte(yamlContent)) == storage.FormatJSON {
+		expr, err := cuejson.Extract("data.json", []byte(yamlContent))
+		if err != nil {
+			result.Valid = false
+			result.Error = fmt.Sprintf("Failed to parse JSON: %v", err)
+			return result
+		}
+		yamlFile, err = astutil.ToFile(expr)
+		if err != nil {
+			result.Valid = false
+			result.Error = fmt.Sprintf("Failed to parse JSON: %v", err)
+			return result
+		}
+	} else {
+		yamlFile, err = yaml.Extract("data.yml", yamlContent)
+		if err != nil {
+			result.Valid = false
+			result.Error = fmt.Sprintf("Failed to parse YAML: %v", err)
+			return result
+		}
 	}
 
 	// Build the YAML as a CUE value
@@ -194,6 +174,44 @@ func (g *GemaraInfoTools) PerformCUEValidation(yamlContent string, layer int) Va
 	return result
 }
 
+// schemaSources returns the common schema files and the schemas of the given layers by file name,
+// loaded through the resource system
+func (g *GemaraInfoTools) schemaSources(layers ...int) (map[string]string, error) {
+	files := []struct{ name, uri string }{
+		{"base.cue", g.getCommonSchemaResourceURI("base")},
+		{"metadata.cue", g.getCommonSchemaResourceURI("metadata")},
+		{"mapping.cue", g.getCommonSchemaResourceURI("mapping")},
+	}
+	for _, layer := range layers {
+		files = append(files, struct{ name, uri string }{fmt.Sprintf("layer-%d.cue", layer), g.getLayerSchemaResourceURI(layer)})
+	}
+	sources := make(map[string]string, len(files))
+	for _, file := range files {
+		content, err := g.getSchemaResourceContent(file.uri)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load schema resource %s: %v", file.uri, err)
+		}
+		sources[file.name] = content
+	}
+	return sources, nil
+}
+
+// layerEntryPoint returns the definition artifacts of a layer must satisfy. The returned value does
+// not exist for layers without an artifact definition.
+func layerEntryPoint(schema cue.Value, layer int) cue.Value {
+	switch layer {
+	case consts.Layer1:
+		return schema.LookupPath(cue.ParsePath("#GuidanceDocument"))
+	case consts.Layer2:
+		return schema.LookupPath(cue.ParsePath("#Catalog"))
+	case consts.Layer3:
+		return schema.LookupPath(cue.ParsePath("#Policy"))
+	case consts.Layer4:
+		return schema.LookupPath(cue.ParsePath("#EvaluationLog"))
+	}
+	return cue.Value{}
+}
+
 // ValidationResult holds the result of CUE validation
 type ValidationResult struct {
 	Valid  bool     `json:"valid"`
@@ -201,11 +219,27 @@ type ValidationResult struct {
 	Errors []string `json:"errors"`
 }
 
+// newValidationReport wraps a validation result with the schema it was validated against
+func (g *GemaraInfoTools) newValidationReport(result ValidationResult, layer int) ValidationReport {
+	return ValidationReport{
+		ValidationResult: result,
+		Layer:            layer,
+		SchemaVersion:    g.schemaVersion,
+		Schema: struct {
+			URL        string `json:"url"`
+			Repository string `json:"repository"`
+		}{
+			URL:        fmt.Sprintf("https://github.com/ossf/gemara/blob/%s/schemas/layer-%d.cue", g.schemaVersion, layer),
+			Repository: fmt.Sprintf("https://github.com/ossf/gemara/tree/%s/schemas", g.schemaVersion),
+		},
+	}
+}
+
 type ValidationReport struct {
-	ValidationResult `json:,inline`
-	Layer            int    `json:"layer"`
-	SchemaVersion    string `json:"schema_version"`
-	Schema           struct {
+	ValidationResult
+	Layer         int    `json:"layer"`
+	SchemaVersion string `json:"schema_version"`
+	Schema        struct {
 		URL        string `json:"url"`
 		Repository string `json:"repository"`
 	}
@@ -219,7 +253,7 @@ func (v ValidationReport) ToText(yamlContent string) string {
 
 	if v.ValidationResult.Valid {
 		result += "✅ CUE validation PASSED\n\n"
-		result += fmt.Sprintf("The YAML content is valid according to the Layer %d CUE schema.\n\n", v.Layer)
+		result += fmt.Sprintf("The %s content is valid according to the Layer %d CUE schema.\n\n", contentName(yamlContent), v.Layer)
 	} else {
 		result += "❌ CUE validation FAILED\n\n"
 		if v.ValidationResult.Error != "" {
@@ -234,14 +268,11 @@ func (v ValidationReport) ToText(yamlContent string) string {
 		}
 	}
 
-	result += fmt.Sprintf("## Schema Information\n\n")
-	result += fmt.Sprintf("- **Schema Version**: %s\n", v.SchemaVersion)
-	result += fmt.Sprintf("- **Schema URL**: https://github.com/ossf/gemara/blob/%s/schemas/layer-%d.cue\n", v.SchemaVersion, v.Layer)
-	result += fmt.Sprintf("- **Schema Repository**: https://github.com/ossf/gemara/tree/%s/schemas\n\n", v.SchemaVersion)
+	result += v.schemaInformation()
 
 	if !v.ValidationResult.Valid {
-		result += fmt.Sprintf("## Your YAML Content\n\n")
-		result += fmt.Sprintf("```yaml\n")
+		result += fmt.Sprintf("## Your %s Content\n\n", contentName(yamlContent))
+		result += fmt.Sprintf("```%s\n", storage.DetectFormat([]byte(yamlContent)))
 		result += fmt.Sprintf("%s\n", yamlContent)
 		result += fmt.Sprintf("```\n\n")
 
@@ -255,3 +286,20 @@ func (v ValidationReport) ToText(yamlContent string) string {
 	}
 	return result
 }
+
+// schemaInformation renders the schema version and location of a report
+func (v ValidationReport) schemaInformation() string {
+	result := fmt.Sprintf("## Schema Information\n\n")
+	result += fmt.Sprintf("- **Schema Version**: %s\n", v.SchemaVersion)
+	result += fmt.Sprintf("- **Schema URL**: https://github.com/ossf/gemara/blob/%s/schemas/layer-%d.cue\n", v.SchemaVersion, v.Layer)
+	result += fmt.Sprintf("- **Schema Repository**: https://github.com/ossf/gemara/tree/%s/schemas\n\n", v.SchemaVersion)
+	return result
+}
+
+// contentName names the format of validated content for reports
+func contentName(content string) string {
+	if storage.DetectFormat([]byte(content)) == storage.FormatJSON {
+		return "JSON"
+	}
+	return "YAML"
+}
@@ -5,6 +5,9 @@ package info
 import (
 	"testing"
 
+	"github.com/complytime/gemara-mcp-server/internal/schematest"
+	"github.com/complytime/gemara-mcp-server/storage"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -56,6 +59,31 @@ controls:
 title: "Test Policy Document"
 purpose: "Test Purpose"
 "organization-id": "Test Organization"`
+
+	// catalogYAMLL2 is valid against the schemas in schematest
+	catalogYAMLL2 = `metadata:
+  id: test-catalog-2
+  description: A test control catalog
+  author:
+    id: test
+    name: TEST
+    type: Human
+title: Test Control Catalog
+families:
+  - id: DATA
+    title: Data
+    description: Data protection
+controls:
+  - id: DATA-1
+    title: Test Control
+    objective: Test control objective
+    family: DATA
+    assessment-requirements:
+      - id: DATA-1.1
+        text: Test requirement
+        applicability:
+          - all
+`
 )
 
 // TestPerformCUEValidation_ValidLayer1 tests validation with valid Layer 1 YAML
@@ -96,3 +124,54 @@ func TestPerformCUEValidation(t *testing.T) {
 		})
 	}
 }
+
+// TestPerformCUEValidationFormats tests that JSON content is validated like the same YAML content
+func TestPerformCUEValidationFormats(t *testing.T) {
+	g, err := NewGemaraInfoToolsWithClient("main", schematest.Client())
+	require.NoError(t, err)
+	invalidYAML := catalogYAMLL2 + "unknown: true\n"
+	tests := []struct {
+		name      string
+		content   string
+		wantValid bool
+	}{
+		{
+			name:      "Valid/YAML",
+			content:   catalogYAMLL2,
+			wantValid: true,
+		},
+		{
+			name:      "Valid/JSON",
+			content:   mustConvert(t, catalogYAMLL2, storage.FormatJSON),
+			wantValid: true,
+		},
+		{
+			name:      "Invalid/YAML",
+			content:   invalidYAML,
+			wantValid: false,
+		},
+		{
+			name:      "Invalid/JSON",
+			content:   mustConvert(t, invalidYAML, storage.FormatJSON),
+			wantValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := g.PerformCUEValidation(tt.content, 2)
+			assert.Equal(t, tt.wantValid, result.Valid, result.Error)
+		})
+	}
+
+	result := g.PerformCUEValidation(mustConvert(t, invalidYAML, storage.FormatJSON), 2)
+	assert.Contains(t, result.Error, "unknown: field not allowed")
+}
+
+// mustConvert re-serializes test YAML in the given format
+func mustConvert(t *testing.T, content, format string) string {
+	t.Helper()
+	converted, err := storage.ConvertFormat([]byte(content), format)
+	require.NoError(t, err)
+	return string(converted)
+}